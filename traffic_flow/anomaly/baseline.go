@@ -0,0 +1,123 @@
+package anomaly
+
+import (
+	"math"
+	db "smart_city/traffic_flow/db/sqlc"
+	"time"
+)
+
+// Metrics a reading is scored on
+const (
+	MetricVolume = "traffic_volume"
+	MetricSpeed  = "average_speed"
+)
+
+// Kinds of anomalies
+const (
+	KindVolumeDrop  = "volume_drop"
+	KindVolumeSpike = "volume_spike"
+	KindSpeedDrop   = "speed_drop"
+	KindSpeedSpike  = "speed_spike"
+)
+
+const HoursPerWeek = 7 * 24
+
+// Smallest spreads a deviation is measured against, so a sensor whose
+// readings barely vary is not flagged for a few vehicles or km/h
+const (
+	minVolumeStddev = 2
+	minSpeedStddev  = 2
+	// minRelativeStddev is the smallest spread as a share of the mean
+	minRelativeStddev = 0.1
+)
+
+// HourOfWeek returns the hour of the week t falls into, Monday 00:00 being
+// hour 0
+func HourOfWeek(t time.Time) int {
+	return (int(t.Weekday())+6)%7*24 + t.Hour()
+}
+
+// Stats is an exponentially weighted mean and variance
+type Stats struct {
+	Samples  int     `json:"samples"`
+	Mean     float64 `json:"mean"`
+	Variance float64 `json:"variance"`
+}
+
+// Add folds a value into the moving mean and variance. Until there are
+// 1/alpha samples they are weighted evenly so a young baseline is not
+// dominated by its first values.
+func (stats *Stats) Add(value, alpha float64) {
+	weight := max(alpha, 1/float64(stats.Samples+1))
+	diff := value - stats.Mean
+	increment := weight * diff
+	stats.Mean += increment
+	stats.Variance = (1 - weight) * (stats.Variance + diff*increment)
+	stats.Samples++
+}
+
+func (stats Stats) Stddev() float64 {
+	return math.Sqrt(stats.Variance)
+}
+
+// spread is the standard deviation a deviation is measured against
+func (stats Stats) spread(floor float64) float64 {
+	return max(stats.Stddev(), floor, minRelativeStddev*math.Abs(stats.Mean))
+}
+
+// Baseline is what a sensor's readings look like in one hour of the week
+type Baseline struct {
+	Volume Stats `json:"volume"`
+	Speed  Stats `json:"speed"`
+}
+
+// Add folds a reading into the baseline
+func (baseline *Baseline) Add(reading db.TrafficDatum, alpha float64) {
+	baseline.Volume.Add(float64(reading.TrafficVolume), alpha)
+	baseline.Speed.Add(reading.AverageSpeed, alpha)
+}
+
+// Score compares a reading with the baseline of its hour of the week and
+// returns an anomaly for every metric whose z-score reaches the threshold.
+// Baselines with fewer than minSamples readings flag nothing.
+func Score(baseline Baseline, reading db.TrafficDatum, threshold float64, minSamples int) []db.CreateTrafficAnomalyParams {
+	anomalies := []db.CreateTrafficAnomalyParams{}
+
+	metrics := []struct {
+		name        string
+		stats       Stats
+		value       float64
+		floor       float64
+		drop, spike string
+	}{
+		{MetricVolume, baseline.Volume, float64(reading.TrafficVolume), minVolumeStddev, KindVolumeDrop, KindVolumeSpike},
+		{MetricSpeed, baseline.Speed, reading.AverageSpeed, minSpeedStddev, KindSpeedDrop, KindSpeedSpike},
+	}
+	for _, metric := range metrics {
+		if metric.stats.Samples < minSamples {
+			continue
+		}
+
+		spread := metric.stats.spread(metric.floor)
+		z := (metric.value - metric.stats.Mean) / spread
+		if math.Abs(z) < threshold {
+			continue
+		}
+
+		kind := metric.spike
+		if z < 0 {
+			kind = metric.drop
+		}
+		anomalies = append(anomalies, db.CreateTrafficAnomalyParams{
+			SensorID:  reading.SensorID,
+			Timestamp: reading.Timestamp,
+			Metric:    metric.name,
+			Kind:      kind,
+			Observed:  metric.value,
+			Expected:  metric.stats.Mean,
+			Stddev:    spread,
+			ZScore:    z,
+		})
+	}
+	return anomalies
+}
@@ -0,0 +1,69 @@
+package anomaly
+
+import (
+	db "smart_city/traffic_flow/db/sqlc"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHourOfWeek(t *testing.T) {
+	monday := time.Date(2026, 10, 12, 0, 30, 0, 0, time.UTC)
+	require.Equal(t, 0, HourOfWeek(monday))
+	require.Equal(t, 8, HourOfWeek(monday.Add(8*time.Hour)))
+	require.Equal(t, HoursPerWeek-1, HourOfWeek(monday.Add(6*24*time.Hour+23*time.Hour)))
+}
+
+func TestStatsAdd(t *testing.T) {
+	var stats Stats
+	stats.Add(10, 0.1)
+	require.Equal(t, 10.0, stats.Mean)
+	require.Equal(t, 0.0, stats.Variance)
+
+	// The first values are averaged evenly
+	stats.Add(20, 0.1)
+	require.InDelta(t, 15, stats.Mean, 1e-9)
+	require.InDelta(t, 25, stats.Variance, 1e-9)
+
+	for range 100 {
+		stats.Add(50, 0.1)
+	}
+	require.InDelta(t, 50, stats.Mean, 1e-3)
+	require.Less(t, stats.Stddev(), 0.5)
+}
+
+func reading(volume int32, speed float64) db.TrafficDatum {
+	return db.TrafficDatum{
+		SensorID:      7,
+		Timestamp:     pgtype.Timestamp{Time: time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC), Valid: true},
+		TrafficVolume: volume,
+		AverageSpeed:  speed,
+	}
+}
+
+func TestScore(t *testing.T) {
+	var baseline Baseline
+	for i := range 20 {
+		baseline.Add(reading(int32(480+i*2), 55+float64(i%3)), 0.1)
+	}
+
+	require.Empty(t, Score(baseline, reading(500, 56), 3.5, 8))
+
+	// Zero volume at rush hour
+	anomalies := Score(baseline, reading(0, 56), 3.5, 8)
+	require.Len(t, anomalies, 1)
+	require.Equal(t, MetricVolume, anomalies[0].Metric)
+	require.Equal(t, KindVolumeDrop, anomalies[0].Kind)
+	require.Less(t, anomalies[0].ZScore, -3.5)
+
+	// Speed collapse together with a volume surge
+	anomalies = Score(baseline, reading(900, 8), 3.5, 8)
+	require.Len(t, anomalies, 2)
+	require.Equal(t, KindVolumeSpike, anomalies[0].Kind)
+	require.Equal(t, KindSpeedDrop, anomalies[1].Kind)
+
+	// Young baselines flag nothing
+	require.Empty(t, Score(baseline, reading(0, 8), 3.5, 50))
+}
@@ -0,0 +1,98 @@
+package anomaly
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config controls the anomaly detector
+type Config struct {
+	Enabled bool
+	// Alpha is the weight of a new reading in the moving baselines
+	Alpha float64
+	// Threshold is the absolute z-score from which a reading is anomalous
+	Threshold float64
+	// MinSamples is how many readings an hour of the week needs before its
+	// baseline is trusted
+	MinSamples int
+	// History is how far back the baselines are seeded from on startup
+	History time.Duration
+	// QueueSize bounds the readings waiting to be scored; readings arriving
+	// at a full queue are not scored
+	QueueSize int
+}
+
+// LoadConfig reads the anomaly detection settings from the environment,
+// falling back to defaults for unset variables
+func LoadConfig() (Config, error) {
+	config := Config{
+		Enabled:    true,
+		Alpha:      0.1,
+		Threshold:  3.5,
+		MinSamples: 8,
+		History:    28 * 24 * time.Hour,
+		QueueSize:  10000,
+	}
+
+	if value := os.Getenv("ANOMALY_ENABLED"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return config, fmt.Errorf("cannot parse ANOMALY_ENABLED: %w", err)
+		}
+		config.Enabled = enabled
+	}
+
+	floats := map[string]*float64{
+		"ANOMALY_ALPHA":       &config.Alpha,
+		"ANOMALY_Z_THRESHOLD": &config.Threshold,
+	}
+	for key, target := range floats {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return config, fmt.Errorf("cannot parse %s: %w", key, err)
+		}
+		*target = number
+	}
+
+	ints := map[string]*int{
+		"ANOMALY_MIN_SAMPLES": &config.MinSamples,
+		"ANOMALY_QUEUE_SIZE":  &config.QueueSize,
+	}
+	for key, target := range ints {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return config, fmt.Errorf("cannot parse %s: %w", key, err)
+		}
+		*target = number
+	}
+
+	if value := os.Getenv("ANOMALY_HISTORY"); value != "" {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return config, fmt.Errorf("cannot parse ANOMALY_HISTORY: %w", err)
+		}
+		config.History = duration
+	}
+
+	if config.Alpha <= 0 || config.Alpha >= 1 {
+		return config, fmt.Errorf("ANOMALY_ALPHA must be between 0 and 1")
+	}
+	if config.Threshold <= 0 {
+		return config, fmt.Errorf("ANOMALY_Z_THRESHOLD must be positive")
+	}
+	if config.QueueSize < 1 {
+		return config, fmt.Errorf("ANOMALY_QUEUE_SIZE must be positive")
+	}
+
+	return config, nil
+}
@@ -0,0 +1,198 @@
+package anomaly
+
+import (
+	"context"
+	db "smart_city/traffic_flow/db/sqlc"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+// Listener is notified of every anomaly the detector stores
+type Listener func(anomaly db.TrafficAnomaly)
+
+// DetectorStats is a snapshot of the detector's state
+type DetectorStats struct {
+	Enabled       bool   `json:"enabled"`
+	Sensors       int    `json:"sensors"`
+	QueueDepth    int    `json:"queue_depth"`
+	QueueCapacity int    `json:"queue_capacity"`
+	Scored        uint64 `json:"scored"`
+	Anomalies     uint64 `json:"anomalies"`
+	// Dropped readings arrived while the queue was full and were not scored
+	Dropped uint64 `json:"dropped"`
+}
+
+// Detector scores new readings against a seasonal baseline of their sensor:
+// an exponentially weighted mean and variance of volume and speed for each
+// hour of the week. Readings are queued by Observe and scored in the
+// background by Run so ingestion never waits on it.
+type Detector struct {
+	store    *db.Store
+	config   Config
+	readings chan db.TrafficDatum
+
+	mu        sync.Mutex
+	baselines map[int32]*[HoursPerWeek]Baseline
+
+	listenerMu   sync.RWMutex
+	listeners    map[int]Listener
+	nextListener int
+
+	scored    atomic.Uint64
+	anomalies atomic.Uint64
+	dropped   atomic.Uint64
+}
+
+func NewDetector(store *db.Store, config Config) *Detector {
+	return &Detector{
+		store:     store,
+		config:    config,
+		readings:  make(chan db.TrafficDatum, config.QueueSize),
+		baselines: make(map[int32]*[HoursPerWeek]Baseline),
+		listeners: make(map[int]Listener),
+	}
+}
+
+// OnAnomaly registers a listener for detected anomalies and returns a
+// function that removes it again
+func (detector *Detector) OnAnomaly(listener Listener) (remove func()) {
+	detector.listenerMu.Lock()
+	defer detector.listenerMu.Unlock()
+
+	id := detector.nextListener
+	detector.nextListener++
+	detector.listeners[id] = listener
+
+	return func() {
+		detector.listenerMu.Lock()
+		defer detector.listenerMu.Unlock()
+		delete(detector.listeners, id)
+	}
+}
+
+func (detector *Detector) notify(anomaly db.TrafficAnomaly) {
+	detector.listenerMu.RLock()
+	defer detector.listenerMu.RUnlock()
+	for _, listener := range detector.listeners {
+		listener(anomaly)
+	}
+}
+
+// Observe queues written readings for scoring. It accepts what the ingest
+// service passes its listeners, a single reading or a batch.
+func (detector *Detector) Observe(data any) {
+	switch readings := data.(type) {
+	case db.TrafficDatum:
+		detector.enqueue(readings)
+	case []db.TrafficDatum:
+		for _, reading := range readings {
+			detector.enqueue(reading)
+		}
+	}
+}
+
+func (detector *Detector) enqueue(reading db.TrafficDatum) {
+	select {
+	case detector.readings <- reading:
+	default:
+		detector.dropped.Add(1)
+	}
+}
+
+// Seed replaces the baselines with the statistics of the readings within
+// the configured history
+func (detector *Detector) Seed(ctx context.Context) error {
+	since := time.Now().UTC().Add(-detector.config.History)
+	rows, err := detector.store.GetSeasonalBaselines(ctx, pgtype.Timestamp{Time: since, Valid: true})
+	if err != nil {
+		return err
+	}
+
+	baselines := make(map[int32]*[HoursPerWeek]Baseline)
+	for _, row := range rows {
+		if row.HourOfWeek < 0 || row.HourOfWeek >= HoursPerWeek {
+			continue
+		}
+		week, ok := baselines[row.SensorID]
+		if !ok {
+			week = new([HoursPerWeek]Baseline)
+			baselines[row.SensorID] = week
+		}
+		week[row.HourOfWeek] = Baseline{
+			Volume: Stats{Samples: int(row.Samples), Mean: row.MeanVolume, Variance: row.StddevVolume * row.StddevVolume},
+			Speed:  Stats{Samples: int(row.Samples), Mean: row.MeanSpeed, Variance: row.StddevSpeed * row.StddevSpeed},
+		}
+	}
+
+	detector.mu.Lock()
+	detector.baselines = baselines
+	detector.mu.Unlock()
+	return nil
+}
+
+// Run seeds the baselines and then scores queued readings until ctx is done
+func (detector *Detector) Run(ctx context.Context) {
+	if err := detector.Seed(ctx); err != nil {
+		log.Error().Err(err).Msg("cannot seed anomaly baselines")
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case reading := <-detector.readings:
+			detector.process(ctx, reading)
+		}
+	}
+}
+
+// score compares a reading with its baseline and then folds it in
+func (detector *Detector) score(reading db.TrafficDatum) []db.CreateTrafficAnomalyParams {
+	detector.mu.Lock()
+	defer detector.mu.Unlock()
+
+	week, ok := detector.baselines[reading.SensorID]
+	if !ok {
+		week = new([HoursPerWeek]Baseline)
+		detector.baselines[reading.SensorID] = week
+	}
+	baseline := &week[HourOfWeek(reading.Timestamp.Time)]
+
+	anomalies := Score(*baseline, reading, detector.config.Threshold, detector.config.MinSamples)
+	baseline.Add(reading, detector.config.Alpha)
+	return anomalies
+}
+
+func (detector *Detector) process(ctx context.Context, reading db.TrafficDatum) {
+	detector.scored.Add(1)
+
+	for _, arg := range detector.score(reading) {
+		anomaly, err := detector.store.CreateTrafficAnomaly(ctx, arg)
+		if err != nil {
+			log.Error().Err(err).Int32("sensor_id", arg.SensorID).Msg("cannot store traffic anomaly")
+			continue
+		}
+		detector.anomalies.Add(1)
+		detector.notify(anomaly)
+	}
+}
+
+func (detector *Detector) Stats() DetectorStats {
+	detector.mu.Lock()
+	sensors := len(detector.baselines)
+	detector.mu.Unlock()
+
+	return DetectorStats{
+		Enabled:       detector.config.Enabled,
+		Sensors:       sensors,
+		QueueDepth:    len(detector.readings),
+		QueueCapacity: cap(detector.readings),
+		Scored:        detector.scored.Load(),
+		Anomalies:     detector.anomalies.Load(),
+		Dropped:       detector.dropped.Load(),
+	}
+}
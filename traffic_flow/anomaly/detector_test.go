@@ -0,0 +1,37 @@
+package anomaly
+
+import (
+	db "smart_city/traffic_flow/db/sqlc"
+	"smart_city/traffic_flow/ingest"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectorScoresZeroVolume(t *testing.T) {
+	config, err := LoadConfig()
+	require.NoError(t, err)
+	detector := NewDetector(nil, config)
+
+	for i := range 20 {
+		require.Empty(t, detector.score(reading(int32(480+i*2), 55+float64(i%3))))
+	}
+
+	// A sensor reporting no vehicles at rush hour passes ingestion and is
+	// scored like any other reading
+	volume, speed := int32(0), 56.0
+	zero := ingest.Reading{SensorID: 7, TrafficVolume: &volume, AverageSpeed: &speed}
+	require.NoError(t, zero.Validate())
+
+	anomalies := detector.score(db.TrafficDatum{
+		SensorID:      zero.SensorID,
+		Timestamp:     pgtype.Timestamp{Time: time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC), Valid: true},
+		TrafficVolume: *zero.TrafficVolume,
+		AverageSpeed:  *zero.AverageSpeed,
+	})
+	require.Len(t, anomalies, 1)
+	require.Equal(t, KindVolumeDrop, anomalies[0].Kind)
+	require.Equal(t, 0.0, anomalies[0].Observed)
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	authorizationHeaderKey  = "authorization"
+	authorizationTypeBearer = "bearer"
+)
+
+var (
+	errAdminDisabled        = errors.New("the admin API is disabled; set ADMIN_API_TOKEN to enable it")
+	errAdminTokenMissing    = errors.New("authorization header is not provided")
+	errAdminTokenMalformed  = errors.New("invalid authorization header format")
+	errAdminTokenNotAllowed = errors.New("invalid admin token")
+)
+
+// adminMiddleware only lets requests carrying the configured admin token
+// as a bearer token through. The admin routes refresh rollups and change or
+// drop stored readings, so without a configured token they are refused
+// altogether.
+func (server *Server) adminMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if server.config.AdminToken == "" {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(errAdminDisabled))
+			return
+		}
+
+		authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
+		if len(authorizationHeader) == 0 {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(errAdminTokenMissing))
+			return
+		}
+
+		fields := strings.Fields(authorizationHeader)
+		if len(fields) != 2 || strings.ToLower(fields[0]) != authorizationTypeBearer {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(errAdminTokenMalformed))
+			return
+		}
+
+		if subtle.ConstantTimeCompare([]byte(fields[1]), []byte(server.config.AdminToken)) != 1 {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(errAdminTokenNotAllowed))
+			return
+		}
+		ctx.Next()
+	}
+}
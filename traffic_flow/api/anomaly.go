@@ -0,0 +1,76 @@
+package api
+
+import (
+	"net/http"
+	db "smart_city/traffic_flow/db/sqlc"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Readings that were abnormal for their sensor at that hour of the week
+
+// defaultAnomalyRange is the time range of an anomaly listing without
+// start_time
+const defaultAnomalyRange = 24 * time.Hour
+
+// trafficAnomalyEvent is pushed to WebSocket clients for every anomaly
+type trafficAnomalyEvent struct {
+	Event string `json:"event"`
+	db.TrafficAnomaly
+}
+
+func (server *Server) broadcastTrafficAnomaly(trafficAnomaly db.TrafficAnomaly) {
+	server.broadcastTrafficUpdate(trafficAnomalyEvent{Event: "traffic_anomaly", TrafficAnomaly: trafficAnomaly})
+}
+
+type listTrafficAnomaliesRequest struct {
+	SensorID  *int32     `form:"sensor_id" binding:"omitempty,min=1"`
+	Kind      string     `form:"kind" binding:"omitempty,oneof=volume_drop volume_spike speed_drop speed_spike"`
+	StartTime *time.Time `form:"start_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndTime   *time.Time `form:"end_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit     int32      `form:"limit" binding:"omitempty,min=1,max=1000"`
+}
+
+// listTrafficAnomalies returns detected anomalies newest first. Without
+// end_time the range ends now and without start_time it covers the
+// preceding 24 hours.
+func (server *Server) listTrafficAnomalies(ctx *gin.Context) {
+	req := listTrafficAnomaliesRequest{Limit: 100}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	endTime := time.Now().UTC()
+	if req.EndTime != nil {
+		endTime = req.EndTime.UTC()
+	}
+	startTime := endTime.Add(-defaultAnomalyRange)
+	if req.StartTime != nil {
+		startTime = req.StartTime.UTC()
+	}
+	if !startTime.Before(endTime) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "start_time must be before end_time"})
+		return
+	}
+
+	anomalies, err := server.store.ListTrafficAnomalies(ctx, db.ListTrafficAnomaliesParams{
+		StartTime: pgtype.Timestamp{Time: startTime, Valid: true},
+		EndTime:   pgtype.Timestamp{Time: endTime, Valid: true},
+		SensorID:  optionalInt4(req.SensorID),
+		Kind:      pgtype.Text{String: req.Kind, Valid: req.Kind != ""},
+		RowLimit:  req.Limit,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, anomalies)
+}
+
+func (server *Server) getAnomalyStats(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, server.anomalies.Stats())
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"smart_city/traffic_flow/cityindex"
+	db "smart_city/traffic_flow/db/sqlc"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// congestionIndexEvent is pushed to WebSocket clients for every computed
+// index value
+type congestionIndexEvent struct {
+	Event string `json:"event"`
+	db.CongestionIndex
+}
+
+func (server *Server) broadcastCongestionIndex(index db.CongestionIndex) {
+	server.broadcastTrafficUpdate(congestionIndexEvent{Event: "congestion_index", CongestionIndex: index})
+}
+
+// congestionIndexViews are the bucket widths and default ranges of the
+// aggregated views
+var congestionIndexViews = map[string]struct {
+	width        time.Duration
+	defaultRange time.Duration
+}{
+	"hourly": {time.Hour, 24 * time.Hour},
+	"daily":  {24 * time.Hour, 30 * 24 * time.Hour},
+}
+
+type congestionIndexRequest struct {
+	View      string     `form:"view" binding:"oneof=current hourly daily"`
+	StartTime *time.Time `form:"start_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndTime   *time.Time `form:"end_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	qualityFilter
+}
+
+type congestionIndexResponse struct {
+	View      string                            `json:"view"`
+	StartTime time.Time                         `json:"start_time"`
+	EndTime   time.Time                         `json:"end_time"`
+	Buckets   []db.GetCongestionIndexBucketsRow `json:"buckets"`
+}
+
+// getCongestionIndex returns the city-wide congestion index: by default
+// the latest value next to its typical value at that hour of the week, or
+// with view=hourly or view=daily the index averaged per hour or day.
+// Without end_time the range ends now and without start_time it covers the
+// preceding 24 hours for hourly and 30 days for daily. With
+// exclude_low_quality=true the index is computed from the readings of the
+// other sensors instead of read from the stored series, and the current
+// view has no typical value.
+func (server *Server) getCongestionIndex(ctx *gin.Context) {
+	req := congestionIndexRequest{View: "current"}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var sensorIDs []int32
+	if req.ExcludeLowQuality {
+		var err error
+		sensorIDs, err = server.excludeLowQuality(ctx, req.qualityFilter, nil)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	view, ok := congestionIndexViews[req.View]
+	if !ok {
+		var current cityindex.Current
+		var err error
+		if req.ExcludeLowQuality {
+			current, err = server.congestionIndex.CurrentOf(ctx, sensorIDs)
+		} else {
+			current, err = server.congestionIndex.Current(ctx)
+		}
+		if err != nil {
+			ctx.JSON(congestionIndexErrorStatus(err), errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusOK, current)
+		return
+	}
+
+	startTime, endTime, err := resolveTimeRange(req.StartTime, req.EndTime, view.defaultRange)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var buckets []db.GetCongestionIndexBucketsRow
+	if req.ExcludeLowQuality {
+		buckets, err = server.congestionIndex.BucketsOf(ctx, view.width, startTime, endTime, sensorIDs)
+	} else {
+		buckets, err = server.congestionIndex.Buckets(ctx, view.width, startTime, endTime)
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, congestionIndexResponse{
+		View:      req.View,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Buckets:   buckets,
+	})
+}
+
+// congestionIndexErrorStatus maps a congestion index error to an HTTP status
+func congestionIndexErrorStatus(err error) int {
+	if errors.Is(err, cityindex.ErrNoIndex) || errors.Is(err, cityindex.ErrNoReadings) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
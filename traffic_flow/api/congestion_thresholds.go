@@ -0,0 +1,147 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"smart_city/traffic_flow/catalog"
+	"smart_city/traffic_flow/congestion"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Thresholds used to derive congestion levels, editable per sensor type and
+// per sensor on top of a city-wide default
+
+func (server *Server) listCongestionThresholds(ctx *gin.Context) {
+	thresholds, err := server.congestion.List(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, thresholds)
+}
+
+func (server *Server) setDefaultCongestionThresholds(ctx *gin.Context) {
+	var req congestion.Thresholds
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	thresholds, err := server.congestion.SetDefault(ctx, req)
+	if err != nil {
+		ctx.JSON(congestionErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, thresholds)
+}
+
+func (server *Server) setSensorTypeCongestionThresholds(ctx *gin.Context) {
+	var uri getSensorTypeRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req congestion.Thresholds
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	thresholds, err := server.congestion.SetForSensorType(ctx, uri.TypeID, req)
+	if err != nil {
+		ctx.JSON(congestionErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, thresholds)
+}
+
+func (server *Server) deleteSensorTypeCongestionThresholds(ctx *gin.Context) {
+	var uri getSensorTypeRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := server.congestion.DeleteForSensorType(ctx, uri.TypeID); err != nil {
+		ctx.JSON(congestionErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"detail": "sensor type thresholds deleted, default applies"})
+}
+
+// getSensorCongestionThresholds returns the thresholds currently applied to
+// a sensor's readings and where they come from
+func (server *Server) getSensorCongestionThresholds(ctx *gin.Context) {
+	var uri getSensorRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	thresholds, source, err := server.congestion.Effective(ctx, uri.SensorID)
+	if err != nil {
+		ctx.JSON(congestionErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"sensor_id":  uri.SensorID,
+		"source":     source,
+		"thresholds": thresholds,
+	})
+}
+
+func (server *Server) setSensorCongestionThresholds(ctx *gin.Context) {
+	var uri getSensorRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req congestion.Thresholds
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	thresholds, err := server.congestion.SetForSensor(ctx, uri.SensorID, req)
+	if err != nil {
+		ctx.JSON(congestionErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, thresholds)
+}
+
+func (server *Server) deleteSensorCongestionThresholds(ctx *gin.Context) {
+	var uri getSensorRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := server.congestion.DeleteForSensor(ctx, uri.SensorID); err != nil {
+		ctx.JSON(congestionErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"detail": "sensor thresholds deleted, sensor type or default applies"})
+}
+
+// congestionErrorStatus maps a congestion service error to an HTTP status
+func congestionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, catalog.ErrInvalidRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, congestion.ErrNotFound), errors.Is(err, congestion.ErrNoThresholds):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,233 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"smart_city/traffic_flow/catalog"
+	"smart_city/traffic_flow/corridor"
+	"smart_city/traffic_flow/rollup"
+	"smart_city/traffic_flow/series"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultCorridorSeriesRange is the time range of a travel-time series
+// request without start_time
+const defaultCorridorSeriesRange = 24 * time.Hour
+
+type getCorridorRequest struct {
+	CorridorID int32 `uri:"corridor_id" binding:"required,min=1"`
+}
+
+func (server *Server) createCorridor(ctx *gin.Context) {
+	var req corridor.CorridorRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.corridors.Create(ctx, req)
+	if err != nil {
+		ctx.JSON(corridorErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, result)
+}
+
+func (server *Server) listCorridors(ctx *gin.Context) {
+	corridors, err := server.corridors.List(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, corridors)
+}
+
+func (server *Server) getCorridor(ctx *gin.Context) {
+	var uri getCorridorRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.corridors.Get(ctx, uri.CorridorID)
+	if err != nil {
+		ctx.JSON(corridorErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// updateCorridor replaces a corridor's attributes and its segments
+func (server *Server) updateCorridor(ctx *gin.Context) {
+	var uri getCorridorRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req corridor.CorridorRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.corridors.Update(ctx, uri.CorridorID, req)
+	if err != nil {
+		ctx.JSON(corridorErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (server *Server) deleteCorridor(ctx *gin.Context) {
+	var uri getCorridorRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := server.corridors.Delete(ctx, uri.CorridorID); err != nil {
+		ctx.JSON(corridorErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"detail": "corridor deleted"})
+}
+
+// getCorridorTravelTime estimates the current travel time and delay of a
+// corridor from the latest speed of each of its sensors
+func (server *Server) getCorridorTravelTime(ctx *gin.Context) {
+	var uri getCorridorRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var filter qualityFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	low, err := server.lowQualitySensors(ctx, filter)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	result, err := server.corridors.Live(ctx, uri.CorridorID, low)
+	if err != nil {
+		ctx.JSON(corridorErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+type corridorTravelTimeSeriesRequest struct {
+	Bucket    string     `form:"bucket" binding:"required"`
+	StartTime *time.Time `form:"start_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndTime   *time.Time `form:"end_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	qualityFilter
+	// Fill gapfills the series, filling the speeds of sensors without
+	// readings with null, locf or linear
+	Fill string `form:"fill"`
+}
+
+type corridorTravelTimeSeriesResponse struct {
+	CorridorID int32            `json:"corridor_id"`
+	Bucket     string           `json:"bucket"`
+	Source     rollup.Source    `json:"source"`
+	StartTime  time.Time        `json:"start_time"`
+	EndTime    time.Time        `json:"end_time"`
+	Fill       series.Fill      `json:"fill,omitempty"`
+	Points     []corridor.Point `json:"points"`
+}
+
+// getCorridorTravelTimeSeries estimates a corridor's travel time per bucket
+// from the average speeds of its sensors. Without end_time the range ends
+// now and without start_time it covers the preceding 24 hours; buckets
+// without any reading are left out. With fill every bucket gets a point,
+// flagged as filled when a sensor had no readings in it, whose speed is then
+// carried forward (locf), interpolated (linear) or, with null, left out so
+// that its segment counts as flowing freely.
+func (server *Server) getCorridorTravelTimeSeries(ctx *gin.Context) {
+	var uri getCorridorRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req corridorTravelTimeSeriesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	bucket, err := series.ParseBucket(req.Bucket)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var fill series.Fill
+	if req.Fill != "" {
+		fill, err = series.ParseFill(req.Fill)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	startTime, endTime, err := resolveTimeRange(req.StartTime, req.EndTime, defaultCorridorSeriesRange)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := bucket.Check(startTime, endTime); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	low, err := server.lowQualitySensors(ctx, req.qualityFilter)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	points, plan, err := server.corridors.Series(ctx, uri.CorridorID, bucket.Width, startTime, endTime, low, fill)
+	if err != nil {
+		ctx.JSON(corridorErrorStatus(err), errorResponse(err))
+		return
+	}
+	setDataSource(ctx, plan)
+
+	ctx.JSON(http.StatusOK, corridorTravelTimeSeriesResponse{
+		CorridorID: uri.CorridorID,
+		Bucket:     bucket.String(),
+		Source:     plan.Source,
+		StartTime:  plan.StartTime,
+		EndTime:    plan.EndTime,
+		Fill:       fill,
+		Points:     points,
+	})
+}
+
+// corridorErrorStatus maps a corridor service error to an HTTP status
+func corridorErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, catalog.ErrInvalidRequest), errors.Is(err, corridor.ErrUnknownSensor):
+		return http.StatusBadRequest
+	case errors.Is(err, corridor.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, corridor.ErrDuplicateName):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
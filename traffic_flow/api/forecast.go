@@ -0,0 +1,59 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"smart_city/traffic_flow/forecast"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type trafficForecastRequest struct {
+	SensorID int32  `form:"sensor_id" binding:"required,min=1"`
+	Horizon  string `form:"horizon"`
+	Level    int    `form:"level"`
+}
+
+// getTrafficForecast predicts a sensor's volume and speed per step until
+// the horizon, one hour by default, with 95% prediction intervals unless
+// another level is requested
+func (server *Server) getTrafficForecast(ctx *gin.Context) {
+	req := trafficForecastRequest{Horizon: "1h", Level: 95}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	horizon, err := time.ParseDuration(req.Horizon)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.forecasts.Forecast(ctx, req.SensorID, horizon, req.Level)
+	if err != nil {
+		ctx.JSON(forecastErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// listForecastAccuracy reports the backtest MAPE of every sensor's current
+// models
+func (server *Server) listForecastAccuracy(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, server.forecasts.Models())
+}
+
+// forecastErrorStatus maps a forecast service error to an HTTP status
+func forecastErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, forecast.ErrInvalidHorizon), errors.Is(err, forecast.ErrInvalidLevel):
+		return http.StatusBadRequest
+	case errors.Is(err, forecast.ErrInsufficientHistory):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	db "smart_city/traffic_flow/db/sqlc"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+	idempotencyPurgeInterval = time.Hour
+	defaultIdempotencyKeyTTL = 24 * time.Hour
+	// idempotencyClaimLease is how long a key stays claimed before its
+	// response is stored, so that a crashed request frees it again
+	idempotencyClaimLease    = time.Minute
+	idempotencyInFlightRetry = "1"
+)
+
+var (
+	errIdempotencyKeyTooLong  = fmt.Errorf("%s must be at most %d characters", idempotencyKeyHeader, maxIdempotencyKeyLength)
+	errIdempotencyKeyMismatch = fmt.Errorf("%s was already used for a different request", idempotencyKeyHeader)
+	errIdempotencyKeyInFlight = fmt.Errorf("a request with this %s is still being processed", idempotencyKeyHeader)
+)
+
+// loadIdempotencyTTL reads how long stored responses are replayed
+func loadIdempotencyTTL() (time.Duration, error) {
+	value := os.Getenv("IDEMPOTENCY_KEY_TTL")
+	if value == "" {
+		return defaultIdempotencyKeyTTL, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("cannot parse IDEMPOTENCY_KEY_TTL: %w", err)
+	}
+	if ttl <= 0 {
+		return 0, fmt.Errorf("IDEMPOTENCY_KEY_TTL must be positive")
+	}
+	return ttl, nil
+}
+
+// responseRecorder keeps a copy of everything written to the client so the
+// response can be stored under its idempotency key
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// idempotencyMiddleware makes POST and PUT requests carrying an
+// Idempotency-Key header safe to retry. The first request with a key runs
+// normally and its response is stored for IdempotencyTTL; a retry with the
+// same key and body gets the stored status and body back without running
+// the handler again. Server errors and 429s are not stored so the client
+// can retry them for real. Until its response is stored a key is only held
+// for idempotencyClaimLease, and a panicking handler releases it at once.
+//
+// user_management has its own copy, as the services are separate modules.
+// There keys are scoped to the authenticated user; this API has no users,
+// so a key is shared by every client of a route.
+func (server *Server) idempotencyMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(idempotencyKeyHeader)
+		method := ctx.Request.Method
+		if key == "" || (method != http.MethodPost && method != http.MethodPut) {
+			ctx.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errIdempotencyKeyTooLong))
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, int64(server.config.MaxBodySize)))
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, errorResponse(err))
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		scope := method + " " + ctx.Request.URL.Path
+		hash := sha256.New()
+		hash.Write([]byte(ctx.Request.URL.RawQuery))
+		hash.Write([]byte{0})
+		hash.Write(body)
+		requestHash := hash.Sum(nil)
+
+		_, err = server.store.ClaimIdempotencyKey(ctx, db.ClaimIdempotencyKeyParams{
+			IdempotencyKey: key,
+			Scope:          scope,
+			RequestHash:    requestHash,
+			ExpiresAt:      pgtype.Timestamptz{Time: time.Now().Add(idempotencyClaimLease), Valid: true},
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			server.replayIdempotentResponse(ctx, key, scope, requestHash)
+			return
+		}
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		// The response is already on its way, so the bookkeeping must not be
+		// cut short by a client that disconnects
+		storeCtx := context.WithoutCancel(ctx.Request.Context())
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				if err := server.store.DeleteIdempotencyKey(storeCtx, db.DeleteIdempotencyKeyParams{IdempotencyKey: key, Scope: scope}); err != nil {
+					log.Error().Err(err).Str("scope", scope).Msg("cannot release idempotency key")
+				}
+				panic(recovered)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = recorder
+		ctx.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError || status == http.StatusTooManyRequests {
+			err = server.store.DeleteIdempotencyKey(storeCtx, db.DeleteIdempotencyKeyParams{IdempotencyKey: key, Scope: scope})
+		} else {
+			err = server.store.SaveIdempotentResponse(storeCtx, db.SaveIdempotentResponseParams{
+				IdempotencyKey: key,
+				Scope:          scope,
+				StatusCode:     pgtype.Int4{Int32: int32(status), Valid: true},
+				ContentType:    pgtype.Text{String: recorder.Header().Get("Content-Type"), Valid: true},
+				ResponseBody:   recorder.body.Bytes(),
+				ExpiresAt:      pgtype.Timestamptz{Time: time.Now().Add(server.config.IdempotencyTTL), Valid: true},
+			})
+		}
+		if err != nil {
+			log.Error().Err(err).Str("scope", scope).Msg("cannot store idempotent response")
+		}
+	}
+}
+
+// replayIdempotentResponse answers a request whose key is already taken
+func (server *Server) replayIdempotentResponse(ctx *gin.Context, key, scope string, requestHash []byte) {
+	stored, err := server.store.GetIdempotencyKey(ctx, db.GetIdempotencyKeyParams{IdempotencyKey: key, Scope: scope})
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !bytes.Equal(stored.RequestHash, requestHash) {
+		ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, errorResponse(errIdempotencyKeyMismatch))
+		return
+	}
+	if !stored.StatusCode.Valid {
+		ctx.Header("Retry-After", idempotencyInFlightRetry)
+		ctx.AbortWithStatusJSON(http.StatusConflict, errorResponse(errIdempotencyKeyInFlight))
+		return
+	}
+
+	ctx.Header(idempotentReplayedHeader, "true")
+	ctx.Data(int(stored.StatusCode.Int32), stored.ContentType.String, stored.ResponseBody)
+	ctx.Abort()
+}
+
+// purgeIdempotencyKeys periodically removes expired idempotency keys
+func (server *Server) purgeIdempotencyKeys() {
+	ticker := time.NewTicker(idempotencyPurgeInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		deleted, err := server.store.DeleteExpiredIdempotencyKeys(context.Background())
+		if err != nil {
+			log.Error().Err(err).Msg("cannot purge expired idempotency keys")
+			continue
+		}
+		if deleted > 0 {
+			log.Debug().Int64("deleted", deleted).Msg("purged expired idempotency keys")
+		}
+	}
+}
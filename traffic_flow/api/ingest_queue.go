@@ -0,0 +1,48 @@
+package api
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"smart_city/traffic_flow/ingest"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// enqueueTrafficData hands a reading to the write-behind queue. The reading
+// is validated up front, but it is written later, so the client receives 202
+// instead of the stored row.
+func (server *Server) enqueueTrafficData(ctx *gin.Context, reading ingest.Reading) {
+	err := server.queue.Enqueue(reading)
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusAccepted, gin.H{"detail": "reading queued"})
+	case errors.Is(err, ingest.ErrInvalidReading):
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	case errors.Is(err, ingest.ErrTimestampTooOld), errors.Is(err, ingest.ErrTimestampInFuture):
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+	case errors.Is(err, ingest.ErrQueueFull):
+		server.setRetryAfter(ctx)
+		ctx.JSON(http.StatusTooManyRequests, errorResponse(err))
+	case errors.Is(err, ingest.ErrQueueClosed):
+		server.setRetryAfter(ctx)
+		ctx.JSON(http.StatusServiceUnavailable, errorResponse(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	}
+}
+
+func (server *Server) setRetryAfter(ctx *gin.Context) {
+	seconds := math.Ceil(server.queue.RetryAfter().Seconds())
+	ctx.Header("Retry-After", strconv.Itoa(int(seconds)))
+}
+
+func (server *Server) getIngestStats(ctx *gin.Context) {
+	if server.queue == nil {
+		ctx.JSON(http.StatusOK, gin.H{"mode": "sync"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"mode": "async", "queue": server.queue.Stats()})
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	db "smart_city/traffic_flow/db/sqlc"
+	"smart_city/traffic_flow/ingest"
+	"smart_city/traffic_flow/lineproto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type lineResult struct {
+	Line     int           `json:"line"`
+	SensorID int32         `json:"sensor_id,omitempty"`
+	Status   db.RowOutcome `json:"status"`
+	Error    string        `json:"error"`
+}
+
+type writeLineProtocolResponse struct {
+	Accepted    int          `json:"accepted"`
+	Skipped     int          `json:"skipped"`
+	Quarantined int          `json:"quarantined"`
+	Rejected    int          `json:"rejected"`
+	Errors      []lineResult `json:"errors"`
+}
+
+// writeLineProtocol ingests readings in Influx line protocol, so agents such
+// as Telegraf can write to us directly. Like Influx it answers 204 when every
+// line was written; otherwise the lines that failed are listed. All lines of
+// a body are written or none are, so a body is capped at maxBatchRows lines
+// like a JSON batch.
+func (server *Server) writeLineProtocol(ctx *gin.Context) {
+	precision, err := lineproto.ParsePrecision(ctx.Query("precision"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var body io.Reader = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, int64(server.config.MaxBodySize))
+	if ctx.GetHeader("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		defer gz.Close()
+		body = io.LimitReader(gz, int64(server.config.MaxBodySize))
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("cannot read body: %w", err)))
+		return
+	}
+
+	lines := lineproto.Parse(data, precision)
+	if len(lines) == 0 {
+		ctx.Status(http.StatusNoContent)
+		return
+	}
+	if len(lines) > maxBatchRows {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("body exceeds %d lines", maxBatchRows)})
+		return
+	}
+
+	entries := make([]ingest.BatchEntry, len(lines))
+	for i, line := range lines {
+		entries[i] = line.Entry
+	}
+
+	// The whole body is written in one transaction, so a failure leaves
+	// nothing behind and the client can retry it as is
+	result, err := server.ingester.RecordBatch(ctx, entries)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := writeLineProtocolResponse{
+		Accepted:    result.Accepted,
+		Skipped:     result.Skipped,
+		Quarantined: result.Quarantined,
+		Rejected:    result.Rejected,
+		Errors:      []lineResult{},
+	}
+	for _, row := range result.Results {
+		if row.Status != db.RowRejected && row.Status != db.RowQuarantined {
+			continue
+		}
+		rsp.Errors = append(rsp.Errors, lineResult{
+			Line:     lines[row.Index].Number,
+			SensorID: row.SensorID,
+			Status:   row.Status,
+			Error:    row.Error,
+		})
+	}
+
+	switch {
+	case rsp.Rejected == 0 && rsp.Quarantined == 0:
+		ctx.Status(http.StatusNoContent)
+	case rsp.Rejected == len(lines):
+		ctx.JSON(http.StatusBadRequest, rsp)
+	default:
+		ctx.JSON(http.StatusMultiStatus, rsp)
+	}
+}
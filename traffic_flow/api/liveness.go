@@ -0,0 +1,116 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"smart_city/traffic_flow/catalog"
+	db "smart_city/traffic_flow/db/sqlc"
+	"smart_city/traffic_flow/liveness"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Silence thresholds after which the liveness monitor marks sensors stale
+// or silent, and the transitions it made
+
+// sensorStatusEvent is pushed to WebSocket clients for every transition
+type sensorStatusEvent struct {
+	Event string `json:"event"`
+	db.SensorStatusTransition
+}
+
+func (server *Server) broadcastSensorStatus(transition db.SensorStatusTransition) {
+	server.broadcastTrafficUpdate(sensorStatusEvent{Event: "sensor_status", SensorStatusTransition: transition})
+}
+
+func (server *Server) listLivenessThresholds(ctx *gin.Context) {
+	thresholds, err := server.liveness.List(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"default":      server.liveness.Defaults(),
+		"sensor_types": thresholds,
+	})
+}
+
+func (server *Server) setSensorTypeLivenessThresholds(ctx *gin.Context) {
+	var uri getSensorTypeRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req liveness.Thresholds
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	thresholds, err := server.liveness.SetForSensorType(ctx, uri.TypeID, req)
+	if err != nil {
+		ctx.JSON(livenessErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, thresholds)
+}
+
+func (server *Server) deleteSensorTypeLivenessThresholds(ctx *gin.Context) {
+	var uri getSensorTypeRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := server.liveness.DeleteForSensorType(ctx, uri.TypeID); err != nil {
+		ctx.JSON(livenessErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"detail": "sensor type liveness thresholds deleted, default applies"})
+}
+
+type listSensorStatusTransitionsRequest struct {
+	Limit int32 `form:"limit" binding:"omitempty,min=1,max=1000"`
+}
+
+// listSensorStatusTransitions returns a sensor's status changes, newest first
+func (server *Server) listSensorStatusTransitions(ctx *gin.Context) {
+	var uri getSensorRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	req := listSensorStatusTransitionsRequest{Limit: 50}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	transitions, err := server.store.ListSensorStatusTransitions(ctx, db.ListSensorStatusTransitionsParams{
+		SensorID: uri.SensorID,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, transitions)
+}
+
+// livenessErrorStatus maps a liveness monitor error to an HTTP status
+func livenessErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, catalog.ErrInvalidRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, liveness.ErrNotFound), errors.Is(err, liveness.ErrNoThresholds):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
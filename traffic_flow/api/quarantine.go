@@ -0,0 +1,176 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	db "smart_city/traffic_flow/db/sqlc"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Inspection and handling of readings quarantined because their sensor was
+// unknown or not active when they arrived
+
+type listQuarantineRequest struct {
+	SensorID *int32 `form:"sensor_id" binding:"omitempty,min=1"`
+	Reason   string `form:"reason" binding:"omitempty,oneof=unknown_sensor inactive_sensor write_failed"`
+	PageID   int32  `form:"page_id" binding:"omitempty,min=1"`
+	PageSize int32  `form:"page_size" binding:"omitempty,min=1,max=1000"`
+}
+
+func (server *Server) listQuarantine(ctx *gin.Context) {
+	req := listQuarantineRequest{PageID: 1, PageSize: 100}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListQuarantinedTrafficDataParams{
+		SensorID:  optionalInt4(req.SensorID),
+		Reason:    pgtype.Text{String: req.Reason, Valid: req.Reason != ""},
+		RowLimit:  req.PageSize,
+		RowOffset: (req.PageID - 1) * req.PageSize,
+	}
+
+	rows, err := server.store.ListQuarantinedTrafficData(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rows)
+}
+
+func (server *Server) getQuarantineSummary(ctx *gin.Context) {
+	summary, err := server.store.SummarizeQuarantine(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, summary)
+}
+
+type quarantineURIRequest struct {
+	QuarantineID int64 `uri:"quarantine_id" binding:"required,min=1"`
+}
+
+func (server *Server) getQuarantinedReading(ctx *gin.Context) {
+	var req quarantineURIRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	row, err := server.store.GetQuarantinedTrafficData(ctx, req.QuarantineID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, row)
+}
+
+type releaseQuarantineRequest struct {
+	QuarantineIDs []int64 `json:"quarantine_ids" binding:"required_without=SensorID,max=10000,dive,min=1"`
+	SensorID      *int32  `json:"sensor_id" binding:"required_without=QuarantineIDs,omitempty,min=1"`
+	Limit         int32   `json:"limit" binding:"omitempty,min=1,max=10000"`
+}
+
+// releaseQuarantine moves the selected readings into traffic_data. Readings
+// whose sensor still does not exist stay quarantined and are reported.
+func (server *Server) releaseQuarantine(ctx *gin.Context) {
+	var req releaseQuarantineRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = maxBatchRows
+	}
+
+	rsp, err := server.ingester.ReleaseQuarantine(ctx, req.QuarantineIDs, optionalInt4(req.SensorID), req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	status := http.StatusOK
+	if rsp.Kept > 0 {
+		status = http.StatusMultiStatus
+	}
+	ctx.JSON(status, rsp)
+}
+
+type purgeQuarantineRequest struct {
+	SensorID       *int32     `form:"sensor_id" binding:"omitempty,min=1"`
+	Reason         string     `form:"reason" binding:"omitempty,oneof=unknown_sensor inactive_sensor write_failed"`
+	ReceivedBefore *time.Time `form:"received_before" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// purgeQuarantine deletes quarantined readings matching the filters. At
+// least one filter is required so the whole quarantine is never dropped by
+// accident.
+func (server *Server) purgeQuarantine(ctx *gin.Context) {
+	var req purgeQuarantineRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.SensorID == nil && req.Reason == "" && req.ReceivedBefore == nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "one of sensor_id, reason or received_before is required"})
+		return
+	}
+
+	arg := db.PurgeQuarantinedTrafficDataParams{
+		SensorID: optionalInt4(req.SensorID),
+		Reason:   pgtype.Text{String: req.Reason, Valid: req.Reason != ""},
+	}
+	if req.ReceivedBefore != nil {
+		arg.ReceivedBefore = pgtype.Timestamptz{Time: *req.ReceivedBefore, Valid: true}
+	}
+
+	purged, err := server.store.PurgeQuarantinedTrafficData(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"purged": purged})
+}
+
+func (server *Server) deleteQuarantinedReading(ctx *gin.Context) {
+	var req quarantineURIRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	purged, err := server.store.PurgeQuarantinedTrafficData(ctx, db.PurgeQuarantinedTrafficDataParams{
+		QuarantineIds: []int64{req.QuarantineID},
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if purged == 0 {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "quarantined reading not found"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"detail": "quarantined reading deleted"})
+}
+
+func optionalInt4(value *int32) pgtype.Int4 {
+	if value == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: *value, Valid: true}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"smart_city/traffic_flow/rollup"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// dataSourceHeader tells clients whether a response was computed from raw
+// readings or from a rollup
+const dataSourceHeader = "X-Data-Source"
+
+func setDataSource(ctx *gin.Context, plan rollup.Plan) {
+	ctx.Header(dataSourceHeader, string(plan.Source))
+}
+
+type refreshRollupsRequest struct {
+	// Rollups defaults to both; the hourly one is always refreshed first
+	Rollups   []string  `json:"rollups"`
+	StartTime time.Time `json:"start_time" binding:"required"`
+	EndTime   time.Time `json:"end_time" binding:"required"`
+}
+
+// refreshRollups rematerializes the hourly and/or daily rollups for a
+// window, e.g. after backfilling readings older than the refresh policies
+// cover
+func (server *Server) refreshRollups(ctx *gin.Context) {
+	var req refreshRollupsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.EndTime.Before(req.StartTime) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "start_time must not be after end_time"})
+		return
+	}
+
+	sources := make([]rollup.Source, len(req.Rollups))
+	for i, value := range req.Rollups {
+		source, err := rollup.ParseSource(value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		sources[i] = source
+	}
+
+	refreshed, err := server.rollups.Refresh(ctx, sources, req.StartTime, req.EndTime)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, rollup.ErrInvalidSource) {
+			status = http.StatusBadRequest
+		}
+		ctx.JSON(status, gin.H{"error": err.Error(), "refreshed": refreshed})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"refreshed": refreshed})
+}
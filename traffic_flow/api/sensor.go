@@ -1,34 +1,25 @@
 package api
 
 import (
+	"errors"
 	"net/http"
-	db "smart_city/traffic_flow/db/sqlc"
+	"smart_city/traffic_flow/catalog"
 
 	"github.com/gin-gonic/gin"
-	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // CRUD operations for sensors
 
-type createSensorTypeRequest struct {
-	TypeName    string `json:"type_name" binding:"required"`
-	Description string `json:"description" binding:"required"`
-}
-
 func (server *Server) createSensorType(ctx *gin.Context) {
-	var req createSensorTypeRequest
+	var req catalog.CreateSensorTypeRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	arg := db.CreateSensorTypeParams{
-		TypeName:    req.TypeName,
-		Description: pgtype.Text{String: req.Description, Valid: true},
-	}
-	sensor, err := server.store.CreateSensorType(ctx, arg)
+	sensor, err := server.catalog.CreateSensorType(ctx, req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(catalogErrorStatus(err), errorResponse(err))
 		return
 	}
 
@@ -70,11 +61,6 @@ type updateSensorTypeURIRequest struct {
 	TypeID int32 `uri:"type_id" binding:"required,min=1"`
 }
 
-type updateSensorTypeJSONRequest struct {
-	TypeName    string `json:"type_name" binding:"required"`
-	Description string `json:"description" binding:"required"`
-}
-
 func (server *Server) updateSensorType(ctx *gin.Context) {
 	var uriReq updateSensorTypeURIRequest
 	if err := ctx.ShouldBindUri(&uriReq); err != nil {
@@ -82,21 +68,15 @@ func (server *Server) updateSensorType(ctx *gin.Context) {
 		return
 	}
 
-	var jsonReq updateSensorTypeJSONRequest
+	var jsonReq catalog.UpdateSensorTypeRequest
 	if err := ctx.ShouldBindJSON(&jsonReq); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	arg := db.UpdateSensorTypeParams{
-		TypeID:      uriReq.TypeID,
-		TypeName:    jsonReq.TypeName,
-		Description: pgtype.Text{String: jsonReq.Description, Valid: true},
-	}
-
-	sensor, err := server.store.UpdateSensorType(ctx, arg)
+	sensor, err := server.catalog.UpdateSensorType(ctx, uriReq.TypeID, jsonReq)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(catalogErrorStatus(err), errorResponse(err))
 		return
 	}
 	ctx.JSON(http.StatusOK, sensor)
@@ -113,7 +93,7 @@ func (server *Server) deleteSensorType(ctx *gin.Context) {
 		return
 	}
 
-	err := server.store.DeleteSensorType(ctx, req.TypeID)
+	err := server.catalog.DeleteSensorType(ctx, req.TypeID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -122,45 +102,16 @@ func (server *Server) deleteSensorType(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"detail": "sensor type deleted"})
 }
 
-type createSensorRequest struct {
-	Latitude         float64 `json:"latitude" binding:"required"`
-	Longitude        float64 `json:"longitude" binding:"required"`
-	TypeID           int32   `json:"type_id" binding:"required,min=1"`
-	InstallationDate string  `json:"installation_date" binding:"required"`
-	Status           string  `json:"status" binding:"omitempty"`
-}
-
 func (server *Server) createSensor(ctx *gin.Context) {
-	var req createSensorRequest
+	var req catalog.CreateSensorRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	// Set default status if not provided
-	status := req.Status
-	if status == "" {
-		status = "active"
-	}
-
-	// Convert string date to pgtype.Date
-	var installationDate pgtype.Date
-	if err := installationDate.Scan(req.InstallationDate); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
-	}
-
-	arg := db.CreateSensorParams{
-		Latitude:         req.Latitude,
-		Longitude:        req.Longitude,
-		TypeID:           req.TypeID,
-		InstallationDate: installationDate,
-		Status:           status,
-	}
-
-	sensor, err := server.store.CreateSensor(ctx, arg)
+	sensor, err := server.catalog.CreateSensor(ctx, req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(catalogErrorStatus(err), errorResponse(err))
 		return
 	}
 
@@ -202,10 +153,6 @@ type updateSensorURIRequest struct {
 	SensorID int32 `uri:"sensor_id" binding:"required,min=1"`
 }
 
-type updateSensorJSONRequest struct {
-	Status string `json:"status" binding:"required"`
-}
-
 func (server *Server) updateSensor(ctx *gin.Context) {
 	var uriReq updateSensorURIRequest
 	if err := ctx.ShouldBindUri(&uriReq); err != nil {
@@ -213,20 +160,15 @@ func (server *Server) updateSensor(ctx *gin.Context) {
 		return
 	}
 
-	var jsonReq updateSensorJSONRequest
+	var jsonReq catalog.UpdateSensorRequest
 	if err := ctx.ShouldBindJSON(&jsonReq); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	arg := db.UpdateSensorStatusParams{
-		SensorID: uriReq.SensorID,
-		Status:   jsonReq.Status,
-	}
-
-	sensor, err := server.store.UpdateSensorStatus(ctx, arg)
+	sensor, err := server.catalog.UpdateSensor(ctx, uriReq.SensorID, jsonReq)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(catalogErrorStatus(err), errorResponse(err))
 		return
 	}
 	ctx.JSON(http.StatusOK, sensor)
@@ -243,7 +185,7 @@ func (server *Server) deleteSensor(ctx *gin.Context) {
 		return
 	}
 
-	err := server.store.DeleteSensor(ctx, req.SensorID)
+	err := server.catalog.DeleteSensor(ctx, req.SensorID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -283,3 +225,11 @@ func (server *Server) getSensorsByType(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, sensors)
 }
+
+// catalogErrorStatus maps a catalog service error to an HTTP status
+func catalogErrorStatus(err error) int {
+	if errors.Is(err, catalog.ErrInvalidRequest) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"smart_city/traffic_flow/catalog"
+	"smart_city/traffic_flow/geo"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Spatial sensor lookups backed by the location index
+
+type sensorsNearRequest struct {
+	Lat     *float64 `form:"lat" binding:"required"`
+	Lon     *float64 `form:"lon" binding:"required"`
+	RadiusM float64  `form:"radius_m" binding:"required,gt=0,max=100000"`
+	Status  string   `form:"status"`
+	Limit   int32    `form:"limit" binding:"omitempty,min=1,max=10000"`
+}
+
+// getSensorsNear returns the sensors within radius_m meters of a point,
+// nearest first, each with its distance in meters
+func (server *Server) getSensorsNear(ctx *gin.Context) {
+	req := sensorsNearRequest{Limit: 100}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	sensors, err := server.geo.Near(ctx, *req.Lat, *req.Lon, req.RadiusM, req.Status, req.Limit)
+	if err != nil {
+		ctx.JSON(geoErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, sensors)
+}
+
+type sensorsNearestRequest struct {
+	Lat        *float64 `form:"lat" binding:"required"`
+	Lon        *float64 `form:"lon" binding:"required"`
+	K          int32    `form:"k" binding:"omitempty,min=1,max=100"`
+	MaxRadiusM float64  `form:"max_radius_m" binding:"omitempty,gt=0,max=1000000"`
+	Status     string   `form:"status"`
+}
+
+// getNearestSensors returns the k sensors nearest to a point, 5 unless
+// given, searching up to max_radius_m meters away, 50 km by default
+func (server *Server) getNearestSensors(ctx *gin.Context) {
+	req := sensorsNearestRequest{K: 5, MaxRadiusM: 50000}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	sensors, err := server.geo.Nearest(ctx, *req.Lat, *req.Lon, req.K, req.MaxRadiusM, req.Status)
+	if err != nil {
+		ctx.JSON(geoErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, sensors)
+}
+
+type sensorsWithinRequest struct {
+	BBox   string `form:"bbox" binding:"required"`
+	Status string `form:"status"`
+	Limit  int32  `form:"limit" binding:"omitempty,min=1,max=10000"`
+}
+
+// getSensorsWithin returns the sensors inside a bounding box given as
+// bbox=min_lon,min_lat,max_lon,max_lat
+func (server *Server) getSensorsWithin(ctx *gin.Context) {
+	req := sensorsWithinRequest{Limit: 1000}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	box, err := geo.ParseBox(req.BBox)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	sensors, err := server.geo.Within(ctx, box, req.Status, req.Limit)
+	if err != nil {
+		ctx.JSON(geoErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, sensors)
+}
+
+// geoErrorStatus maps a spatial lookup error to an HTTP status
+func geoErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, catalog.ErrInvalidRequest), errors.Is(err, geo.ErrInvalidBox), errors.Is(err, geo.ErrInvalidCoordinates):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
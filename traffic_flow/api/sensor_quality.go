@@ -0,0 +1,87 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"smart_city/traffic_flow/quality"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getSensorQuality scores a sensor's recent readings for completeness,
+// gaps, flatlines, impossible values and volume/speed consistency
+func (server *Server) getSensorQuality(ctx *gin.Context) {
+	var uri getSensorRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	report, err := server.quality.Sensor(ctx, uri.SensorID)
+	if err != nil {
+		ctx.JSON(qualityErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+type sensorQualityRankingRequest struct {
+	LowOnly bool `form:"low_only"`
+	Limit   int  `form:"limit" binding:"omitempty,min=1,max=10000"`
+}
+
+// getSensorQualityRanking ranks all sensors by data quality, worst first,
+// optionally only those below the minimum score
+func (server *Server) getSensorQualityRanking(ctx *gin.Context) {
+	req := sensorQualityRankingRequest{Limit: 100}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	ranking, err := server.quality.Ranking(ctx, req.LowOnly, req.Limit)
+	if err != nil {
+		ctx.JSON(qualityErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ranking)
+}
+
+// errLowQualitySensor answers a request about a single sensor that asked to
+// leave out low-quality sensors when the sensor is one of them
+var errLowQualitySensor = errors.New("sensor fails the data quality checks and is left out")
+
+// qualityFilter is embedded in the request of every endpoint that can
+// leave out sensors failing the data quality checks
+type qualityFilter struct {
+	ExcludeLowQuality bool `form:"exclude_low_quality" json:"exclude_low_quality"`
+}
+
+// excludeLowQuality drops low-quality sensors from sensorIDs when the
+// request asks for it. An empty list stands for all sensors and becomes
+// all but those.
+func (server *Server) excludeLowQuality(ctx *gin.Context, filter qualityFilter, sensorIDs []int32) ([]int32, error) {
+	if !filter.ExcludeLowQuality {
+		return sensorIDs, nil
+	}
+	return server.quality.Exclude(ctx, sensorIDs)
+}
+
+// lowQualitySensors returns the sensors to leave out of results computed
+// per sensor, none unless the request asks for it
+func (server *Server) lowQualitySensors(ctx *gin.Context, filter qualityFilter) (map[int32]bool, error) {
+	if !filter.ExcludeLowQuality {
+		return nil, nil
+	}
+	return server.quality.LowQuality(ctx)
+}
+
+// qualityErrorStatus maps a data quality service error to an HTTP status
+func qualityErrorStatus(err error) int {
+	if errors.Is(err, quality.ErrSensorNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -1,8 +1,27 @@
 package api
 
 import (
+	"context"
+	"net"
 	"net/http"
+	"os"
+	"smart_city/traffic_flow/anomaly"
+	"smart_city/traffic_flow/catalog"
+	"smart_city/traffic_flow/cityindex"
+	"smart_city/traffic_flow/comparison"
+	"smart_city/traffic_flow/congestion"
+	"smart_city/traffic_flow/corridor"
 	db "smart_city/traffic_flow/db/sqlc"
+	"smart_city/traffic_flow/episode"
+	"smart_city/traffic_flow/export"
+	"smart_city/traffic_flow/forecast"
+	"smart_city/traffic_flow/geo"
+	"smart_city/traffic_flow/ingest"
+	"smart_city/traffic_flow/liveness"
+	"smart_city/traffic_flow/peak"
+	"smart_city/traffic_flow/quality"
+	"smart_city/traffic_flow/rollup"
+	"smart_city/traffic_flow/storage"
 	"sync"
 	"time"
 
@@ -14,17 +33,41 @@ type ServerConfig struct {
 	Timeout        time.Duration
 	TrustedProxies []string
 	MaxBodySize    int
+	// IdempotencyTTL is how long responses are kept for Idempotency-Key retries
+	IdempotencyTTL time.Duration
+	// AdminToken is the bearer token the /admin routes require; they are
+	// disabled while it is empty
+	AdminToken string
 }
 
 type Server struct {
-	store     *db.Store
-	router    *gin.Engine
-	config    ServerConfig
-	wsClients map[*Client]bool
-	wsLock    sync.RWMutex
+	store           *db.Store
+	ingester        *ingest.Service
+	catalog         *catalog.Service
+	congestion      *congestion.Service
+	liveness        *liveness.Monitor
+	rollups         *rollup.Service
+	anomalies       *anomaly.Detector
+	forecasts       *forecast.Service
+	geo             *geo.Service
+	corridors       *corridor.Service
+	comparisons     *comparison.Service
+	peaks           *peak.Service
+	episodes        *episode.Service
+	congestionIndex *cityindex.Service
+	quality         *quality.Service
+	exports         *export.Service
+	storage         *storage.Service
+	// queue is nil when readings are written synchronously
+	queue      *ingest.Queue
+	router     *gin.Engine
+	httpServer *http.Server
+	config     ServerConfig
+	wsClients  map[*Client]bool
+	wsLock     sync.RWMutex
 }
 
-func NewServer(store *db.Store) (*Server, error) {
+func NewServer(store *db.Store, ingester *ingest.Service, catalog *catalog.Service, congestion *congestion.Service, monitor *liveness.Monitor, rollups *rollup.Service, detector *anomaly.Detector, forecasts *forecast.Service, locator *geo.Service, corridors *corridor.Service, comparisons *comparison.Service, peaks *peak.Service, episodes *episode.Service, congestionIndex *cityindex.Service, quality *quality.Service, exports *export.Service, storage *storage.Service, queue *ingest.Queue) (*Server, error) {
 	// Default configuration
 	config := ServerConfig{
 		Mode:           gin.ReleaseMode,
@@ -33,13 +76,46 @@ func NewServer(store *db.Store) (*Server, error) {
 		MaxBodySize:    8 * 1024 * 1024, // 8MB
 	}
 
+	idempotencyTTL, err := loadIdempotencyTTL()
+	if err != nil {
+		return nil, err
+	}
+	config.IdempotencyTTL = idempotencyTTL
+	config.AdminToken = os.Getenv("ADMIN_API_TOKEN")
+
 	server := &Server{
-		store:     store,
-		config:    config,
-		wsClients: make(map[*Client]bool),
+		store:           store,
+		ingester:        ingester,
+		catalog:         catalog,
+		congestion:      congestion,
+		liveness:        monitor,
+		rollups:         rollups,
+		anomalies:       detector,
+		forecasts:       forecasts,
+		geo:             locator,
+		corridors:       corridors,
+		comparisons:     comparisons,
+		peaks:           peaks,
+		episodes:        episodes,
+		congestionIndex: congestionIndex,
+		quality:         quality,
+		exports:         exports,
+		storage:         storage,
+		queue:           queue,
+		config:          config,
+		wsClients:       make(map[*Client]bool),
 	}
 
+	// Every ingestion transport feeds the WebSocket broadcast
+	ingester.OnRecord(server.broadcastTrafficUpdate)
+	monitor.OnTransition(server.broadcastSensorStatus)
+	detector.OnAnomaly(server.broadcastTrafficAnomaly)
+	congestionIndex.OnIndex(server.broadcastCongestionIndex)
+
 	server.setupRouter(config)
+	// The http.Server exists before Start so that Shutdown never races with
+	// its creation
+	server.httpServer = &http.Server{Handler: server.router}
 	return server, nil
 }
 
@@ -51,6 +127,10 @@ func (server *Server) setupRouter(config ServerConfig) {
 
 	router.SetTrustedProxies(config.TrustedProxies)
 
+	// Retried POST and PUT requests with an Idempotency-Key are answered
+	// from the stored response
+	router.Use(server.idempotencyMiddleware())
+
 	router.GET("/", func(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
@@ -66,6 +146,10 @@ func (server *Server) setupRouter(config ServerConfig) {
 			sensorTypes.GET("/:type_id", server.getSensorType)
 			sensorTypes.PUT("/:type_id", server.updateSensorType)
 			sensorTypes.DELETE("/:type_id", server.deleteSensorType)
+			sensorTypes.PUT("/:type_id/congestion-thresholds", server.setSensorTypeCongestionThresholds)
+			sensorTypes.DELETE("/:type_id/congestion-thresholds", server.deleteSensorTypeCongestionThresholds)
+			sensorTypes.PUT("/:type_id/liveness-thresholds", server.setSensorTypeLivenessThresholds)
+			sensorTypes.DELETE("/:type_id/liveness-thresholds", server.deleteSensorTypeLivenessThresholds)
 		}
 
 		// Sensors routes
@@ -74,6 +158,10 @@ func (server *Server) setupRouter(config ServerConfig) {
 			// Special sensor routes - MUST come before /:sensor_id routes to avoid conflicts
 			sensors.GET("/active", server.getActiveSensors)
 			sensors.GET("/by-type/:type_id", server.getSensorsByType)
+			sensors.GET("/near", server.getSensorsNear)
+			sensors.GET("/nearest", server.getNearestSensors)
+			sensors.GET("/within", server.getSensorsWithin)
+			sensors.GET("/quality", server.getSensorQualityRanking)
 
 			// Regular CRUD routes
 			sensors.POST("", server.createSensor)
@@ -81,18 +169,94 @@ func (server *Server) setupRouter(config ServerConfig) {
 			sensors.GET("/:sensor_id", server.getSensor)
 			sensors.PUT("/:sensor_id", server.updateSensor)
 			sensors.DELETE("/:sensor_id", server.deleteSensor)
+			sensors.GET("/:sensor_id/congestion-thresholds", server.getSensorCongestionThresholds)
+			sensors.PUT("/:sensor_id/congestion-thresholds", server.setSensorCongestionThresholds)
+			sensors.DELETE("/:sensor_id/congestion-thresholds", server.deleteSensorCongestionThresholds)
+			sensors.GET("/:sensor_id/status-transitions", server.listSensorStatusTransitions)
+			sensors.GET("/:sensor_id/quality", server.getSensorQuality)
 		}
 
 		// Traffic data endpoints
 		traffic := api.Group("/traffic")
 		{
 			traffic.POST("/record", server.recordTrafficData)
+			traffic.POST("/batch", server.recordTrafficBatch)
 			traffic.GET("/by-sensor", server.getTrafficDataBySensor)
 			traffic.GET("/latest", server.getLatestTrafficData)
 			traffic.GET("/high-congestion", server.getHighCongestionAreas)
 			traffic.GET("/averages", server.getTrafficAverages)
 			traffic.GET("/congestion-distribution", server.getSensorCongestionDistribution)
+			traffic.GET("/congestion-index", server.getCongestionIndex)
+			traffic.GET("/series", server.getTrafficSeries)
+			traffic.GET("/percentiles", server.getTrafficPercentiles)
+			traffic.GET("/histogram", server.getTrafficHistogram)
+			traffic.GET("/heatmap", server.getTrafficHeatmap)
+			traffic.GET("/compare", server.getTrafficComparison)
+			traffic.GET("/peak-hours", server.getPeakHours)
+			traffic.GET("/congestion-episodes", server.listCongestionEpisodes)
+			traffic.GET("/congestion-episodes/summary", server.getCongestionEpisodeSummary)
+			traffic.GET("/anomalies", server.listTrafficAnomalies)
+			traffic.GET("/anomalies/stats", server.getAnomalyStats)
+			traffic.GET("/forecast", server.getTrafficForecast)
+			traffic.GET("/forecast/accuracy", server.listForecastAccuracy)
+			traffic.GET("/export", server.exportTrafficData)
+		}
+
+		// Road corridors and their travel times
+		corridors := api.Group("/corridors")
+		{
+			corridors.POST("", server.createCorridor)
+			corridors.GET("", server.listCorridors)
+			corridors.GET("/:corridor_id", server.getCorridor)
+			corridors.PUT("/:corridor_id", server.updateCorridor)
+			corridors.DELETE("/:corridor_id", server.deleteCorridor)
+			corridors.GET("/:corridor_id/travel-time", server.getCorridorTravelTime)
+			corridors.GET("/:corridor_id/travel-time/series", server.getCorridorTravelTimeSeries)
+		}
+
+		// Ingestion pipeline metrics
+		api.GET("/ingest/stats", server.getIngestStats)
+
+		// Congestion classification thresholds
+		thresholds := api.Group("/congestion-thresholds")
+		{
+			thresholds.GET("", server.listCongestionThresholds)
+			thresholds.PUT("/default", server.setDefaultCongestionThresholds)
+		}
+
+		// Silence thresholds of the sensor liveness monitor
+		api.GET("/liveness-thresholds", server.listLivenessThresholds)
+
+		// Quarantined readings from unknown or non-active sensors
+		quarantine := api.Group("/quarantine")
+		{
+			quarantine.GET("", server.listQuarantine)
+			quarantine.GET("/summary", server.getQuarantineSummary)
+			quarantine.POST("/release", server.releaseQuarantine)
+			quarantine.DELETE("", server.purgeQuarantine)
+			quarantine.GET("/:quarantine_id", server.getQuarantinedReading)
+			quarantine.DELETE("/:quarantine_id", server.deleteQuarantinedReading)
+		}
+
+		// Maintenance of the hourly and daily rollups and of the
+		// compression and retention of traffic_data. They can destroy
+		// readings, so they need the admin token.
+		admin := api.Group("/admin", server.adminMiddleware())
+		{
+			admin.POST("/rollups/refresh", server.refreshRollups)
+
+			admin.GET("/storage/policies", server.getStoragePolicies)
+			admin.PUT("/storage/policies/compression", server.setCompressionPolicy)
+			admin.DELETE("/storage/policies/compression", server.deleteCompressionPolicy)
+			admin.PUT("/storage/policies/retention", server.setRetentionPolicy)
+			admin.DELETE("/storage/policies/retention", server.deleteRetentionPolicy)
+			admin.GET("/storage/chunks", server.listChunks)
+			admin.POST("/storage/chunks/compress", server.compressChunks)
+			admin.POST("/storage/chunks/drop", server.dropChunks)
 		}
+
+		// Influx line protocol ingestion
+		api.POST("/write", server.writeLineProtocol)
 	}
 
 	// WebSocket endpoint for real-time updates
@@ -104,8 +268,21 @@ func (server *Server) setupRouter(config ServerConfig) {
 func (server *Server) Start(address string) error {
 	// Start the background goroutine to send updates to WebSocket clients
 	server.startBackgroundUpdates()
+	go server.purgeIdempotencyKeys()
+
+	if address == "" {
+		address = ":http"
+	}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	return server.httpServer.Serve(listener)
+}
 
-	return server.router.Run(address)
+// Shutdown stops accepting requests and waits for in-flight ones to finish
+func (server *Server) Shutdown(ctx context.Context) error {
+	return server.httpServer.Shutdown(ctx)
 }
 
 // registerClient adds a new WebSocket client
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"smart_city/traffic_flow/catalog"
+	"smart_city/traffic_flow/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Compression and retention of the traffic_data hypertable
+
+func (server *Server) getStoragePolicies(ctx *gin.Context) {
+	policies, err := server.storage.Policies(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, policies)
+}
+
+type setCompressionPolicyRequest struct {
+	CompressAfterDays int32 `json:"compress_after_days" binding:"required"`
+}
+
+func (server *Server) setCompressionPolicy(ctx *gin.Context) {
+	var req setCompressionPolicyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	policies, err := server.storage.SetCompressionPolicy(ctx, req.CompressAfterDays)
+	if err != nil {
+		ctx.JSON(storageErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, policies)
+}
+
+func (server *Server) deleteCompressionPolicy(ctx *gin.Context) {
+	if err := server.storage.RemoveCompressionPolicy(ctx); err != nil {
+		ctx.JSON(storageErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"detail": "compression policy removed, compressed chunks stay compressed"})
+}
+
+type setRetentionPolicyRequest struct {
+	DropAfterDays int32 `json:"drop_after_days" binding:"required"`
+}
+
+func (server *Server) setRetentionPolicy(ctx *gin.Context) {
+	var req setRetentionPolicyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	policies, err := server.storage.SetRetentionPolicy(ctx, req.DropAfterDays)
+	if err != nil {
+		ctx.JSON(storageErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, policies)
+}
+
+func (server *Server) deleteRetentionPolicy(ctx *gin.Context) {
+	if err := server.storage.RemoveRetentionPolicy(ctx); err != nil {
+		ctx.JSON(storageErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"detail": "retention policy removed, readings are kept indefinitely"})
+}
+
+// listChunks returns every chunk with its size and compression ratio
+// together with totals over all of them
+func (server *Server) listChunks(ctx *gin.Context) {
+	chunks, err := server.storage.Chunks(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"summary": storage.Summarize(chunks),
+		"chunks":  chunks,
+	})
+}
+
+type compressChunksRequest struct {
+	OlderThanDays int32 `json:"older_than_days" binding:"required"`
+}
+
+// compressChunks compresses old chunks right away instead of waiting for
+// the compression policy
+func (server *Server) compressChunks(ctx *gin.Context) {
+	var req compressChunksRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	compressed, err := server.storage.Compress(ctx, req.OlderThanDays)
+	if err != nil {
+		ctx.JSON(storageErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"compressed": compressed})
+}
+
+type dropChunksRequest struct {
+	OlderThanDays int32 `json:"older_than_days" binding:"required"`
+	// DryRun lists the chunks that would be dropped without dropping them
+	DryRun bool `json:"dry_run"`
+}
+
+func (server *Server) dropChunks(ctx *gin.Context) {
+	var req dropChunksRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	dropped, err := server.storage.Drop(ctx, req.OlderThanDays, req.DryRun)
+	if err != nil {
+		ctx.JSON(storageErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"dropped": dropped, "dry_run": req.DryRun})
+}
+
+// storageErrorStatus maps a storage service error to an HTTP status
+func storageErrorStatus(err error) int {
+	if errors.Is(err, catalog.ErrInvalidRequest) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
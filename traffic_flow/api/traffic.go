@@ -3,8 +3,11 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	db "smart_city/traffic_flow/db/sqlc"
+	"smart_city/traffic_flow/ingest"
+	"smart_city/traffic_flow/series"
 	"strconv"
 	"time"
 
@@ -14,36 +17,44 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-type recordTrafficDataRequest struct {
-	SensorID        int32   `json:"sensor_id" binding:"required"`
-	TrafficVolume   int32   `json:"traffic_volume" binding:"required"`
-	AverageSpeed    float64 `json:"average_speed" binding:"required"`
-	CongestionLevel string  `json:"congestion_level" binding:"required,oneof=low moderate high"`
-}
-
 func (server *Server) recordTrafficData(ctx *gin.Context) {
-	var req recordTrafficDataRequest
+	var req ingest.Reading
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	arg := db.RecordTrafficDataParams{
-		SensorID:        req.SensorID,
-		Timestamp:       pgtype.Timestamp{Time: time.Now(), Valid: true},
-		TrafficVolume:   req.TrafficVolume,
-		AverageSpeed:    req.AverageSpeed,
-		CongestionLevel: db.CongestionLevelType(req.CongestionLevel),
+	if server.queue != nil {
+		server.enqueueTrafficData(ctx, req)
+		return
 	}
 
-	trafficData, err := server.store.RecordTrafficData(ctx, arg)
+	trafficData, outcome, err := server.ingester.Record(ctx, req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		switch {
+		case errors.Is(err, ingest.ErrQuarantined):
+			ctx.JSON(http.StatusAccepted, gin.H{"detail": err.Error(), "data": trafficData})
+		case errors.Is(err, ingest.ErrInvalidReading):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		case errors.Is(err, ingest.ErrTimestampTooOld), errors.Is(err, ingest.ErrTimestampInFuture),
+			errors.Is(err, db.ErrUnknownSensor), errors.Is(err, ingest.ErrInactiveSensor):
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+		case errors.Is(err, db.ErrDuplicateReading):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
 		return
 	}
 
-	// Broadcast update to WebSocket clients
-	server.broadcastTrafficUpdate(trafficData)
+	if outcome == db.RowSkipped {
+		ctx.JSON(http.StatusOK, gin.H{"detail": "duplicate reading ignored, existing reading kept", "data": trafficData})
+		return
+	}
+	if outcome == db.RowOverwritten {
+		ctx.JSON(http.StatusOK, gin.H{"detail": "existing reading overwritten", "data": trafficData})
+		return
+	}
 
 	ctx.JSON(http.StatusCreated, trafficData)
 }
@@ -52,6 +63,12 @@ type getTrafficDataRequest struct {
 	SensorID  int32  `json:"sensor_id" binding:"required"`
 	StartTime string `json:"start_time" binding:"required"`
 	EndTime   string `json:"end_time" binding:"required"`
+	// Bucket returns the readings aggregated into a series of buckets of
+	// this width, as /traffic/series does, instead of one by one
+	Bucket     string   `json:"bucket"`
+	Aggregates []string `json:"aggregates"`
+	// Fill gapfills the series with null, locf or linear; it needs a bucket
+	Fill string `json:"fill"`
 }
 
 func (server *Server) getTrafficDataBySensor(ctx *gin.Context) {
@@ -73,6 +90,15 @@ func (server *Server) getTrafficDataBySensor(ctx *gin.Context) {
 		return
 	}
 
+	if req.Bucket != "" {
+		server.getTrafficDataSeries(ctx, req, startTime.UTC(), endTime.UTC())
+		return
+	}
+	if req.Fill != "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "fill needs a bucket"})
+		return
+	}
+
 	arg := db.GetTrafficDataBySensorParams{
 		SensorID:    req.SensorID,
 		Timestamp:   pgtype.Timestamp{Time: startTime, Valid: true},
@@ -88,6 +114,48 @@ func (server *Server) getTrafficDataBySensor(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, trafficData)
 }
 
+// getTrafficDataSeries answers getTrafficDataBySensor with a bucket: the
+// sensor's series over the range, gapfilled when the request has a fill so
+// that charts have no holes where the sensor dropped out
+func (server *Server) getTrafficDataSeries(ctx *gin.Context, req getTrafficDataRequest, startTime, endTime time.Time) {
+	bucket, err := series.ParseBucket(req.Bucket)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	aggregates, err := series.ParseAggregates(req.Aggregates)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var fill series.Fill
+	if req.Fill != "" {
+		fill, err = series.ParseFill(req.Fill)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	if !startTime.Before(endTime) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "start_time must be before end_time"})
+		return
+	}
+	if err := bucket.Check(startTime, endTime); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rsp, err := server.trafficSeries(ctx, bucket, startTime, endTime, []int32{req.SensorID}, aggregates, fill)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
 func (server *Server) getLatestTrafficData(ctx *gin.Context) {
 	limitStr := ctx.DefaultQuery("limit", "100")
 	limit, err := strconv.Atoi(limitStr)
@@ -108,6 +176,7 @@ func (server *Server) getLatestTrafficData(ctx *gin.Context) {
 type trafficStatsRequest struct {
 	StartTime string `json:"start_time" binding:"required"`
 	EndTime   string `json:"end_time" binding:"required"`
+	qualityFilter
 }
 
 func (server *Server) getHighCongestionAreas(ctx *gin.Context) {
@@ -136,17 +205,18 @@ func (server *Server) getHighCongestionAreas(ctx *gin.Context) {
 		return
 	}
 
-	arg := db.GetHighCongestionAreasParams{
-		Timestamp:   pgtype.Timestamp{Time: startTime, Valid: true},
-		Timestamp_2: pgtype.Timestamp{Time: endTime, Valid: true},
-		Limit:       int32(limit),
+	sensorIDs, err := server.excludeLowQuality(ctx, req.qualityFilter, nil)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
-	congestionAreas, err := server.store.GetHighCongestionAreas(ctx, arg)
+	congestionAreas, plan, err := server.rollups.HighCongestionAreas(ctx, startTime, endTime, int32(limit), sensorIDs)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	setDataSource(ctx, plan)
 
 	ctx.JSON(http.StatusOK, congestionAreas)
 }
@@ -155,6 +225,7 @@ type trafficAveragesRequest struct {
 	SensorID  int32  `json:"sensor_id" binding:"required"`
 	StartTime string `json:"start_time" binding:"required"`
 	EndTime   string `json:"end_time" binding:"required"`
+	qualityFilter
 }
 
 func (server *Server) getTrafficAverages(ctx *gin.Context) {
@@ -176,17 +247,22 @@ func (server *Server) getTrafficAverages(ctx *gin.Context) {
 		return
 	}
 
-	arg := db.GetTrafficAveragesParams{
-		SensorID:    req.SensorID,
-		Timestamp:   pgtype.Timestamp{Time: startTime, Valid: true},
-		Timestamp_2: pgtype.Timestamp{Time: endTime, Valid: true},
+	low, err := server.lowQualitySensors(ctx, req.qualityFilter)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if low[req.SensorID] {
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errLowQualitySensor))
+		return
 	}
 
-	averages, err := server.store.GetTrafficAverages(ctx, arg)
+	averages, plan, err := server.rollups.Averages(ctx, req.SensorID, startTime, endTime)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	setDataSource(ctx, plan)
 
 	ctx.JSON(http.StatusOK, averages)
 }
@@ -210,16 +286,18 @@ func (server *Server) getSensorCongestionDistribution(ctx *gin.Context) {
 		return
 	}
 
-	arg := db.GetSensorCongestionDistributionParams{
-		Timestamp:   pgtype.Timestamp{Time: startTime, Valid: true},
-		Timestamp_2: pgtype.Timestamp{Time: endTime, Valid: true},
+	sensorIDs, err := server.excludeLowQuality(ctx, req.qualityFilter, nil)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
-	distribution, err := server.store.GetSensorCongestionDistribution(ctx, arg)
+	distribution, plan, err := server.rollups.CongestionDistribution(ctx, startTime, endTime, sensorIDs)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	setDataSource(ctx, plan)
 
 	ctx.JSON(http.StatusOK, distribution)
 }
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"smart_city/traffic_flow/ingest"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxBatchRows caps the number of readings accepted by a single batch request
+const maxBatchRows = 10000
+
+// recordTrafficBatch ingests many readings in one request. The body is either
+// a JSON array of readings or NDJSON (one object per line) when sent with an
+// application/x-ndjson content type.
+func (server *Server) recordTrafficBatch(ctx *gin.Context) {
+	body := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, int64(server.config.MaxBodySize))
+
+	var (
+		entries []ingest.BatchEntry
+		err     error
+	)
+	if isNDJSON(ctx.ContentType()) {
+		entries, err = decodeNDJSONBatch(body)
+	} else {
+		entries, err = decodeJSONBatch(body)
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if len(entries) == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "batch is empty"})
+		return
+	}
+	if len(entries) > maxBatchRows {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("batch exceeds %d rows", maxBatchRows)})
+		return
+	}
+
+	rsp, err := server.ingester.RecordBatch(ctx, entries)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	status := http.StatusCreated
+	if rsp.Rejected > 0 || rsp.Quarantined > 0 {
+		status = http.StatusMultiStatus
+	}
+	ctx.JSON(status, rsp)
+}
+
+func isNDJSON(contentType string) bool {
+	return strings.HasSuffix(contentType, "ndjson") || contentType == "application/jsonl"
+}
+
+// decodeJSONBatch decodes a JSON array, keeping per-element decode and
+// validation errors instead of failing the whole batch.
+func decodeJSONBatch(r io.Reader) ([]ingest.BatchEntry, error) {
+	var raw []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("body must be a JSON array of readings: %w", err)
+	}
+
+	rows := make([]ingest.BatchEntry, 0, len(raw))
+	for _, msg := range raw {
+		if len(rows) > maxBatchRows {
+			break
+		}
+		rows = append(rows, decodeBatchEntry(msg))
+	}
+	return rows, nil
+}
+
+// decodeNDJSONBatch decodes one reading per non-empty line
+func decodeNDJSONBatch(r io.Reader) ([]ingest.BatchEntry, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	rows := []ingest.BatchEntry{}
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if len(rows) > maxBatchRows {
+			break
+		}
+		rows = append(rows, decodeBatchEntry(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read NDJSON body: %w", err)
+	}
+	return rows, nil
+}
+
+// decodeBatchEntry decodes a single reading; validation happens in the
+// ingest service
+func decodeBatchEntry(data []byte) ingest.BatchEntry {
+	var entry ingest.BatchEntry
+	if err := json.Unmarshal(data, &entry.Reading); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			entry.Err = fmt.Errorf("invalid value for %s", typeErr.Field)
+		} else {
+			entry.Err = err
+		}
+	}
+	return entry
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"smart_city/traffic_flow/comparison"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultCompareRange is the base window of a comparison without start_time
+const defaultCompareRange = 7 * 24 * time.Hour
+
+var errCompareWindow = errors.New("give either against or both compare_start and compare_end")
+
+type trafficCompareRequest struct {
+	Scope     string     `form:"scope" binding:"oneof=sensor corridor"`
+	StartTime *time.Time `form:"start_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndTime   *time.Time `form:"end_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	// Against names the comparison window relative to the base one
+	Against      string     `form:"against"`
+	CompareStart *time.Time `form:"compare_start" time_format:"2006-01-02T15:04:05Z07:00"`
+	CompareEnd   *time.Time `form:"compare_end" time_format:"2006-01-02T15:04:05Z07:00"`
+	RankBy       string     `form:"rank_by"`
+	Limit        int        `form:"limit" binding:"omitempty,min=1,max=10000"`
+	qualityFilter
+}
+
+// getTrafficComparison compares volume, speed and congestion, and for
+// corridors travel time, of every sensor or corridor between a base window
+// and a comparison window, ranked by the largest percentage change in
+// rank_by. Without end_time the base window ends now and without start_time
+// it covers the preceding 7 days. The comparison window is given with
+// compare_start and compare_end or as against=prev_period (the default),
+// prev_day, prev_week or prev_year.
+func (server *Server) getTrafficComparison(ctx *gin.Context) {
+	req := trafficCompareRequest{Scope: "sensor", RankBy: comparison.RankVolume, Limit: 100}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	startTime, endTime, err := resolveTimeRange(req.StartTime, req.EndTime, defaultCompareRange)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	base := comparison.Window{Start: startTime, End: endTime}
+
+	var against comparison.Window
+	switch {
+	case req.CompareStart != nil && req.CompareEnd != nil && req.Against == "":
+		against = comparison.Window{Start: req.CompareStart.UTC(), End: req.CompareEnd.UTC()}
+		if !against.Start.Before(against.End) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidTimeRange))
+			return
+		}
+	case req.CompareStart == nil && req.CompareEnd == nil:
+		shorthand := req.Against
+		if shorthand == "" {
+			shorthand = comparison.PrevPeriod
+		}
+		against.Start, against.End, err = comparison.Shift(shorthand, startTime, endTime)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	default:
+		ctx.JSON(http.StatusBadRequest, errorResponse(errCompareWindow))
+		return
+	}
+
+	low, err := server.lowQualitySensors(ctx, req.qualityFilter)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	var report comparison.Report
+	if req.Scope == "corridor" {
+		report, err = server.comparisons.Corridors(ctx, base, against, req.RankBy, low)
+	} else {
+		report, err = server.comparisons.Sensors(ctx, base, against, req.RankBy, low)
+	}
+	if err != nil {
+		ctx.JSON(comparisonErrorStatus(err), errorResponse(err))
+		return
+	}
+	setDataSource(ctx, report.Base)
+
+	if len(report.Entries) > req.Limit {
+		report.Entries = report.Entries[:req.Limit]
+	}
+	ctx.JSON(http.StatusOK, report)
+}
+
+// comparisonErrorStatus maps a comparison service error to an HTTP status
+func comparisonErrorStatus(err error) int {
+	if errors.Is(err, comparison.ErrInvalidRankMetric) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,278 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	db "smart_city/traffic_flow/db/sqlc"
+	"smart_city/traffic_flow/distribution"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Percentiles and histograms of speed and volume, which show the tails
+// that averages hide
+
+// defaultDistributionRange is the time range of a distribution request
+// without start_time
+const defaultDistributionRange = 24 * time.Hour
+
+var errInvalidTimeRange = errors.New("start_time must be before end_time")
+
+// resolveTimeRange defaults a missing end to now and a missing start to
+// fallback before the end
+func resolveTimeRange(start, end *time.Time, fallback time.Duration) (time.Time, time.Time, error) {
+	endTime := time.Now().UTC()
+	if end != nil {
+		endTime = end.UTC()
+	}
+	startTime := endTime.Add(-fallback)
+	if start != nil {
+		startTime = start.UTC()
+	}
+	if !startTime.Before(endTime) {
+		return startTime, endTime, errInvalidTimeRange
+	}
+	return startTime, endTime, nil
+}
+
+type trafficPercentilesRequest struct {
+	SensorIDs   []string   `form:"sensor_id"`
+	Percentiles []string   `form:"percentile"`
+	StartTime   *time.Time `form:"start_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndTime     *time.Time `form:"end_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	// PerSensor adds the percentiles of each sensor to those of the set
+	PerSensor bool `form:"per_sensor"`
+	qualityFilter
+}
+
+type percentileSummary struct {
+	Readings int64              `json:"readings"`
+	Speed    map[string]float64 `json:"speed"`
+	Volume   map[string]float64 `json:"volume"`
+}
+
+type sensorPercentiles struct {
+	SensorID int32 `json:"sensor_id"`
+	percentileSummary
+}
+
+type trafficPercentilesResponse struct {
+	StartTime   time.Time           `json:"start_time"`
+	EndTime     time.Time           `json:"end_time"`
+	Percentiles []string            `json:"percentiles"`
+	Combined    percentileSummary   `json:"combined"`
+	Sensors     []sensorPercentiles `json:"sensors,omitempty"`
+}
+
+// getTrafficPercentiles returns speed and volume percentiles, p50, p85 and
+// p95 unless others are requested, across the given sensors or all of
+// them, and optionally per sensor. Without end_time the range ends now and
+// without start_time it covers the preceding 24 hours.
+func (server *Server) getTrafficPercentiles(ctx *gin.Context) {
+	req := trafficPercentilesRequest{PerSensor: true}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	percentiles, err := distribution.ParsePercentiles(req.Percentiles)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	sensorIDs, err := parseSensorIDs(req.SensorIDs)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	sensorIDs, err = server.excludeLowQuality(ctx, req.qualityFilter, sensorIDs)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	startTime, endTime, err := resolveTimeRange(req.StartTime, req.EndTime, defaultDistributionRange)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	fractions := distribution.Fractions(percentiles)
+	combined, err := server.store.GetCombinedTrafficPercentiles(ctx, db.GetCombinedTrafficPercentilesParams{
+		Fractions: fractions,
+		StartTime: pgtype.Timestamp{Time: startTime, Valid: true},
+		EndTime:   pgtype.Timestamp{Time: endTime, Valid: true},
+		SensorIds: sensorIDs,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := trafficPercentilesResponse{
+		StartTime:   startTime,
+		EndTime:     endTime,
+		Percentiles: make([]string, len(percentiles)),
+		Combined: percentileSummary{
+			Readings: combined.Readings,
+			Speed:    distribution.Named(percentiles, combined.SpeedPercentiles),
+			Volume:   distribution.Named(percentiles, combined.VolumePercentiles),
+		},
+	}
+	for i, percentile := range percentiles {
+		rsp.Percentiles[i] = distribution.Label(percentile)
+	}
+
+	if req.PerSensor {
+		rows, err := server.store.GetTrafficPercentiles(ctx, db.GetTrafficPercentilesParams{
+			Fractions: fractions,
+			StartTime: pgtype.Timestamp{Time: startTime, Valid: true},
+			EndTime:   pgtype.Timestamp{Time: endTime, Valid: true},
+			SensorIds: sensorIDs,
+		})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		rsp.Sensors = make([]sensorPercentiles, len(rows))
+		for i, row := range rows {
+			rsp.Sensors[i] = sensorPercentiles{
+				SensorID: row.SensorID,
+				percentileSummary: percentileSummary{
+					Readings: row.Readings,
+					Speed:    distribution.Named(percentiles, row.SpeedPercentiles),
+					Volume:   distribution.Named(percentiles, row.VolumePercentiles),
+				},
+			}
+		}
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type trafficHistogramRequest struct {
+	Metric    string     `form:"metric"`
+	BinWidth  float64    `form:"bin_width" binding:"omitempty,gt=0"`
+	SensorIDs []string   `form:"sensor_id"`
+	StartTime *time.Time `form:"start_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndTime   *time.Time `form:"end_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	qualityFilter
+}
+
+type trafficHistogramResponse struct {
+	Metric    distribution.Metric `json:"metric"`
+	BinWidth  float64             `json:"bin_width"`
+	StartTime time.Time           `json:"start_time"`
+	EndTime   time.Time           `json:"end_time"`
+	Bins      []distribution.Bin  `json:"bins"`
+}
+
+// getTrafficHistogram counts the readings of the given sensors, or of all
+// of them, per speed or volume bin. Bins are 5 km/h or 10 vehicles wide
+// unless bin_width says otherwise.
+func (server *Server) getTrafficHistogram(ctx *gin.Context) {
+	req := trafficHistogramRequest{Metric: string(distribution.MetricSpeed)}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	metric, err := distribution.ParseMetric(req.Metric)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	binWidth := req.BinWidth
+	if binWidth == 0 {
+		binWidth = metric.DefaultBinWidth()
+	}
+
+	sensorIDs, err := parseSensorIDs(req.SensorIDs)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	sensorIDs, err = server.excludeLowQuality(ctx, req.qualityFilter, sensorIDs)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	startTime, endTime, err := resolveTimeRange(req.StartTime, req.EndTime, defaultDistributionRange)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// A narrow bin width over a wide range of values is refused before the
+	// readings are counted
+	bounds, err := server.store.GetTrafficValueRange(ctx, db.GetTrafficValueRangeParams{
+		StartTime: pgtype.Timestamp{Time: startTime, Valid: true},
+		EndTime:   pgtype.Timestamp{Time: endTime, Valid: true},
+		SensorIds: sensorIDs,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	low, high := bounds.MinSpeed, bounds.MaxSpeed
+	if metric == distribution.MetricVolume {
+		low, high = bounds.MinVolume, bounds.MaxVolume
+	}
+	if err := distribution.CheckRange(low, high, binWidth); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var counts []distribution.Count
+	switch {
+	case bounds.Readings == 0:
+		// Nothing to count
+	case metric == distribution.MetricVolume:
+		rows, err := server.store.GetVolumeHistogram(ctx, db.GetVolumeHistogramParams{
+			BinWidth:  binWidth,
+			StartTime: pgtype.Timestamp{Time: startTime, Valid: true},
+			EndTime:   pgtype.Timestamp{Time: endTime, Valid: true},
+			SensorIds: sensorIDs,
+		})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		for _, row := range rows {
+			counts = append(counts, distribution.Count(row))
+		}
+	default:
+		rows, err := server.store.GetSpeedHistogram(ctx, db.GetSpeedHistogramParams{
+			BinWidth:  binWidth,
+			StartTime: pgtype.Timestamp{Time: startTime, Valid: true},
+			EndTime:   pgtype.Timestamp{Time: endTime, Valid: true},
+			SensorIds: sensorIDs,
+		})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		for _, row := range rows {
+			counts = append(counts, distribution.Count(row))
+		}
+	}
+
+	bins, err := distribution.Build(counts, binWidth)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, trafficHistogramResponse{
+		Metric:    metric,
+		BinWidth:  binWidth,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Bins:      bins,
+	})
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"smart_city/traffic_flow/export"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// defaultExportRange is the time range of an export without start_time
+const defaultExportRange = 24 * time.Hour
+
+// exportErrorTrailer carries the error that cut an export short, which can
+// no longer change the status once rows have been streamed
+const exportErrorTrailer = "X-Export-Error"
+
+type trafficExportRequest struct {
+	Format    string     `form:"format"`
+	StartTime *time.Time `form:"start_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndTime   *time.Time `form:"end_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	SensorIDs []string   `form:"sensor_id"`
+	// WithSensors adds the sensor's latitude, longitude and type to each row
+	WithSensors bool `form:"with_sensors"`
+	// After resumes an interrupted export after the "timestamp,sensor_id"
+	// of the last row received
+	After string `form:"after"`
+}
+
+// flushingWriter pushes each flushed page of an export to the client
+type flushingWriter struct {
+	export.Writer
+	response http.Flusher
+}
+
+func (writer flushingWriter) Flush() error {
+	if err := writer.Writer.Flush(); err != nil {
+		return err
+	}
+	writer.response.Flush()
+	return nil
+}
+
+// exportTrafficData streams raw readings as CSV (the default), NDJSON or
+// Parquet with chunked transfer, in (timestamp, sensor_id) order. Without
+// end_time the range ends now and without start_time it covers the
+// preceding 24 hours. An export that breaks off is resumed by repeating the
+// request with after set to the timestamp and sensor_id of the last row
+// received; for CSV these are its first two columns, and the resumed rows
+// come without a header so that they can be appended.
+func (server *Server) exportTrafficData(ctx *gin.Context) {
+	req := trafficExportRequest{Format: string(export.FormatCSV)}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	format, err := export.ParseFormat(req.Format)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	sensorIDs, err := parseSensorIDs(req.SensorIDs)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	startTime, endTime, err := resolveTimeRange(req.StartTime, req.EndTime, defaultExportRange)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	exportReq := export.Request{
+		StartTime:   startTime,
+		EndTime:     endTime,
+		SensorIDs:   sensorIDs,
+		WithSensors: req.WithSensors,
+	}
+	if req.After != "" {
+		after, err := export.ParseCursor(req.After)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		exportReq.After = &after
+	}
+
+	filename := fmt.Sprintf("traffic_data_%s_%s.%s", startTime.Format("20060102T150405Z"), endTime.Format("20060102T150405Z"), format)
+	ctx.Header("Content-Type", format.ContentType())
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Header("Trailer", exportErrorTrailer)
+	ctx.Status(http.StatusOK)
+
+	// A resumed CSV export is appended to what was received, which already
+	// starts with the header
+	var writer export.Writer
+	if format == export.FormatCSV {
+		writer, err = export.NewCSVWriter(ctx.Writer, req.WithSensors, exportReq.After == nil)
+	} else {
+		writer, err = export.NewWriter(ctx.Writer, format, req.WithSensors)
+	}
+	if err == nil {
+		var result export.Result
+		result, err = server.exports.Export(ctx.Request.Context(), exportReq, flushingWriter{Writer: writer, response: ctx.Writer})
+		if err != nil {
+			event := log.Error().Err(err).Int64("rows", result.Rows)
+			if result.Last != nil {
+				event = event.Stringer("last", result.Last)
+			}
+			event.Msg("traffic export cut short")
+		} else {
+			// A Parquet file is only valid once its footer is written
+			err = writer.Close()
+		}
+	}
+	if err != nil {
+		ctx.Writer.Header().Set(exportErrorTrailer, err.Error())
+	}
+}
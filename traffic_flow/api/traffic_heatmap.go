@@ -0,0 +1,97 @@
+package api
+
+import (
+	"net/http"
+	"smart_city/traffic_flow/geo"
+	"smart_city/traffic_flow/rollup"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultHeatmapRange is the time window of a heatmap request without
+// start_time
+const defaultHeatmapRange = time.Hour
+
+type trafficHeatmapRequest struct {
+	Precision int        `form:"precision" binding:"min=1,max=9"`
+	StartTime *time.Time `form:"start_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndTime   *time.Time `form:"end_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	BBox      string     `form:"bbox"`
+	Format    string     `form:"format" binding:"oneof=json geojson"`
+	qualityFilter
+}
+
+type trafficHeatmapResponse struct {
+	Precision int           `json:"precision"`
+	Source    rollup.Source `json:"source"`
+	StartTime time.Time     `json:"start_time"`
+	EndTime   time.Time     `json:"end_time"`
+	Cells     []geo.Cell    `json:"cells"`
+}
+
+// getTrafficHeatmap aggregates readings into geohash cells, precision 6
+// (about 1.2 by 0.6 km) unless given, optionally only inside
+// bbox=min_lon,min_lat,max_lon,max_lat. Without end_time the window ends
+// now and without start_time it covers the preceding hour. format=geojson
+// returns the cells as a FeatureCollection of polygons.
+func (server *Server) getTrafficHeatmap(ctx *gin.Context) {
+	req := trafficHeatmapRequest{Precision: 6, Format: "json"}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	box := geo.World
+	if req.BBox != "" {
+		var err error
+		box, err = geo.ParseBox(req.BBox)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	startTime, endTime, err := resolveTimeRange(req.StartTime, req.EndTime, defaultHeatmapRange)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rows, plan, err := server.rollups.SensorSummaries(ctx, startTime, endTime, box)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	setDataSource(ctx, plan)
+
+	low, err := server.lowQualitySensors(ctx, req.qualityFilter)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if low != nil {
+		kept := rows[:0]
+		for _, row := range rows {
+			if !low[row.SensorID] {
+				kept = append(kept, row)
+			}
+		}
+		rows = kept
+	}
+
+	cells := geo.Heatmap(rows, req.Precision)
+	if req.Format == "geojson" {
+		ctx.Header("Content-Type", "application/geo+json")
+		ctx.JSON(http.StatusOK, geo.HeatmapFeatures(cells))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, trafficHeatmapResponse{
+		Precision: req.Precision,
+		Source:    plan.Source,
+		StartTime: plan.StartTime,
+		EndTime:   plan.EndTime,
+		Cells:     cells,
+	})
+}
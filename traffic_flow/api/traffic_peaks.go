@@ -0,0 +1,206 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"smart_city/traffic_flow/episode"
+	"smart_city/traffic_flow/peak"
+	"smart_city/traffic_flow/rollup"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// defaultPeakRange is the time range of a peak-hour request without
+	// start_time
+	defaultPeakRange = 7 * 24 * time.Hour
+	// defaultEpisodeRange is the time range of an episode request without
+	// start_time
+	defaultEpisodeRange = 24 * time.Hour
+)
+
+type peakHoursRequest struct {
+	SensorIDs []string   `form:"sensor_id"`
+	StartTime *time.Time `form:"start_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndTime   *time.Time `form:"end_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	// TZ is the IANA time zone the day is split into AM and PM in
+	TZ string `form:"tz"`
+	qualityFilter
+}
+
+type peakHoursResponse struct {
+	Source    rollup.Source      `json:"source"`
+	StartTime time.Time          `json:"start_time"`
+	EndTime   time.Time          `json:"end_time"`
+	TZ        string             `json:"tz"`
+	Sensors   []peak.SensorPeaks `json:"sensors"`
+}
+
+// getPeakHours returns the morning and evening peak hours of each sensor's
+// average day with their peak-hour factors. Without end_time the range
+// ends now and without start_time it covers the preceding 7 days; times of
+// day are in tz, UTC unless given.
+func (server *Server) getPeakHours(ctx *gin.Context) {
+	req := peakHoursRequest{TZ: "UTC"}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	loc, err := time.LoadLocation(req.TZ)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	sensorIDs, err := parseSensorIDs(req.SensorIDs)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	sensorIDs, err = server.excludeLowQuality(ctx, req.qualityFilter, sensorIDs)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	startTime, endTime, err := resolveTimeRange(req.StartTime, req.EndTime, defaultPeakRange)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	sensors, plan, err := server.peaks.Peaks(ctx, startTime, endTime, sensorIDs, loc)
+	if err != nil {
+		ctx.JSON(peakErrorStatus(err), errorResponse(err))
+		return
+	}
+	setDataSource(ctx, plan)
+
+	ctx.JSON(http.StatusOK, peakHoursResponse{
+		Source:    plan.Source,
+		StartTime: plan.StartTime,
+		EndTime:   plan.EndTime,
+		TZ:        loc.String(),
+		Sensors:   sensors,
+	})
+}
+
+type congestionEpisodesRequest struct {
+	SensorIDs   []string   `form:"sensor_id"`
+	StartTime   *time.Time `form:"start_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndTime     *time.Time `form:"end_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	MaxGap      string     `form:"max_gap"`
+	MinDuration string     `form:"min_duration"`
+	Limit       int        `form:"limit" binding:"omitempty,min=1,max=10000"`
+	qualityFilter
+}
+
+// parseDurations reads max_gap and min_duration
+func (req congestionEpisodesRequest) parseDurations() (time.Duration, time.Duration, error) {
+	maxGap, err := time.ParseDuration(req.MaxGap)
+	if err != nil {
+		return 0, 0, err
+	}
+	minDuration, err := time.ParseDuration(req.MinDuration)
+	if err != nil {
+		return 0, 0, err
+	}
+	return maxGap, minDuration, nil
+}
+
+// listCongestionEpisodes returns the stretches of uninterrupted high
+// congestion per sensor, in sensor and time order. Readings more than
+// max_gap apart, 15 minutes by default, split an episode; episodes shorter
+// than min_duration are left out. Without end_time the range ends now and
+// without start_time it covers the preceding 24 hours.
+func (server *Server) listCongestionEpisodes(ctx *gin.Context) {
+	req := congestionEpisodesRequest{MaxGap: "15m", MinDuration: "0s", Limit: 1000}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	maxGap, minDuration, err := req.parseDurations()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	sensorIDs, err := parseSensorIDs(req.SensorIDs)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	sensorIDs, err = server.excludeLowQuality(ctx, req.qualityFilter, sensorIDs)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	startTime, endTime, err := resolveTimeRange(req.StartTime, req.EndTime, defaultEpisodeRange)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	episodes, err := server.episodes.List(ctx, startTime, endTime, sensorIDs, maxGap, minDuration)
+	if err != nil {
+		ctx.JSON(peakErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	if len(episodes) > req.Limit {
+		episodes = episodes[:req.Limit]
+	}
+	ctx.JSON(http.StatusOK, episodes)
+}
+
+// getCongestionEpisodeSummary ranks the city-wide longest congestion
+// episodes and the sensors with the most episodes, limit of each, 10 by
+// default. The range, episode and quality parameters are those of
+// listCongestionEpisodes; sensor_id is ignored.
+func (server *Server) getCongestionEpisodeSummary(ctx *gin.Context) {
+	req := congestionEpisodesRequest{MaxGap: "15m", MinDuration: "0s", Limit: 10}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	maxGap, minDuration, err := req.parseDurations()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	sensorIDs, err := server.excludeLowQuality(ctx, req.qualityFilter, nil)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	startTime, endTime, err := resolveTimeRange(req.StartTime, req.EndTime, defaultEpisodeRange)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	summary, err := server.episodes.Summary(ctx, startTime, endTime, sensorIDs, maxGap, minDuration, req.Limit)
+	if err != nil {
+		ctx.JSON(peakErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, summary)
+}
+
+// peakErrorStatus maps a peak or episode service error to an HTTP status
+func peakErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, peak.ErrRangeTooLong), errors.Is(err, episode.ErrInvalidGap):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"smart_city/traffic_flow/rollup"
+	"smart_city/traffic_flow/series"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultSeriesRange is the time range of a series request without start_time
+const defaultSeriesRange = 24 * time.Hour
+
+type trafficSeriesRequest struct {
+	Bucket     string     `form:"bucket" binding:"required"`
+	StartTime  *time.Time `form:"start_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndTime    *time.Time `form:"end_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	SensorIDs  []string   `form:"sensor_id"`
+	Aggregates []string   `form:"aggregate"`
+	qualityFilter
+	// Fill gapfills the series, filling empty buckets with null, locf or linear
+	Fill string `form:"fill"`
+}
+
+type trafficSeriesResponse struct {
+	Bucket     string             `json:"bucket"`
+	Source     rollup.Source      `json:"source"`
+	StartTime  time.Time          `json:"start_time"`
+	EndTime    time.Time          `json:"end_time"`
+	Fill       series.Fill        `json:"fill,omitempty"`
+	Aggregates []series.Aggregate `json:"aggregates"`
+	Series     []series.Series    `json:"series"`
+}
+
+// getTrafficSeries aggregates readings into time buckets of any width, one
+// series per sensor. Sensors and aggregates may be given as repeated or
+// comma separated query parameters; without end_time the range ends now and
+// without start_time it covers the preceding 24 hours. Long ranges on hour
+// or day boundaries with whole-hour or whole-day buckets are read from a
+// rollup. With fill every bucket of the range gets a
+// point for every sensor, also those without any readings in the range,
+// flagged as filled when the sensor had no readings in it, whose aggregates
+// are null, carried forward (locf) or interpolated (linear).
+func (server *Server) getTrafficSeries(ctx *gin.Context) {
+	var req trafficSeriesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	bucket, err := series.ParseBucket(req.Bucket)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	aggregates, err := series.ParseAggregates(req.Aggregates)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var fill series.Fill
+	if req.Fill != "" {
+		fill, err = series.ParseFill(req.Fill)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	sensorIDs, err := parseSensorIDs(req.SensorIDs)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	sensorIDs, err = server.excludeLowQuality(ctx, req.qualityFilter, sensorIDs)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	endTime := time.Now().UTC()
+	if req.EndTime != nil {
+		endTime = req.EndTime.UTC()
+	}
+	startTime := endTime.Add(-defaultSeriesRange)
+	if req.StartTime != nil {
+		startTime = req.StartTime.UTC()
+	}
+	if !startTime.Before(endTime) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "start_time must be before end_time"})
+		return
+	}
+	if err := bucket.Check(startTime, endTime); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rsp, err := server.trafficSeries(ctx, bucket, startTime, endTime, sensorIDs, aggregates, fill)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// trafficSeries reads the series of the sensors over the range, gapfilled
+// when fill is set, and reports the data source it was read from
+func (server *Server) trafficSeries(ctx *gin.Context, bucket series.Bucket, startTime, endTime time.Time, sensorIDs []int32, aggregates []series.Aggregate, fill series.Fill) (trafficSeriesResponse, error) {
+	var result []series.Series
+	var plan rollup.Plan
+	if fill != "" {
+		rows, gapfilledPlan, err := server.rollups.GapfilledSeries(ctx, bucket.Width, startTime, endTime, sensorIDs)
+		if err != nil {
+			return trafficSeriesResponse{}, err
+		}
+		result, plan = series.BuildFilled(rows, aggregates, fill), gapfilledPlan
+	} else {
+		rows, seriesPlan, err := server.rollups.Series(ctx, bucket.Width, startTime, endTime, sensorIDs)
+		if err != nil {
+			return trafficSeriesResponse{}, err
+		}
+		result, plan = series.Build(rows, aggregates), seriesPlan
+	}
+	setDataSource(ctx, plan)
+
+	return trafficSeriesResponse{
+		Bucket:     bucket.String(),
+		Source:     plan.Source,
+		StartTime:  plan.StartTime,
+		EndTime:    plan.EndTime,
+		Fill:       fill,
+		Aggregates: aggregates,
+		Series:     result,
+	}, nil
+}
+
+// parseSensorIDs reads sensor ids given as repeated and/or comma separated
+// values; nil means all sensors
+func parseSensorIDs(values []string) ([]int32, error) {
+	var sensorIDs []int32
+	for _, value := range values {
+		for _, field := range strings.Split(value, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			id, err := strconv.ParseInt(field, 10, 32)
+			if err != nil || id < 1 {
+				return nil, fmt.Errorf("invalid sensor_id %q", field)
+			}
+			sensorIDs = append(sensorIDs, int32(id))
+		}
+	}
+	return sensorIDs, nil
+}
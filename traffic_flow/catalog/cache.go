@@ -0,0 +1,151 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	db "smart_city/traffic_flow/db/sqlc"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog/log"
+)
+
+// Sensor statuses. Stale and silent are set by the liveness monitor and
+// cleared again as soon as the sensor reports; any other status, such as
+// offline, is set by an operator and left alone by the monitor.
+const (
+	StatusActive  = "active"
+	StatusStale   = "stale"
+	StatusSilent  = "silent"
+	StatusOffline = "offline"
+)
+
+// AcceptsReadings reports whether readings from a sensor with the given
+// status are ingested directly. Sensors the monitor found silent must be
+// able to report again; those an operator took offline stay quarantined.
+func AcceptsReadings(status string) bool {
+	return status == StatusActive || status == StatusStale || status == StatusSilent
+}
+
+// missingTTL is how long a sensor id that was not found is answered from
+// the cache, so that readings for an unknown sensor do not hit the database
+// one by one
+const missingTTL = 30 * time.Second
+
+// SensorState is the part of a sensor that ingestion depends on
+type SensorState struct {
+	TypeID int32
+	Status string
+}
+
+// SensorCache keeps the type and status of every sensor in memory so
+// ingestion does not need a catalog lookup per reading. The catalog service updates it on
+// every mutation; Run additionally reloads it periodically to pick up
+// changes made by other instances or directly in the database.
+type SensorCache struct {
+	store   *db.Store
+	mu      sync.RWMutex
+	sensors map[int32]SensorState
+	// missing holds when each negative lookup expires
+	missing map[int32]time.Time
+}
+
+func NewSensorCache(store *db.Store) *SensorCache {
+	return &SensorCache{
+		store:   store,
+		sensors: make(map[int32]SensorState),
+		missing: make(map[int32]time.Time),
+	}
+}
+
+// Refresh replaces the cached sensors with the current catalog
+func (cache *SensorCache) Refresh(ctx context.Context) error {
+	rows, err := cache.store.ListSensorStates(ctx)
+	if err != nil {
+		return err
+	}
+
+	sensors := make(map[int32]SensorState, len(rows))
+	for _, row := range rows {
+		sensors[row.SensorID] = SensorState{TypeID: row.TypeID, Status: row.Status}
+	}
+
+	cache.mu.Lock()
+	cache.sensors = sensors
+	cache.missing = make(map[int32]time.Time)
+	cache.mu.Unlock()
+	return nil
+}
+
+// Run refreshes the cache every interval until ctx is done
+func (cache *SensorCache) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := cache.Refresh(ctx); err != nil {
+				log.Error().Err(err).Msg("cannot refresh sensor cache")
+			}
+		}
+	}
+}
+
+// Lookup returns the state of a sensor and whether it exists. Sensors
+// missing from the cache are looked up in case they were created after the
+// last refresh; one that does not exist either is not looked up again for
+// missingTTL.
+func (cache *SensorCache) Lookup(ctx context.Context, sensorID int32) (SensorState, bool, error) {
+	cache.mu.RLock()
+	state, ok := cache.sensors[sensorID]
+	expires, missing := cache.missing[sensorID]
+	cache.mu.RUnlock()
+	if ok {
+		return state, true, nil
+	}
+	if missing && time.Now().Before(expires) {
+		return SensorState{}, false, nil
+	}
+
+	row, err := cache.store.GetSensorState(ctx, sensorID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		cache.mu.Lock()
+		cache.missing[sensorID] = time.Now().Add(missingTTL)
+		cache.mu.Unlock()
+		return SensorState{}, false, nil
+	}
+	if err != nil {
+		return SensorState{}, false, err
+	}
+
+	state = SensorState{TypeID: row.TypeID, Status: row.Status}
+	cache.set(sensorID, state)
+	return state, true, nil
+}
+
+// SetStatus updates the cached status of a known sensor
+func (cache *SensorCache) SetStatus(sensorID int32, status string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if state, ok := cache.sensors[sensorID]; ok {
+		state.Status = status
+		cache.sensors[sensorID] = state
+	}
+}
+
+func (cache *SensorCache) set(sensorID int32, state SensorState) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.sensors[sensorID] = state
+	delete(cache.missing, sensorID)
+}
+
+func (cache *SensorCache) remove(sensorID int32) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	delete(cache.sensors, sensorID)
+}
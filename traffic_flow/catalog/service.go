@@ -0,0 +1,147 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "smart_city/traffic_flow/db/sqlc"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrInvalidRequest wraps every validation failure so transports can map it
+// to their "bad request" status
+var ErrInvalidRequest = errors.New("invalid request")
+
+// Service owns the sensor and sensor type mutations. The HTTP and gRPC
+// transports both go through it so they apply identical rules, and it keeps
+// the sensor cache in step with every change.
+type Service struct {
+	store   *db.Store
+	sensors *SensorCache
+}
+
+func NewService(store *db.Store, sensors *SensorCache) *Service {
+	return &Service{store: store, sensors: sensors}
+}
+
+func validate(req any) error {
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+	return nil
+}
+
+type CreateSensorTypeRequest struct {
+	TypeName    string `json:"type_name" binding:"required"`
+	Description string `json:"description" binding:"required"`
+}
+
+func (service *Service) CreateSensorType(ctx context.Context, req CreateSensorTypeRequest) (db.SensorType, error) {
+	if err := validate(&req); err != nil {
+		return db.SensorType{}, err
+	}
+
+	arg := db.CreateSensorTypeParams{
+		TypeName:    req.TypeName,
+		Description: pgtype.Text{String: req.Description, Valid: true},
+	}
+	return service.store.CreateSensorType(ctx, arg)
+}
+
+type UpdateSensorTypeRequest struct {
+	TypeName    string `json:"type_name" binding:"required"`
+	Description string `json:"description" binding:"required"`
+}
+
+func (service *Service) UpdateSensorType(ctx context.Context, typeID int32, req UpdateSensorTypeRequest) (db.SensorType, error) {
+	if err := validate(&req); err != nil {
+		return db.SensorType{}, err
+	}
+
+	arg := db.UpdateSensorTypeParams{
+		TypeID:      typeID,
+		TypeName:    req.TypeName,
+		Description: pgtype.Text{String: req.Description, Valid: true},
+	}
+	return service.store.UpdateSensorType(ctx, arg)
+}
+
+func (service *Service) DeleteSensorType(ctx context.Context, typeID int32) error {
+	return service.store.DeleteSensorType(ctx, typeID)
+}
+
+type CreateSensorRequest struct {
+	Latitude         float64 `json:"latitude" binding:"required"`
+	Longitude        float64 `json:"longitude" binding:"required"`
+	TypeID           int32   `json:"type_id" binding:"required,min=1"`
+	InstallationDate string  `json:"installation_date" binding:"required"`
+	Status           string  `json:"status" binding:"omitempty,max=20"`
+}
+
+func (service *Service) CreateSensor(ctx context.Context, req CreateSensorRequest) (db.Sensor, error) {
+	if err := validate(&req); err != nil {
+		return db.Sensor{}, err
+	}
+
+	// Set default status if not provided
+	status := req.Status
+	if status == "" {
+		status = StatusActive
+	}
+
+	// Convert string date to pgtype.Date
+	var installationDate pgtype.Date
+	if err := installationDate.Scan(req.InstallationDate); err != nil {
+		return db.Sensor{}, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+
+	arg := db.CreateSensorParams{
+		Latitude:         req.Latitude,
+		Longitude:        req.Longitude,
+		TypeID:           req.TypeID,
+		InstallationDate: installationDate,
+		Status:           status,
+	}
+
+	sensor, err := service.store.CreateSensor(ctx, arg)
+	if err != nil {
+		return sensor, err
+	}
+
+	service.sensors.set(sensor.SensorID, SensorState{TypeID: sensor.TypeID, Status: sensor.Status})
+	return sensor, nil
+}
+
+type UpdateSensorRequest struct {
+	Status string `json:"status" binding:"required,max=20"`
+}
+
+func (service *Service) UpdateSensor(ctx context.Context, sensorID int32, req UpdateSensorRequest) (db.Sensor, error) {
+	if err := validate(&req); err != nil {
+		return db.Sensor{}, err
+	}
+
+	arg := db.UpdateSensorStatusParams{
+		SensorID: sensorID,
+		Status:   req.Status,
+	}
+
+	sensor, err := service.store.UpdateSensorStatus(ctx, arg)
+	if err != nil {
+		return sensor, err
+	}
+
+	service.sensors.set(sensor.SensorID, SensorState{TypeID: sensor.TypeID, Status: sensor.Status})
+	return sensor, nil
+}
+
+func (service *Service) DeleteSensor(ctx context.Context, sensorID int32) error {
+	if err := service.store.DeleteSensor(ctx, sensorID); err != nil {
+		return err
+	}
+
+	service.sensors.remove(sensorID)
+	return nil
+}
@@ -0,0 +1,88 @@
+package cityindex
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config controls how the city-wide congestion index is computed
+type Config struct {
+	// Enabled computes the index in the background; when false the stored
+	// series is still served but not extended
+	Enabled bool
+	// Interval is both how often the index is computed and the window of
+	// readings each value covers
+	Interval time.Duration
+	// FreeFlowHistory is the readings each sensor's free-flow speed is
+	// estimated from
+	FreeFlowHistory time.Duration
+	// FreeFlowRefresh is how often the free-flow speeds are estimated again
+	FreeFlowRefresh time.Duration
+	// FreeFlowPercentile of a sensor's speeds is taken as its free-flow
+	// speed unless it is on a corridor configured with one
+	FreeFlowPercentile float64
+	// TypicalHistory is how far back the typical index for the current
+	// hour of the week is averaged over
+	TypicalHistory time.Duration
+}
+
+// LoadConfig reads the congestion index settings from the environment,
+// falling back to defaults for unset variables
+func LoadConfig() (Config, error) {
+	config := Config{
+		Enabled:            true,
+		Interval:           5 * time.Minute,
+		FreeFlowHistory:    28 * 24 * time.Hour,
+		FreeFlowRefresh:    24 * time.Hour,
+		FreeFlowPercentile: 85,
+		TypicalHistory:     28 * 24 * time.Hour,
+	}
+
+	if value := os.Getenv("CONGESTION_INDEX_ENABLED"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return config, fmt.Errorf("cannot parse CONGESTION_INDEX_ENABLED: %w", err)
+		}
+		config.Enabled = enabled
+	}
+
+	if value := os.Getenv("CONGESTION_INDEX_FREE_FLOW_PERCENTILE"); value != "" {
+		percentile, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return config, fmt.Errorf("cannot parse CONGESTION_INDEX_FREE_FLOW_PERCENTILE: %w", err)
+		}
+		config.FreeFlowPercentile = percentile
+	}
+
+	durations := map[string]*time.Duration{
+		"CONGESTION_INDEX_INTERVAL":          &config.Interval,
+		"CONGESTION_INDEX_FREE_FLOW_HISTORY": &config.FreeFlowHistory,
+		"CONGESTION_INDEX_FREE_FLOW_REFRESH": &config.FreeFlowRefresh,
+		"CONGESTION_INDEX_TYPICAL_HISTORY":   &config.TypicalHistory,
+	}
+	for key, target := range durations {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return config, fmt.Errorf("cannot parse %s: %w", key, err)
+		}
+		*target = duration
+	}
+
+	if config.Interval < time.Minute {
+		return config, fmt.Errorf("CONGESTION_INDEX_INTERVAL must be at least 1m")
+	}
+	if config.FreeFlowHistory <= 0 || config.FreeFlowRefresh <= 0 || config.TypicalHistory <= 0 {
+		return config, fmt.Errorf("CONGESTION_INDEX_FREE_FLOW_HISTORY, CONGESTION_INDEX_FREE_FLOW_REFRESH and CONGESTION_INDEX_TYPICAL_HISTORY must be positive")
+	}
+	if config.FreeFlowPercentile <= 0 || config.FreeFlowPercentile >= 100 {
+		return config, fmt.Errorf("CONGESTION_INDEX_FREE_FLOW_PERCENTILE must be between 0 and 100")
+	}
+
+	return config, nil
+}
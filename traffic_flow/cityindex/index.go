@@ -0,0 +1,113 @@
+package cityindex
+
+import (
+	db "smart_city/traffic_flow/db/sqlc"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Index is the congestion of the city over one window
+type Index struct {
+	// Value is the volume-weighted share of free-flow speed lost in
+	// percent, 0 when traffic flows freely and 100 when it stands still
+	Value float64 `json:"value"`
+	// Sensors counts the sensors with readings and a free-flow speed
+	Sensors  int32   `json:"sensors"`
+	Volume   int64   `json:"volume"`
+	AvgSpeed float64 `json:"avg_speed"`
+}
+
+// Compute weighs each sensor's loss of speed against its free-flow speed,
+// given in km/h by sensor id, by the vehicles it counted. Sensors without
+// a free-flow speed are left out, and speeds above it count as no loss.
+func Compute(flows []db.GetActiveSensorFlowsRow, freeFlow map[int32]float64) Index {
+	var index Index
+	var weightedLoss, weightedSpeed float64
+	for _, flow := range flows {
+		speed, ok := freeFlow[flow.SensorID]
+		if !ok || speed <= 0 {
+			continue
+		}
+
+		loss := min(max(1-flow.AvgSpeed/speed, 0), 1)
+		volume := float64(flow.Volume)
+		weightedLoss += volume * loss
+		weightedSpeed += volume * flow.AvgSpeed
+		index.Volume += flow.Volume
+		index.Sensors++
+	}
+
+	if index.Volume > 0 {
+		index.Value = weightedLoss / float64(index.Volume) * 100
+		index.AvgSpeed = weightedSpeed / float64(index.Volume)
+	}
+	return index
+}
+
+// Intervals computes the index of every interval in rows, which hold the
+// flows of one interval after another, and leaves out intervals in which
+// no sensor had a free-flow speed
+func Intervals(rows []db.GetSensorFlowBucketsRow, freeFlow map[int32]float64) []db.CongestionIndex {
+	values := []db.CongestionIndex{}
+	for i := 0; i < len(rows); {
+		end := rows[i].Bucket
+		var flows []db.GetActiveSensorFlowsRow
+		for ; i < len(rows) && rows[i].Bucket.Time.Equal(end.Time); i++ {
+			flows = append(flows, db.GetActiveSensorFlowsRow{
+				SensorID: rows[i].SensorID,
+				Readings: rows[i].Readings,
+				Volume:   rows[i].Volume,
+				AvgSpeed: rows[i].AvgSpeed,
+			})
+		}
+
+		index := Compute(flows, freeFlow)
+		if index.Sensors == 0 {
+			continue
+		}
+		values = append(values, db.CongestionIndex{
+			Timestamp: end,
+			Value:     index.Value,
+			Sensors:   index.Sensors,
+			Volume:    index.Volume,
+			AvgSpeed:  index.AvgSpeed,
+		})
+	}
+	return values
+}
+
+// Aggregate averages index values in time order into buckets of width the
+// way GetCongestionIndexBuckets does with the stored series: weighed by
+// volume, or plainly when a bucket counted no vehicles
+func Aggregate(values []db.CongestionIndex, width time.Duration) []db.GetCongestionIndexBucketsRow {
+	buckets := []db.GetCongestionIndexBucketsRow{}
+	var weighted, sum float64
+	for _, value := range values {
+		start := value.Timestamp.Time.Truncate(width)
+		last := len(buckets) - 1
+		if last < 0 || !buckets[last].Bucket.Time.Equal(start) {
+			buckets = append(buckets, db.GetCongestionIndexBucketsRow{
+				Bucket:   pgtype.Timestamp{Time: start, Valid: true},
+				MinValue: value.Value,
+				MaxValue: value.Value,
+			})
+			last++
+			weighted, sum = 0, 0
+		}
+
+		bucket := &buckets[last]
+		bucket.Samples++
+		bucket.Volume += value.Volume
+		bucket.MinValue = min(bucket.MinValue, value.Value)
+		bucket.MaxValue = max(bucket.MaxValue, value.Value)
+		weighted += value.Value * float64(value.Volume)
+		sum += value.Value
+		if bucket.Volume > 0 {
+			bucket.Value = weighted / float64(bucket.Volume)
+		} else {
+			bucket.Value = sum / float64(bucket.Samples)
+		}
+	}
+	return buckets
+}
@@ -0,0 +1,87 @@
+package cityindex
+
+import (
+	db "smart_city/traffic_flow/db/sqlc"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompute(t *testing.T) {
+	freeFlow := map[int32]float64{1: 60, 2: 100, 3: 50}
+	flows := []db.GetActiveSensorFlowsRow{
+		// Half the free-flow speed
+		{SensorID: 1, Volume: 300, AvgSpeed: 30},
+		// Free flow, and faster still counts as no loss
+		{SensorID: 2, Volume: 100, AvgSpeed: 110},
+		// No free-flow speed known
+		{SensorID: 4, Volume: 1000, AvgSpeed: 5},
+	}
+
+	index := Compute(flows, freeFlow)
+	require.Equal(t, int32(2), index.Sensors)
+	require.Equal(t, int64(400), index.Volume)
+	// 300 vehicles lose half their speed, 100 lose none
+	require.InDelta(t, 37.5, index.Value, 1e-9)
+	require.InDelta(t, (300*30+100*110)/400.0, index.AvgSpeed, 1e-9)
+}
+
+func TestComputeEmpty(t *testing.T) {
+	require.Equal(t, Index{}, Compute(nil, map[int32]float64{1: 60}))
+
+	// Sensors without vehicles carry no weight
+	index := Compute([]db.GetActiveSensorFlowsRow{{SensorID: 1, AvgSpeed: 0}}, map[int32]float64{1: 60})
+	require.Equal(t, int32(1), index.Sensors)
+	require.Zero(t, index.Value)
+}
+
+func TestIntervals(t *testing.T) {
+	at := func(minute int) pgtype.Timestamp {
+		return pgtype.Timestamp{Time: time.Date(2026, 10, 16, 8, minute, 0, 0, time.UTC), Valid: true}
+	}
+	freeFlow := map[int32]float64{1: 60, 2: 100}
+	rows := []db.GetSensorFlowBucketsRow{
+		{Bucket: at(5), SensorID: 1, Volume: 100, AvgSpeed: 30},
+		{Bucket: at(5), SensorID: 2, Volume: 100, AvgSpeed: 100},
+		// Only a sensor without a free-flow speed reported
+		{Bucket: at(10), SensorID: 3, Volume: 50, AvgSpeed: 20},
+		{Bucket: at(15), SensorID: 2, Volume: 40, AvgSpeed: 50},
+	}
+
+	values := Intervals(rows, freeFlow)
+	require.Len(t, values, 2)
+	require.Equal(t, at(5), values[0].Timestamp)
+	require.Equal(t, int32(2), values[0].Sensors)
+	require.InDelta(t, 25, values[0].Value, 1e-9)
+	require.Equal(t, at(15), values[1].Timestamp)
+	require.InDelta(t, 50, values[1].Value, 1e-9)
+}
+
+func TestAggregate(t *testing.T) {
+	at := func(hour, minute int) pgtype.Timestamp {
+		return pgtype.Timestamp{Time: time.Date(2026, 10, 16, hour, minute, 0, 0, time.UTC), Valid: true}
+	}
+	values := []db.CongestionIndex{
+		{Timestamp: at(8, 5), Value: 10, Volume: 300},
+		{Timestamp: at(8, 30), Value: 50, Volume: 100},
+		// No vehicles in the hour, so its values are averaged plainly
+		{Timestamp: at(9, 0), Value: 20},
+		{Timestamp: at(9, 5), Value: 40},
+	}
+
+	buckets := Aggregate(values, time.Hour)
+	require.Len(t, buckets, 2)
+	require.Equal(t, db.GetCongestionIndexBucketsRow{
+		Bucket:   at(8, 0),
+		Samples:  2,
+		Value:    20,
+		MinValue: 10,
+		MaxValue: 50,
+		Volume:   400,
+	}, buckets[0])
+	require.Equal(t, at(9, 0), buckets[1].Bucket)
+	require.InDelta(t, 30, buckets[1].Value, 1e-9)
+	require.Empty(t, Aggregate(nil, time.Hour))
+}
@@ -0,0 +1,279 @@
+package cityindex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"smart_city/traffic_flow/anomaly"
+	db "smart_city/traffic_flow/db/sqlc"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrNoIndex = errors.New("no congestion index computed yet")
+	// ErrNoReadings is returned by Compute for an interval in which no
+	// active sensor with a free-flow speed reported; nothing is stored
+	ErrNoReadings = errors.New("no readings to compute the congestion index from")
+)
+
+// Listener receives every newly computed index value
+type Listener func(db.CongestionIndex)
+
+// Current is the latest index value next to its usual value at that hour
+// of the week
+type Current struct {
+	db.CongestionIndex
+	// Typical is the average index at the same hour of the week, nil
+	// without history
+	Typical *float64 `json:"typical"`
+	// Change is Value minus Typical in index points
+	Change *float64 `json:"change"`
+}
+
+// Service computes the city-wide congestion index every Interval, stores
+// it as its own time series and passes each value to its listeners
+type Service struct {
+	store  *db.Store
+	config Config
+
+	mu              sync.RWMutex
+	freeFlow        map[int32]float64
+	freeFlowUpdated time.Time
+
+	listenerMu   sync.RWMutex
+	listeners    map[int]Listener
+	nextListener int
+}
+
+func NewService(store *db.Store, config Config) *Service {
+	return &Service{
+		store:     store,
+		config:    config,
+		listeners: make(map[int]Listener),
+	}
+}
+
+// OnIndex registers a listener for computed index values and returns a
+// function that removes it again
+func (service *Service) OnIndex(listener Listener) (remove func()) {
+	service.listenerMu.Lock()
+	defer service.listenerMu.Unlock()
+
+	id := service.nextListener
+	service.nextListener++
+	service.listeners[id] = listener
+
+	return func() {
+		service.listenerMu.Lock()
+		defer service.listenerMu.Unlock()
+		delete(service.listeners, id)
+	}
+}
+
+func (service *Service) notify(index db.CongestionIndex) {
+	service.listenerMu.RLock()
+	defer service.listenerMu.RUnlock()
+	for _, listener := range service.listeners {
+		listener(index)
+	}
+}
+
+// freeFlowSpeeds returns the free-flow speed of every sensor, loading them
+// again when they are older than FreeFlowRefresh. Sensors on a corridor use
+// the free-flow speed configured for it, so the index and the corridor
+// travel times agree; the others are estimated from their own speeds.
+func (service *Service) freeFlowSpeeds(ctx context.Context) (map[int32]float64, error) {
+	service.mu.RLock()
+	freeFlow, updated := service.freeFlow, service.freeFlowUpdated
+	service.mu.RUnlock()
+	if freeFlow != nil && time.Since(updated) < service.config.FreeFlowRefresh {
+		return freeFlow, nil
+	}
+
+	since := time.Now().UTC().Add(-service.config.FreeFlowHistory)
+	rows, err := service.store.GetFreeFlowSpeeds(ctx, db.GetFreeFlowSpeedsParams{
+		Fraction: service.config.FreeFlowPercentile / 100,
+		Since:    pgtype.Timestamp{Time: since, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot estimate free-flow speeds: %w", err)
+	}
+
+	configured, err := service.store.GetCorridorFreeFlowSpeeds(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load corridor free-flow speeds: %w", err)
+	}
+
+	freeFlow = make(map[int32]float64, len(rows))
+	for _, row := range rows {
+		freeFlow[row.SensorID] = row.FreeFlowSpeed
+	}
+	for _, row := range configured {
+		freeFlow[row.SensorID] = row.FreeFlowSpeed
+	}
+
+	service.mu.Lock()
+	service.freeFlow, service.freeFlowUpdated = freeFlow, time.Now()
+	service.mu.Unlock()
+	return freeFlow, nil
+}
+
+// Compute stores the index over the Interval ending at end, replacing a
+// value already stored for it, and notifies the listeners. An interval
+// without readings is not stored, as an index of 0 would claim free-flowing
+// traffic; ErrNoReadings is returned instead.
+func (service *Service) Compute(ctx context.Context, end time.Time) (db.CongestionIndex, error) {
+	freeFlow, err := service.freeFlowSpeeds(ctx)
+	if err != nil {
+		return db.CongestionIndex{}, err
+	}
+
+	end = end.UTC()
+	flows, err := service.store.GetActiveSensorFlows(ctx, db.GetActiveSensorFlowsParams{
+		StartTime: pgtype.Timestamp{Time: end.Add(-service.config.Interval), Valid: true},
+		EndTime:   pgtype.Timestamp{Time: end, Valid: true},
+	})
+	if err != nil {
+		return db.CongestionIndex{}, err
+	}
+
+	index := Compute(flows, freeFlow)
+	if index.Sensors == 0 {
+		return db.CongestionIndex{}, ErrNoReadings
+	}
+	stored, err := service.store.UpsertCongestionIndex(ctx, db.UpsertCongestionIndexParams{
+		Timestamp: pgtype.Timestamp{Time: end, Valid: true},
+		Value:     index.Value,
+		Sensors:   index.Sensors,
+		Volume:    index.Volume,
+		AvgSpeed:  index.AvgSpeed,
+	})
+	if err != nil {
+		return db.CongestionIndex{}, err
+	}
+
+	service.notify(stored)
+	return stored, nil
+}
+
+// Run computes the index at every multiple of Interval until ctx is done,
+// starting with the interval that ended last. Readings arriving after
+// their interval was computed are not counted.
+func (service *Service) Run(ctx context.Context) {
+	for {
+		end := time.Now().UTC().Truncate(service.config.Interval)
+		_, err := service.Compute(ctx, end)
+		switch {
+		case errors.Is(err, ErrNoReadings):
+			log.Debug().Time("end", end).Msg("no readings for congestion index")
+		case err != nil:
+			log.Error().Err(err).Msg("cannot compute congestion index")
+		}
+
+		next := end.Add(service.config.Interval)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(next)):
+		}
+	}
+}
+
+// Current returns the latest index value and how it compares with the
+// same hour of the week over the typical history
+func (service *Service) Current(ctx context.Context) (Current, error) {
+	latest, err := service.store.GetLatestCongestionIndex(ctx)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Current{}, ErrNoIndex
+	}
+	if err != nil {
+		return Current{}, err
+	}
+
+	at := latest.Timestamp.Time
+	typical, err := service.store.GetTypicalCongestionIndex(ctx, db.GetTypicalCongestionIndexParams{
+		Since:      pgtype.Timestamp{Time: at.Add(-service.config.TypicalHistory), Valid: true},
+		Until:      pgtype.Timestamp{Time: at.Truncate(time.Hour), Valid: true},
+		HourOfWeek: int32(anomaly.HourOfWeek(at)),
+	})
+	if err != nil {
+		return Current{}, err
+	}
+
+	current := Current{CongestionIndex: latest}
+	if typical.Samples > 0 {
+		change := latest.Value - typical.Value
+		current.Typical = &typical.Value
+		current.Change = &change
+	}
+	return current, nil
+}
+
+// CurrentOf returns the index over the last Interval computed from the
+// readings of the given sensors only. Nothing is stored, and Typical and
+// Change stay nil as the typical values cover all sensors.
+func (service *Service) CurrentOf(ctx context.Context, sensorIDs []int32) (Current, error) {
+	end := time.Now().UTC().Truncate(service.config.Interval)
+	values, err := service.intervals(ctx, end, end.Add(time.Microsecond), sensorIDs)
+	if err != nil {
+		return Current{}, err
+	}
+	if len(values) == 0 {
+		return Current{}, ErrNoReadings
+	}
+	return Current{CongestionIndex: values[len(values)-1]}, nil
+}
+
+// BucketsOf is Buckets over an index computed from the readings of the
+// given sensors only instead of the stored one
+func (service *Service) BucketsOf(ctx context.Context, width time.Duration, start, end time.Time, sensorIDs []int32) ([]db.GetCongestionIndexBucketsRow, error) {
+	values, err := service.intervals(ctx, start, end, sensorIDs)
+	if err != nil {
+		return nil, err
+	}
+	return Aggregate(values, width), nil
+}
+
+// intervals computes the index of the given sensors for every Interval
+// ending in [start, end)
+func (service *Service) intervals(ctx context.Context, start, end time.Time, sensorIDs []int32) ([]db.CongestionIndex, error) {
+	freeFlow, err := service.freeFlowSpeeds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := service.config.Interval
+	rows, err := service.store.GetSensorFlowBuckets(ctx, db.GetSensorFlowBucketsParams{
+		BucketWidth: pgtype.Interval{Microseconds: interval.Microseconds(), Valid: true},
+		StartTime:   pgtype.Timestamp{Time: start.UTC().Add(-interval), Valid: true},
+		EndTime:     pgtype.Timestamp{Time: end.UTC(), Valid: true},
+		SensorIds:   sensorIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	values := Intervals(rows, freeFlow)
+	kept := values[:0]
+	for _, value := range values {
+		if !value.Timestamp.Time.Before(start) && value.Timestamp.Time.Before(end) {
+			kept = append(kept, value)
+		}
+	}
+	return kept, nil
+}
+
+// Buckets averages the stored index into buckets of width, weighing each
+// value by the vehicles it covers
+func (service *Service) Buckets(ctx context.Context, width time.Duration, start, end time.Time) ([]db.GetCongestionIndexBucketsRow, error) {
+	return service.store.GetCongestionIndexBuckets(ctx, db.GetCongestionIndexBucketsParams{
+		BucketWidth: pgtype.Interval{Microseconds: width.Microseconds(), Valid: true},
+		StartTime:   pgtype.Timestamp{Time: start, Valid: true},
+		EndTime:     pgtype.Timestamp{Time: end, Valid: true},
+	})
+}
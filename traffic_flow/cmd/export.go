@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	db "smart_city/traffic_flow/db/sqlc"
+	"smart_city/traffic_flow/export"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// runExport implements the export subcommand, which streams raw readings
+// to a file or stdout:
+//
+//	traffic_flow export -format csv -start 2026-10-01T00:00:00Z -out october.csv
+//
+// An interrupted CSV or NDJSON export is continued with -resume, which
+// appends to -out after its last complete row.
+func runExport(store *db.Store, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	formatFlag := flags.String("format", string(export.FormatCSV), "csv, ndjson or parquet")
+	startFlag := flags.String("start", "", "start of the range, RFC 3339 (default 24h before -end)")
+	endFlag := flags.String("end", "", "end of the range, RFC 3339 (default now)")
+	sensorsFlag := flags.String("sensor", "", "comma separated sensor ids (default all sensors)")
+	withSensors := flags.Bool("with-sensors", false, "add each sensor's latitude, longitude and type")
+	outFlag := flags.String("out", "", "output file (default stdout)")
+	resume := flags.Bool("resume", false, "append to an interrupted CSV or NDJSON -out file")
+	afterFlag := flags.String("after", "", "start after this timestamp,sensor_id cursor")
+	flags.Parse(args)
+
+	format, err := export.ParseFormat(*formatFlag)
+	if err != nil {
+		return err
+	}
+
+	req := export.Request{EndTime: time.Now().UTC(), WithSensors: *withSensors}
+	if *endFlag != "" {
+		if req.EndTime, err = time.Parse(time.RFC3339, *endFlag); err != nil {
+			return fmt.Errorf("cannot parse -end: %w", err)
+		}
+		req.EndTime = req.EndTime.UTC()
+	}
+	req.StartTime = req.EndTime.Add(-24 * time.Hour)
+	if *startFlag != "" {
+		if req.StartTime, err = time.Parse(time.RFC3339, *startFlag); err != nil {
+			return fmt.Errorf("cannot parse -start: %w", err)
+		}
+		req.StartTime = req.StartTime.UTC()
+	}
+	if !req.StartTime.Before(req.EndTime) {
+		return errors.New("-start must be before -end")
+	}
+
+	for _, field := range strings.Split(*sensorsFlag, ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(field, 10, 32)
+		if err != nil || id < 1 {
+			return fmt.Errorf("invalid sensor id %q", field)
+		}
+		req.SensorIDs = append(req.SensorIDs, int32(id))
+	}
+
+	if *afterFlag != "" {
+		after, err := export.ParseCursor(*afterFlag)
+		if err != nil {
+			return err
+		}
+		req.After = &after
+	}
+
+	var out io.Writer = os.Stdout
+	header := true
+	switch {
+	case *resume:
+		if *outFlag == "" {
+			return errors.New("-resume needs an -out file")
+		}
+		file, err := os.OpenFile(*outFlag, os.O_RDWR|os.O_CREATE, 0o644)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		last, err := export.Resume(file, format)
+		if err != nil {
+			return err
+		}
+		if last != nil && req.After == nil {
+			req.After = last
+		}
+		offset, err := file.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return err
+		}
+		header = offset == 0
+		out = file
+	case *outFlag != "":
+		file, err := os.Create(*outFlag)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	var writer export.Writer
+	if format == export.FormatCSV {
+		writer, err = export.NewCSVWriter(out, req.WithSensors, header)
+	} else {
+		writer, err = export.NewWriter(out, format, req.WithSensors)
+	}
+	if err != nil {
+		return err
+	}
+
+	// An interrupted export keeps its complete pages for -resume
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	result, err := export.NewService(store).Export(ctx, req, writer)
+	if err != nil {
+		if result.Last != nil {
+			log.Error().Int64("rows", result.Rows).Msgf("export stopped, continue with -after %s or -resume", result.Last)
+		}
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	log.Info().Int64("rows", result.Rows).Msg("export finished")
+	return nil
+}
@@ -2,14 +2,44 @@ package main
 
 import (
 	"context"
+	"errors"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"smart_city/traffic_flow/anomaly"
 	"smart_city/traffic_flow/api"
+	"smart_city/traffic_flow/catalog"
+	"smart_city/traffic_flow/cityindex"
+	"smart_city/traffic_flow/comparison"
+	"smart_city/traffic_flow/congestion"
+	"smart_city/traffic_flow/corridor"
 	db "smart_city/traffic_flow/db/sqlc"
+	"smart_city/traffic_flow/episode"
+	"smart_city/traffic_flow/export"
+	"smart_city/traffic_flow/forecast"
+	"smart_city/traffic_flow/gapi"
+	"smart_city/traffic_flow/geo"
+	"smart_city/traffic_flow/ingest"
+	"smart_city/traffic_flow/lineproto"
+	"smart_city/traffic_flow/liveness"
+	"smart_city/traffic_flow/mqtt"
+	"smart_city/traffic_flow/pb"
+	"smart_city/traffic_flow/peak"
+	"smart_city/traffic_flow/quality"
+	"smart_city/traffic_flow/rollup"
+	"smart_city/traffic_flow/storage"
+	"syscall"
+	"time"
+	// Time zones named in requests; the alpine image has no zoneinfo
+	_ "time/tzdata"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
@@ -25,24 +55,240 @@ func main() {
 		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
 	}
 
+	poolConfig, err := pgxpool.ParseConfig(dbSource)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot parse db source:")
+	}
+	// Custom enum types must be registered for COPY-based bulk ingestion
+	poolConfig.AfterConnect = db.RegisterEnumTypes
+
 	// Creates DB connection
-	conn, err := pgxpool.New(context.Background(), dbSource)
+	conn, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot connect to db:")
 	}
 
 	store := db.NewStore(conn)
 
-	server, err := api.NewServer(store)
+	// "export" streams readings out of the DB instead of serving the API
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := runExport(store, os.Args[2:]); err != nil {
+			log.Fatal().Err(err).Msg("cannot export traffic data:")
+		}
+		return
+	}
+
+	ingestConfig, err := ingest.LoadConfig()
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot load ingest config:")
+	}
+
+	// Ingestion checks sensor statuses against this cache instead of the DB
+	sensorCache := catalog.NewSensorCache(store)
+	if err := sensorCache.Refresh(context.Background()); err != nil {
+		log.Fatal().Err(err).Msg("cannot load sensor cache:")
+	}
+	go sensorCache.Run(context.Background(), ingestConfig.SensorCacheRefresh)
+
+	// Congestion levels are derived from thresholds stored in the DB
+	classifier := congestion.NewClassifier(store)
+	if err := classifier.Refresh(context.Background()); err != nil {
+		log.Fatal().Err(err).Msg("cannot load congestion thresholds:")
+	}
+	go classifier.Run(context.Background(), ingestConfig.SensorCacheRefresh)
+
+	ingester := ingest.NewService(store, sensorCache, classifier, ingestConfig)
+	sensorCatalog := catalog.NewService(store, sensorCache)
+	thresholds := congestion.NewService(store, classifier, sensorCache)
+
+	// Sensors that stop reporting are marked stale and then silent
+	livenessConfig, err := liveness.LoadConfig()
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot load liveness config:")
+	}
+	monitor := liveness.NewMonitor(store, sensorCache, livenessConfig)
+	if livenessConfig.Enabled {
+		go monitor.Run(context.Background())
+	}
+
+	// Analytics over long ranges are answered from the continuous aggregates
+	rollupConfig, err := rollup.LoadConfig()
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot load rollup config:")
+	}
+	rollups := rollup.NewService(store, rollupConfig)
+	locator := geo.NewService(store)
+	corridors := corridor.NewService(store, rollups)
+	comparisons := comparison.NewService(rollups, corridors)
+	peaks := peak.NewService(rollups)
+	episodes := episode.NewService(store)
+	exports := export.NewService(store)
+
+	// Scores new readings against each sensor's seasonal baseline
+	anomalyConfig, err := anomaly.LoadConfig()
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot load anomaly detection config:")
+	}
+	detector := anomaly.NewDetector(store, anomalyConfig)
+	if anomalyConfig.Enabled {
+		ingester.OnRecord(detector.Observe)
+		go detector.Run(context.Background())
+	}
+
+	// Short-term forecasts of volume and speed per sensor
+	forecastConfig, err := forecast.LoadConfig()
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot load forecast config:")
+	}
+	forecasts := forecast.NewService(store, forecastConfig)
+	if forecastConfig.Enabled {
+		go forecasts.Run(context.Background())
+	}
+
+	// City-wide congestion index, stored as its own time series
+	congestionIndexConfig, err := cityindex.LoadConfig()
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot load congestion index config:")
+	}
+	congestionIndex := cityindex.NewService(store, congestionIndexConfig)
+	if congestionIndexConfig.Enabled {
+		go congestionIndex.Run(context.Background())
+	}
+
+	// Data quality scores of the sensors' recent readings
+	qualityConfig, err := quality.LoadConfig()
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot load quality config:")
+	}
+	dataQuality := quality.NewService(store, qualityConfig)
+	if qualityConfig.Enabled {
+		go dataQuality.Run(context.Background())
+	}
+
+	// Retention may not drop readings the services above still read
+	history := max(anomalyConfig.History, forecastConfig.History, congestionIndexConfig.FreeFlowHistory, qualityConfig.Window)
+	hypertable := storage.NewService(store, history)
+
+	// Opt-in write-behind queue for POST /traffic/record
+	var queue *ingest.Queue
+	if ingestConfig.Queue.Enabled {
+		queue = ingest.NewQueue(ingester, ingestConfig.Queue)
+		log.Info().Msgf("asynchronous ingestion enabled with %d workers", ingestConfig.Queue.Workers)
+	}
+
+	server, err := api.NewServer(store, ingester, sensorCatalog, thresholds, monitor, rollups, detector, forecasts, locator, corridors, comparisons, peaks, episodes, congestionIndex, dataQuality, exports, hypertable, queue)
 
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create server: ")
 	}
 
-	log.Info().Msgf("starting HTTP-Traffic-Flow server on %s", os.Getenv("TF_SERVER_ADDR"))
-	log.Info().Msg("WebSocket server enabled for real-time traffic updates")
-	err = server.Start(os.Getenv("TF_SERVER_ADDR"))
+	mqttConfig, err := mqtt.LoadConfig()
 	if err != nil {
-		log.Fatal().Err(err).Msg("Cannot start server: ")
+		log.Fatal().Err(err).Msg("cannot load MQTT config:")
+	}
+	if mqttConfig.Enabled {
+		subscriber := mqtt.NewSubscriber(mqttConfig, ingester)
+		if err := subscriber.Start(); err != nil {
+			log.Fatal().Err(err).Msg("cannot start MQTT subscriber:")
+		}
+		defer subscriber.Close()
+		log.Info().Msgf("MQTT ingestion enabled on %s", mqttConfig.BrokerURL)
+	}
+
+	udpConfig, err := lineproto.LoadUDPConfig()
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot load line protocol config:")
+	}
+	if udpConfig.Addr != "" {
+		listener := lineproto.NewUDPListener(udpConfig, ingester)
+		if err := listener.Start(); err != nil {
+			log.Fatal().Err(err).Msg("cannot start line protocol UDP listener:")
+		}
+		defer listener.Close()
+		log.Info().Msgf("line protocol UDP listener enabled on %s", udpConfig.Addr)
+	}
+
+	var (
+		grpcServer  *grpc.Server
+		grpcService *gapi.Server
+	)
+	if grpcAddr := os.Getenv("TF_GRPC_SERVER_ADDR"); grpcAddr != "" {
+		grpcServer, grpcService = runGrpcServer(grpcAddr, store, ingester, sensorCatalog, monitor, detector, congestionIndex)
+	}
+
+	go func() {
+		log.Info().Msgf("starting HTTP-Traffic-Flow server on %s", os.Getenv("TF_SERVER_ADDR"))
+		log.Info().Msg("WebSocket server enabled for real-time traffic updates")
+		err := server.Start(os.Getenv("TF_SERVER_ADDR"))
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal().Err(err).Msg("Cannot start server: ")
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	log.Info().Msg("shutting down HTTP-Traffic-Flow server")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.Error().Err(err).Msg("cannot shut down HTTP server gracefully")
+	}
+	// No transport may accept readings once the queue is closing
+	if grpcServer != nil {
+		grpcService.Shutdown()
+		stopGrpcServer(ctx, grpcServer)
+	}
+	// Readings accepted before shutdown are written before we exit
+	if queue != nil {
+		if err := queue.Close(ctx); err != nil {
+			log.Error().Err(err).Int("pending", queue.Stats().Depth).Msg("cannot flush ingestion queue")
+		}
+	}
+}
+
+// runGrpcServer serves the gRPC API next to the gin router; both share the
+// same store and services
+func runGrpcServer(address string, store *db.Store, ingester *ingest.Service, sensorCatalog *catalog.Service, monitor *liveness.Monitor, detector *anomaly.Detector, congestionIndex *cityindex.Service) (*grpc.Server, *gapi.Server) {
+	server, err := gapi.NewServer(store, ingester, sensorCatalog, monitor, detector, congestionIndex)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create gRPC server:")
+	}
+
+	grpcServer := grpc.NewServer()
+	pb.RegisterTrafficServiceServer(grpcServer, server)
+	pb.RegisterSensorServiceServer(grpcServer, server)
+	reflection.Register(grpcServer)
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create gRPC listener:")
+	}
+
+	go func() {
+		log.Info().Msgf("starting gRPC-Traffic-Flow server on %s", listener.Addr().String())
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Fatal().Err(err).Msg("cannot start gRPC server:")
+		}
+	}()
+	return grpcServer, server
+}
+
+// stopGrpcServer lets in-flight calls finish and cuts off those still
+// running when ctx is done
+func stopGrpcServer(ctx context.Context, grpcServer *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
+		log.Error().Err(ctx.Err()).Msg("cannot shut down gRPC server gracefully")
 	}
 }
@@ -0,0 +1,214 @@
+package comparison
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"smart_city/traffic_flow/corridor"
+	db "smart_city/traffic_flow/db/sqlc"
+	"sort"
+)
+
+var ErrInvalidRankMetric = errors.New("invalid rank metric")
+
+// Metrics an entry can be ranked by
+const (
+	RankVolume     = "volume"
+	RankSpeed      = "speed"
+	RankCongestion = "congestion"
+	// RankTravelTime only applies to corridors
+	RankTravelTime = "travel_time"
+)
+
+// CheckRank makes sure entries can be ranked by metric
+func CheckRank(metric string, corridors bool) error {
+	switch metric {
+	case RankVolume, RankSpeed, RankCongestion:
+		return nil
+	case RankTravelTime:
+		if corridors {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w %q", ErrInvalidRankMetric, metric)
+}
+
+// Metrics summarize the readings of one window
+type Metrics struct {
+	Readings    int64   `json:"readings"`
+	TotalVolume int64   `json:"total_volume"`
+	AvgVolume   float64 `json:"avg_volume"`
+	AvgSpeed    float64 `json:"avg_speed"`
+	// HighCongestionShare is the fraction of readings at high congestion
+	HighCongestionShare float64 `json:"high_congestion_share"`
+	// TravelTimeSeconds is only set for corridors
+	TravelTimeSeconds *float64 `json:"travel_time_seconds,omitempty"`
+}
+
+// summarize combines the totals of one or more sensors
+func summarize(rows ...db.GetSensorTrafficSummariesRow) Metrics {
+	var metrics Metrics
+	var sumSpeed float64
+	var highCount int64
+	for _, row := range rows {
+		metrics.Readings += row.Readings
+		metrics.TotalVolume += row.SumVolume
+		sumSpeed += row.SumSpeed
+		highCount += row.HighCount
+	}
+	if metrics.Readings > 0 {
+		readings := float64(metrics.Readings)
+		metrics.AvgVolume = float64(metrics.TotalVolume) / readings
+		metrics.AvgSpeed = sumSpeed / readings
+		metrics.HighCongestionShare = float64(highCount) / readings
+	}
+	return metrics
+}
+
+// Delta is the change of a metric from the comparison to the base window
+type Delta struct {
+	Base       float64 `json:"base"`
+	Comparison float64 `json:"comparison"`
+	Change     float64 `json:"change"`
+	// ChangePct is relative to the comparison value, nil when that is 0
+	ChangePct *float64 `json:"change_pct"`
+}
+
+func newDelta(base, comparison float64) Delta {
+	delta := Delta{Base: base, Comparison: comparison, Change: base - comparison}
+	if comparison != 0 {
+		pct := delta.Change / comparison * 100
+		delta.ChangePct = &pct
+	}
+	return delta
+}
+
+// Entry compares one sensor or corridor across both windows
+type Entry struct {
+	SensorID   int32   `json:"sensor_id,omitempty"`
+	CorridorID int32   `json:"corridor_id,omitempty"`
+	Name       string  `json:"name,omitempty"`
+	Base       Metrics `json:"base"`
+	Comparison Metrics `json:"comparison"`
+	Volume     Delta   `json:"volume"`
+	Speed      Delta   `json:"speed"`
+	Congestion Delta   `json:"congestion"`
+	TravelTime *Delta  `json:"travel_time,omitempty"`
+}
+
+func newEntry(base, comparison Metrics) Entry {
+	entry := Entry{
+		Base:       base,
+		Comparison: comparison,
+		Volume:     newDelta(base.AvgVolume, comparison.AvgVolume),
+		Speed:      newDelta(base.AvgSpeed, comparison.AvgSpeed),
+		Congestion: newDelta(base.HighCongestionShare, comparison.HighCongestionShare),
+	}
+	if base.TravelTimeSeconds != nil && comparison.TravelTimeSeconds != nil {
+		delta := newDelta(*base.TravelTimeSeconds, *comparison.TravelTimeSeconds)
+		entry.TravelTime = &delta
+	}
+	return entry
+}
+
+func (entry Entry) delta(metric string) Delta {
+	switch metric {
+	case RankSpeed:
+		return entry.Speed
+	case RankCongestion:
+		return entry.Congestion
+	case RankTravelTime:
+		if entry.TravelTime != nil {
+			return *entry.TravelTime
+		}
+		return Delta{}
+	default:
+		return entry.Volume
+	}
+}
+
+// Rank orders entries by the size of their percentage change in metric,
+// largest first. Entries without a percentage follow, by absolute change.
+func Rank(entries []Entry, metric string) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].delta(metric), entries[j].delta(metric)
+		switch {
+		case a.ChangePct != nil && b.ChangePct != nil:
+			return math.Abs(*a.ChangePct) > math.Abs(*b.ChangePct)
+		case a.ChangePct != nil || b.ChangePct != nil:
+			return a.ChangePct != nil
+		default:
+			return math.Abs(a.Change) > math.Abs(b.Change)
+		}
+	})
+}
+
+// CompareSensors pairs the sensors with readings in both windows
+func CompareSensors(base, comparison []db.GetSensorTrafficSummariesRow) []Entry {
+	earlier := make(map[int32]db.GetSensorTrafficSummariesRow, len(comparison))
+	for _, row := range comparison {
+		earlier[row.SensorID] = row
+	}
+
+	entries := []Entry{}
+	for _, row := range base {
+		other, ok := earlier[row.SensorID]
+		if !ok || row.Readings == 0 || other.Readings == 0 {
+			continue
+		}
+		entry := newEntry(summarize(row), summarize(other))
+		entry.SensorID = row.SensorID
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// corridorMetrics combines the sensors of a corridor and estimates its
+// travel time from their average speeds
+func corridorMetrics(c corridor.Corridor, rows map[int32]db.GetSensorTrafficSummariesRow) Metrics {
+	var sensorRows []db.GetSensorTrafficSummariesRow
+	speeds := make(map[int32]float64)
+	for _, segment := range c.Segments {
+		row, ok := rows[segment.SensorID]
+		if !ok || row.Readings == 0 {
+			continue
+		}
+		if _, seen := speeds[segment.SensorID]; !seen {
+			sensorRows = append(sensorRows, row)
+		}
+		speeds[segment.SensorID] = row.SumSpeed / float64(row.Readings)
+	}
+
+	metrics := summarize(sensorRows...)
+	if metrics.Readings > 0 {
+		travelTime := corridor.EstimateTravelTime(c, speeds).TravelTimeSeconds
+		metrics.TravelTimeSeconds = &travelTime
+	}
+	return metrics
+}
+
+// CompareCorridors pairs the corridors with readings in both windows
+func CompareCorridors(corridors []corridor.Corridor, base, comparison []db.GetSensorTrafficSummariesRow) []Entry {
+	bySensor := func(rows []db.GetSensorTrafficSummariesRow) map[int32]db.GetSensorTrafficSummariesRow {
+		indexed := make(map[int32]db.GetSensorTrafficSummariesRow, len(rows))
+		for _, row := range rows {
+			indexed[row.SensorID] = row
+		}
+		return indexed
+	}
+	baseRows, comparisonRows := bySensor(base), bySensor(comparison)
+
+	entries := []Entry{}
+	for _, c := range corridors {
+		baseMetrics := corridorMetrics(c, baseRows)
+		comparisonMetrics := corridorMetrics(c, comparisonRows)
+		if baseMetrics.Readings == 0 || comparisonMetrics.Readings == 0 {
+			continue
+		}
+		entry := newEntry(baseMetrics, comparisonMetrics)
+		entry.CorridorID = c.CorridorID
+		entry.Name = c.Name
+		entries = append(entries, entry)
+	}
+	return entries
+}
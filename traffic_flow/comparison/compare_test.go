@@ -0,0 +1,95 @@
+package comparison
+
+import (
+	"smart_city/traffic_flow/corridor"
+	db "smart_city/traffic_flow/db/sqlc"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShift(t *testing.T) {
+	start := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	end := start.Add(3 * 24 * time.Hour)
+
+	from, to, err := Shift(PrevPeriod, start, end)
+	require.NoError(t, err)
+	require.Equal(t, start.Add(-3*24*time.Hour), from)
+	require.Equal(t, start, to)
+
+	from, _, err = Shift(PrevWeek, start, end)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC), from)
+
+	// A year back lands on the same weekday
+	from, _, err = Shift(PrevYear, start, end)
+	require.NoError(t, err)
+	require.Equal(t, start.Weekday(), from.Weekday())
+	require.Equal(t, 2025, from.Year())
+
+	_, _, err = Shift("last_month", start, end)
+	require.ErrorIs(t, err, ErrInvalidPeriod)
+}
+
+func TestCompareSensors(t *testing.T) {
+	base := []db.GetSensorTrafficSummariesRow{
+		{SensorID: 1, Readings: 10, SumVolume: 1200, SumSpeed: 400, HighCount: 5},
+		{SensorID: 2, Readings: 10, SumVolume: 1000, SumSpeed: 500, HighCount: 0},
+		// Only in the base window
+		{SensorID: 3, Readings: 10, SumVolume: 1000, SumSpeed: 500},
+	}
+	comparison := []db.GetSensorTrafficSummariesRow{
+		{SensorID: 1, Readings: 10, SumVolume: 1000, SumSpeed: 500, HighCount: 0},
+		{SensorID: 2, Readings: 20, SumVolume: 1000, SumSpeed: 1000, HighCount: 2},
+	}
+
+	entries := CompareSensors(base, comparison)
+	require.Len(t, entries, 2)
+
+	Rank(entries, RankVolume)
+	// Sensor 2 doubled its volume per reading, sensor 1 grew by 20%
+	require.Equal(t, int32(2), entries[0].SensorID)
+	require.InDelta(t, 100, *entries[0].Volume.ChangePct, 1e-9)
+	require.InDelta(t, 20, *entries[1].Volume.ChangePct, 1e-9)
+	require.InDelta(t, -10, entries[1].Speed.Change, 1e-9)
+	require.InDelta(t, -20, *entries[1].Speed.ChangePct, 1e-9)
+
+	// Sensor 1 had no congestion before, so it has no percentage and
+	// ranks after sensor 2
+	Rank(entries, RankCongestion)
+	require.Equal(t, int32(2), entries[0].SensorID)
+	require.Nil(t, entries[1].Congestion.ChangePct)
+	require.InDelta(t, 0.5, entries[1].Congestion.Change, 1e-9)
+}
+
+func TestCompareCorridors(t *testing.T) {
+	corridors := []corridor.Corridor{{
+		Corridor: db.Corridor{CorridorID: 7, Name: "ring road", FreeFlowSpeed: 60},
+		Segments: []db.CorridorSegment{
+			{SensorID: 1, LengthM: 1000},
+			{Position: 1, SensorID: 2, LengthM: 1000},
+		},
+	}}
+	base := []db.GetSensorTrafficSummariesRow{
+		{SensorID: 1, Readings: 2, SumVolume: 200, SumSpeed: 60},
+		{SensorID: 2, Readings: 2, SumVolume: 200, SumSpeed: 120},
+	}
+	comparison := []db.GetSensorTrafficSummariesRow{
+		{SensorID: 1, Readings: 2, SumVolume: 200, SumSpeed: 120},
+		{SensorID: 2, Readings: 2, SumVolume: 200, SumSpeed: 120},
+	}
+
+	entries := CompareCorridors(corridors, base, comparison)
+	require.Len(t, entries, 1)
+	require.Equal(t, "ring road", entries[0].Name)
+	require.Equal(t, int64(4), entries[0].Base.Readings)
+	// The first kilometer now takes two minutes instead of one
+	require.NotNil(t, entries[0].TravelTime)
+	require.InDelta(t, 180, entries[0].TravelTime.Base, 1e-9)
+	require.InDelta(t, 120, entries[0].TravelTime.Comparison, 1e-9)
+	require.InDelta(t, 50, *entries[0].TravelTime.ChangePct, 1e-9)
+
+	require.NoError(t, CheckRank(RankTravelTime, true))
+	require.ErrorIs(t, CheckRank(RankTravelTime, false), ErrInvalidRankMetric)
+}
@@ -0,0 +1,39 @@
+package comparison
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidPeriod = errors.New("invalid comparison period")
+
+// Shorthands for the comparison window relative to the base window
+const (
+	// PrevPeriod is the window of the same length right before the base
+	PrevPeriod = "prev_period"
+	PrevDay    = "prev_day"
+	PrevWeek   = "prev_week"
+	// PrevYear is 52 weeks earlier so that weekdays line up
+	PrevYear = "prev_year"
+)
+
+// Shift returns the comparison window a shorthand names for the base
+// window from start to end
+func Shift(shorthand string, start, end time.Time) (time.Time, time.Time, error) {
+	var offset time.Duration
+	switch shorthand {
+	case PrevPeriod:
+		offset = end.Sub(start)
+	case PrevDay:
+		offset = 24 * time.Hour
+	case PrevWeek:
+		offset = 7 * 24 * time.Hour
+	case PrevYear:
+		offset = 52 * 7 * 24 * time.Hour
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("%w %q, must be one of %s, %s, %s or %s",
+			ErrInvalidPeriod, shorthand, PrevPeriod, PrevDay, PrevWeek, PrevYear)
+	}
+	return start.Add(-offset), end.Add(-offset), nil
+}
@@ -0,0 +1,103 @@
+package comparison
+
+import (
+	"context"
+	"smart_city/traffic_flow/corridor"
+	db "smart_city/traffic_flow/db/sqlc"
+	"smart_city/traffic_flow/geo"
+	"smart_city/traffic_flow/rollup"
+	"time"
+)
+
+// Window is a time range to compare
+type Window struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Report is a ranked comparison of two windows, each with the source it
+// was read from.
+type Report struct {
+	Base       rollup.Plan `json:"base"`
+	Comparison rollup.Plan `json:"comparison"`
+	RankBy     string      `json:"rank_by"`
+	Entries    []Entry     `json:"entries"`
+}
+
+// Service compares the traffic of two windows per sensor or per corridor
+type Service struct {
+	rollups   *rollup.Service
+	corridors *corridor.Service
+}
+
+func NewService(rollups *rollup.Service, corridors *corridor.Service) *Service {
+	return &Service{rollups: rollups, corridors: corridors}
+}
+
+// Sensors compares every sensor with readings in both windows, leaving out
+// the excluded ones
+func (service *Service) Sensors(ctx context.Context, base, comparison Window, rankBy string, excluded map[int32]bool) (Report, error) {
+	if err := CheckRank(rankBy, false); err != nil {
+		return Report{}, err
+	}
+
+	report, baseRows, comparisonRows, err := service.summaries(ctx, base, comparison, excluded)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report.RankBy = rankBy
+	report.Entries = CompareSensors(baseRows, comparisonRows)
+	Rank(report.Entries, rankBy)
+	return report, nil
+}
+
+// Corridors compares every corridor with readings in both windows from the
+// sensors that are not excluded
+func (service *Service) Corridors(ctx context.Context, base, comparison Window, rankBy string, excluded map[int32]bool) (Report, error) {
+	if err := CheckRank(rankBy, true); err != nil {
+		return Report{}, err
+	}
+
+	corridors, err := service.corridors.List(ctx)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report, baseRows, comparisonRows, err := service.summaries(ctx, base, comparison, excluded)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report.RankBy = rankBy
+	report.Entries = CompareCorridors(corridors, baseRows, comparisonRows)
+	Rank(report.Entries, rankBy)
+	return report, nil
+}
+
+func (service *Service) summaries(ctx context.Context, base, comparison Window, excluded map[int32]bool) (Report, []db.GetSensorTrafficSummariesRow, []db.GetSensorTrafficSummariesRow, error) {
+	baseRows, basePlan, err := service.rollups.SensorSummaries(ctx, base.Start, base.End, geo.World)
+	if err != nil {
+		return Report{}, nil, nil, err
+	}
+	comparisonRows, comparisonPlan, err := service.rollups.SensorSummaries(ctx, comparison.Start, comparison.End, geo.World)
+	if err != nil {
+		return Report{}, nil, nil, err
+	}
+	report := Report{Base: basePlan, Comparison: comparisonPlan}
+	return report, without(baseRows, excluded), without(comparisonRows, excluded), nil
+}
+
+// without drops the rows of the excluded sensors
+func without(rows []db.GetSensorTrafficSummariesRow, excluded map[int32]bool) []db.GetSensorTrafficSummariesRow {
+	if len(excluded) == 0 {
+		return rows
+	}
+	kept := rows[:0]
+	for _, row := range rows {
+		if !excluded[row.SensorID] {
+			kept = append(kept, row)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,95 @@
+package congestion
+
+import (
+	"context"
+	db "smart_city/traffic_flow/db/sqlc"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Classifier derives congestion levels using the most specific thresholds
+// configured for a sensor: its own, then its type's, then the default. The
+// thresholds are kept in memory and reloaded after every change made
+// through the Service, and periodically by Run.
+type Classifier struct {
+	store    *db.Store
+	mu       sync.RWMutex
+	fallback Thresholds
+	byType   map[int32]Thresholds
+	bySensor map[int32]Thresholds
+}
+
+func NewClassifier(store *db.Store) *Classifier {
+	return &Classifier{
+		store:    store,
+		fallback: DefaultThresholds,
+		byType:   make(map[int32]Thresholds),
+		bySensor: make(map[int32]Thresholds),
+	}
+}
+
+// Refresh reloads all thresholds from the database
+func (classifier *Classifier) Refresh(ctx context.Context) error {
+	rows, err := classifier.store.ListCongestionThresholds(ctx)
+	if err != nil {
+		return err
+	}
+
+	fallback := DefaultThresholds
+	byType := make(map[int32]Thresholds)
+	bySensor := make(map[int32]Thresholds)
+	for _, row := range rows {
+		switch {
+		case row.SensorID.Valid:
+			bySensor[row.SensorID.Int32] = fromRow(row)
+		case row.TypeID.Valid:
+			byType[row.TypeID.Int32] = fromRow(row)
+		default:
+			fallback = fromRow(row)
+		}
+	}
+
+	classifier.mu.Lock()
+	classifier.fallback, classifier.byType, classifier.bySensor = fallback, byType, bySensor
+	classifier.mu.Unlock()
+	return nil
+}
+
+// Run refreshes the thresholds every interval until ctx is done
+func (classifier *Classifier) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := classifier.Refresh(ctx); err != nil {
+				log.Error().Err(err).Msg("cannot refresh congestion thresholds")
+			}
+		}
+	}
+}
+
+// Resolve returns the thresholds that apply to a sensor of the given type
+func (classifier *Classifier) Resolve(sensorID, typeID int32) (Thresholds, Source) {
+	classifier.mu.RLock()
+	defer classifier.mu.RUnlock()
+
+	if thresholds, ok := classifier.bySensor[sensorID]; ok {
+		return thresholds, SourceSensor
+	}
+	if thresholds, ok := classifier.byType[typeID]; ok {
+		return thresholds, SourceSensorType
+	}
+	return classifier.fallback, SourceDefault
+}
+
+// Classify derives the congestion level of a reading
+func (classifier *Classifier) Classify(sensorID, typeID, volume int32, speed float64) db.CongestionLevelType {
+	thresholds, _ := classifier.Resolve(sensorID, typeID)
+	return thresholds.Classify(volume, speed)
+}
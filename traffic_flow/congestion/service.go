@@ -0,0 +1,139 @@
+package congestion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"smart_city/traffic_flow/catalog"
+	db "smart_city/traffic_flow/db/sqlc"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrNotFound = errors.New("sensor or sensor type not found")
+	// ErrNoThresholds is returned when deleting thresholds that were never set
+	ErrNoThresholds = errors.New("no thresholds configured")
+)
+
+// Service edits the stored thresholds and keeps the classifier in step
+type Service struct {
+	store      *db.Store
+	classifier *Classifier
+	sensors    *catalog.SensorCache
+}
+
+func NewService(store *db.Store, classifier *Classifier, sensors *catalog.SensorCache) *Service {
+	return &Service{
+		store:      store,
+		classifier: classifier,
+		sensors:    sensors,
+	}
+}
+
+func validate(thresholds *Thresholds) error {
+	if err := binding.Validator.ValidateStruct(thresholds); err != nil {
+		return fmt.Errorf("%w: %v", catalog.ErrInvalidRequest, err)
+	}
+	return nil
+}
+
+// changed reloads the classifier after a successful mutation
+func (service *Service) changed(ctx context.Context, row db.CongestionThreshold, err error) (db.CongestionThreshold, error) {
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == db.ForeignKeyViolation {
+			return row, ErrNotFound
+		}
+		return row, err
+	}
+	return row, service.classifier.Refresh(ctx)
+}
+
+func (service *Service) List(ctx context.Context) ([]db.CongestionThreshold, error) {
+	return service.store.ListCongestionThresholds(ctx)
+}
+
+func (service *Service) SetDefault(ctx context.Context, thresholds Thresholds) (db.CongestionThreshold, error) {
+	if err := validate(&thresholds); err != nil {
+		return db.CongestionThreshold{}, err
+	}
+
+	row, err := service.store.UpdateDefaultCongestionThresholds(ctx, db.UpdateDefaultCongestionThresholdsParams{
+		ModerateSpeed:  thresholds.ModerateSpeed,
+		HighSpeed:      thresholds.HighSpeed,
+		ModerateVolume: thresholds.ModerateVolume,
+		HighVolume:     thresholds.HighVolume,
+	})
+	return service.changed(ctx, row, err)
+}
+
+func (service *Service) SetForSensorType(ctx context.Context, typeID int32, thresholds Thresholds) (db.CongestionThreshold, error) {
+	if err := validate(&thresholds); err != nil {
+		return db.CongestionThreshold{}, err
+	}
+
+	row, err := service.store.UpsertSensorTypeCongestionThresholds(ctx, db.UpsertSensorTypeCongestionThresholdsParams{
+		TypeID:         pgtype.Int4{Int32: typeID, Valid: true},
+		ModerateSpeed:  thresholds.ModerateSpeed,
+		HighSpeed:      thresholds.HighSpeed,
+		ModerateVolume: thresholds.ModerateVolume,
+		HighVolume:     thresholds.HighVolume,
+	})
+	return service.changed(ctx, row, err)
+}
+
+func (service *Service) SetForSensor(ctx context.Context, sensorID int32, thresholds Thresholds) (db.CongestionThreshold, error) {
+	if err := validate(&thresholds); err != nil {
+		return db.CongestionThreshold{}, err
+	}
+
+	row, err := service.store.UpsertSensorCongestionThresholds(ctx, db.UpsertSensorCongestionThresholdsParams{
+		SensorID:       pgtype.Int4{Int32: sensorID, Valid: true},
+		ModerateSpeed:  thresholds.ModerateSpeed,
+		HighSpeed:      thresholds.HighSpeed,
+		ModerateVolume: thresholds.ModerateVolume,
+		HighVolume:     thresholds.HighVolume,
+	})
+	return service.changed(ctx, row, err)
+}
+
+// DeleteForSensorType makes sensors of the type fall back to the default
+func (service *Service) DeleteForSensorType(ctx context.Context, typeID int32) error {
+	deleted, err := service.store.DeleteSensorTypeCongestionThresholds(ctx, pgtype.Int4{Int32: typeID, Valid: true})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrNoThresholds
+	}
+	return service.classifier.Refresh(ctx)
+}
+
+// DeleteForSensor makes the sensor fall back to its type's thresholds
+func (service *Service) DeleteForSensor(ctx context.Context, sensorID int32) error {
+	deleted, err := service.store.DeleteSensorCongestionThresholds(ctx, pgtype.Int4{Int32: sensorID, Valid: true})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrNoThresholds
+	}
+	return service.classifier.Refresh(ctx)
+}
+
+// Effective returns the thresholds currently applied to a sensor's readings
+func (service *Service) Effective(ctx context.Context, sensorID int32) (Thresholds, Source, error) {
+	state, ok, err := service.sensors.Lookup(ctx, sensorID)
+	if err != nil {
+		return Thresholds{}, "", err
+	}
+	if !ok {
+		return Thresholds{}, "", ErrNotFound
+	}
+
+	thresholds, source := service.classifier.Resolve(sensorID, state.TypeID)
+	return thresholds, source, nil
+}
@@ -0,0 +1,52 @@
+package congestion
+
+import (
+	db "smart_city/traffic_flow/db/sqlc"
+)
+
+// Thresholds classify a reading from its speed and volume. A reading is at
+// least moderate (high) when its speed is at or below ModerateSpeed
+// (HighSpeed) or its volume is at or above ModerateVolume (HighVolume).
+type Thresholds struct {
+	ModerateSpeed  float64 `json:"moderate_speed" binding:"gt=0"`
+	HighSpeed      float64 `json:"high_speed" binding:"gte=0,ltefield=ModerateSpeed"`
+	ModerateVolume int32   `json:"moderate_volume" binding:"gte=0"`
+	HighVolume     int32   `json:"high_volume" binding:"gt=0,gtefield=ModerateVolume"`
+}
+
+// DefaultThresholds apply until the stored defaults have been loaded
+var DefaultThresholds = Thresholds{
+	ModerateSpeed:  35,
+	HighSpeed:      15,
+	ModerateVolume: 350,
+	HighVolume:     600,
+}
+
+// Source tells which level of configuration thresholds came from
+type Source string
+
+const (
+	SourceSensor     Source = "sensor"
+	SourceSensorType Source = "sensor_type"
+	SourceDefault    Source = "default"
+)
+
+func (thresholds Thresholds) Classify(volume int32, speed float64) db.CongestionLevelType {
+	switch {
+	case speed <= thresholds.HighSpeed || volume >= thresholds.HighVolume:
+		return db.CongestionLevelTypeHigh
+	case speed <= thresholds.ModerateSpeed || volume >= thresholds.ModerateVolume:
+		return db.CongestionLevelTypeModerate
+	default:
+		return db.CongestionLevelTypeLow
+	}
+}
+
+func fromRow(row db.CongestionThreshold) Thresholds {
+	return Thresholds{
+		ModerateSpeed:  row.ModerateSpeed,
+		HighSpeed:      row.HighSpeed,
+		ModerateVolume: row.ModerateVolume,
+		HighVolume:     row.HighVolume,
+	}
+}
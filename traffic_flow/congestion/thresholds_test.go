@@ -0,0 +1,30 @@
+package congestion
+
+import (
+	db "smart_city/traffic_flow/db/sqlc"
+	"testing"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	thresholds := DefaultThresholds
+
+	require.Equal(t, db.CongestionLevelTypeLow, thresholds.Classify(120, 48))
+	require.Equal(t, db.CongestionLevelTypeModerate, thresholds.Classify(120, 35))
+	require.Equal(t, db.CongestionLevelTypeModerate, thresholds.Classify(350, 60))
+	require.Equal(t, db.CongestionLevelTypeHigh, thresholds.Classify(40, 12.5))
+	require.Equal(t, db.CongestionLevelTypeHigh, thresholds.Classify(600, 60))
+}
+
+func TestThresholdsValidation(t *testing.T) {
+	valid := DefaultThresholds
+	require.NoError(t, binding.Validator.ValidateStruct(&valid))
+
+	inverted := Thresholds{ModerateSpeed: 20, HighSpeed: 30, ModerateVolume: 100, HighVolume: 200}
+	require.Error(t, binding.Validator.ValidateStruct(&inverted))
+
+	inverted = Thresholds{ModerateSpeed: 30, HighSpeed: 20, ModerateVolume: 300, HighVolume: 200}
+	require.Error(t, binding.Validator.ValidateStruct(&inverted))
+}
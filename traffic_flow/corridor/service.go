@@ -0,0 +1,280 @@
+package corridor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"smart_city/traffic_flow/catalog"
+	db "smart_city/traffic_flow/db/sqlc"
+	"smart_city/traffic_flow/rollup"
+	"smart_city/traffic_flow/series"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrNotFound      = errors.New("corridor not found")
+	ErrDuplicateName = errors.New("corridor name already exists")
+	ErrUnknownSensor = errors.New("corridor segment references an unknown sensor")
+)
+
+// liveWindow is how old a reading may be to count as the current speed
+const liveWindow = 15 * time.Minute
+
+// Corridor is a corridor with its segments in driving order
+type Corridor struct {
+	db.Corridor
+	Segments []db.CorridorSegment `json:"segments"`
+}
+
+type SegmentRequest struct {
+	SensorID int32   `json:"sensor_id" binding:"required,min=1"`
+	LengthM  float64 `json:"length_m" binding:"required,gt=0"`
+	// FreeFlowSpeed overrides the corridor's for this segment
+	FreeFlowSpeed *float64 `json:"free_flow_speed" binding:"omitempty,gt=0"`
+}
+
+type CorridorRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description"`
+	// FreeFlowSpeed is the uncongested speed in km/h
+	FreeFlowSpeed float64          `json:"free_flow_speed" binding:"required,gt=0"`
+	Segments      []SegmentRequest `json:"segments" binding:"required,min=1,max=500,dive"`
+}
+
+// LiveTravelTime is the travel time at the most recent readings
+type LiveTravelTime struct {
+	TravelTime
+	// AsOf is the time of the oldest reading used
+	AsOf *time.Time `json:"as_of"`
+}
+
+// Point is the travel time over one bucket of a series
+type Point struct {
+	Time time.Time `json:"time"`
+	// Filled marks points of a gapfilled series in which a sensor had no
+	// readings, so its speed was filled in or its segment assumed to flow
+	// freely
+	Filled bool `json:"filled"`
+	TravelTime
+}
+
+// Service manages corridors and estimates their travel times from the
+// speeds of their sensors
+type Service struct {
+	store   *db.Store
+	rollups *rollup.Service
+}
+
+func NewService(store *db.Store, rollups *rollup.Service) *Service {
+	return &Service{store: store, rollups: rollups}
+}
+
+func validate(req any) error {
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return fmt.Errorf("%w: %v", catalog.ErrInvalidRequest, err)
+	}
+	return nil
+}
+
+// storeError translates constraint violations into the service's errors
+func storeError(err error) error {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return ErrNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == db.UniqueViolation:
+		return ErrDuplicateName
+	case errors.As(err, &pgErr) && pgErr.Code == db.ForeignKeyViolation:
+		return ErrUnknownSensor
+	default:
+		return err
+	}
+}
+
+func segmentParams(segments []SegmentRequest) []db.CorridorSegmentParams {
+	params := make([]db.CorridorSegmentParams, len(segments))
+	for i, segment := range segments {
+		params[i] = db.CorridorSegmentParams{
+			SensorID: segment.SensorID,
+			LengthM:  segment.LengthM,
+		}
+		if segment.FreeFlowSpeed != nil {
+			params[i].FreeFlowSpeed = pgtype.Float8{Float64: *segment.FreeFlowSpeed, Valid: true}
+		}
+	}
+	return params
+}
+
+func (service *Service) Create(ctx context.Context, req CorridorRequest) (Corridor, error) {
+	if err := validate(&req); err != nil {
+		return Corridor{}, err
+	}
+
+	corridor, segments, err := service.store.CreateCorridorTx(ctx, db.CreateCorridorParams{
+		Name:          req.Name,
+		Description:   pgtype.Text{String: req.Description, Valid: req.Description != ""},
+		FreeFlowSpeed: req.FreeFlowSpeed,
+	}, segmentParams(req.Segments))
+	if err != nil {
+		return Corridor{}, storeError(err)
+	}
+	return Corridor{Corridor: corridor, Segments: segments}, nil
+}
+
+// Update replaces a corridor's attributes and segments
+func (service *Service) Update(ctx context.Context, corridorID int32, req CorridorRequest) (Corridor, error) {
+	if err := validate(&req); err != nil {
+		return Corridor{}, err
+	}
+
+	corridor, segments, err := service.store.UpdateCorridorTx(ctx, db.UpdateCorridorParams{
+		CorridorID:    corridorID,
+		Name:          req.Name,
+		Description:   pgtype.Text{String: req.Description, Valid: req.Description != ""},
+		FreeFlowSpeed: req.FreeFlowSpeed,
+	}, segmentParams(req.Segments))
+	if err != nil {
+		return Corridor{}, storeError(err)
+	}
+	return Corridor{Corridor: corridor, Segments: segments}, nil
+}
+
+func (service *Service) Delete(ctx context.Context, corridorID int32) error {
+	deleted, err := service.store.DeleteCorridor(ctx, corridorID)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (service *Service) Get(ctx context.Context, corridorID int32) (Corridor, error) {
+	corridor, err := service.store.GetCorridor(ctx, corridorID)
+	if err != nil {
+		return Corridor{}, storeError(err)
+	}
+
+	segments, err := service.store.ListCorridorSegments(ctx, []int32{corridorID})
+	if err != nil {
+		return Corridor{}, err
+	}
+	return Corridor{Corridor: corridor, Segments: segments}, nil
+}
+
+// List returns all corridors with their segments ordered by name
+func (service *Service) List(ctx context.Context) ([]Corridor, error) {
+	rows, err := service.store.ListCorridors(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int32, len(rows))
+	byID := make(map[int32]int, len(rows))
+	corridors := make([]Corridor, len(rows))
+	for i, row := range rows {
+		ids[i] = row.CorridorID
+		byID[row.CorridorID] = i
+		corridors[i] = Corridor{Corridor: row, Segments: []db.CorridorSegment{}}
+	}
+
+	segments, err := service.store.ListCorridorSegments(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, segment := range segments {
+		corridor := &corridors[byID[segment.CorridorID]]
+		corridor.Segments = append(corridor.Segments, segment)
+	}
+	return corridors, nil
+}
+
+// sensorIDs returns the sensors of the segments except the excluded ones
+func (corridor Corridor) sensorIDs(excluded map[int32]bool) []int32 {
+	ids := make([]int32, 0, len(corridor.Segments))
+	for _, segment := range corridor.Segments {
+		if !excluded[segment.SensorID] {
+			ids = append(ids, segment.SensorID)
+		}
+	}
+	return ids
+}
+
+// Live estimates the travel time from each sensor's latest reading of the
+// last 15 minutes; segments without one, or whose sensor is excluded, are
+// assumed to flow freely
+func (service *Service) Live(ctx context.Context, corridorID int32, excluded map[int32]bool) (LiveTravelTime, error) {
+	corridor, err := service.Get(ctx, corridorID)
+	if err != nil {
+		return LiveTravelTime{}, err
+	}
+
+	since := time.Now().UTC().Add(-liveWindow)
+	rows, err := service.store.GetLatestSensorSpeeds(ctx, db.GetLatestSensorSpeedsParams{
+		SensorIds: corridor.sensorIDs(excluded),
+		Since:     pgtype.Timestamp{Time: since, Valid: true},
+	})
+	if err != nil {
+		return LiveTravelTime{}, err
+	}
+
+	var live LiveTravelTime
+	speeds := make(map[int32]float64, len(rows))
+	for _, row := range rows {
+		speeds[row.SensorID] = row.AverageSpeed
+		if live.AsOf == nil || row.Timestamp.Time.Before(*live.AsOf) {
+			asOf := row.Timestamp.Time
+			live.AsOf = &asOf
+		}
+	}
+	live.TravelTime = EstimateTravelTime(corridor, speeds)
+	return live, nil
+}
+
+// Series estimates the travel time per bucket from the average speeds the
+// sensors measured in it, reading from a rollup where the range allows.
+// Excluded sensors count as not having measured anything. With a fill
+// every bucket of the range gets a point, and the speeds of sensors
+// without readings in it are filled as it says.
+func (service *Service) Series(ctx context.Context, corridorID int32, width time.Duration, start, end time.Time, excluded map[int32]bool, fill series.Fill) ([]Point, rollup.Plan, error) {
+	corridor, err := service.Get(ctx, corridorID)
+	if err != nil {
+		return nil, rollup.Plan{}, err
+	}
+
+	var buckets map[int64]map[int32]float64
+	var filled map[int64]bool
+	var plan rollup.Plan
+	if fill != "" {
+		var rows []db.GetTrafficSeriesGapfillRow
+		rows, plan, err = service.rollups.GapfilledSeries(ctx, width, start, end, corridor.sensorIDs(excluded))
+		buckets, filled = filledSpeedsByBucket(rows, fill)
+	} else {
+		var rows []db.GetTrafficSeriesRow
+		rows, plan, err = service.rollups.Series(ctx, width, start, end, corridor.sensorIDs(excluded))
+		buckets = speedsByBucket(rows)
+	}
+	if err != nil {
+		return nil, plan, err
+	}
+
+	points := []Point{}
+	for key, speeds := range buckets {
+		points = append(points, Point{
+			Time:       time.UnixMicro(key).UTC(),
+			Filled:     filled[key],
+			TravelTime: EstimateTravelTime(corridor, speeds),
+		})
+	}
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Time.Before(points[j].Time)
+	})
+	return points, plan, nil
+}
@@ -0,0 +1,118 @@
+package corridor
+
+import (
+	db "smart_city/traffic_flow/db/sqlc"
+	"smart_city/traffic_flow/series"
+)
+
+// minSpeed in km/h keeps standing traffic from producing an infinite
+// travel time
+const minSpeed = 1
+
+// SegmentTravelTime is the estimate for one segment
+type SegmentTravelTime struct {
+	Position int32   `json:"position"`
+	SensorID int32   `json:"sensor_id"`
+	LengthM  float64 `json:"length_m"`
+	// Speed is the measured speed in km/h, nil when the sensor had no
+	// reading and the segment is assumed to flow freely
+	Speed             *float64 `json:"speed"`
+	FreeFlowSpeed     float64  `json:"free_flow_speed"`
+	TravelTimeSeconds float64  `json:"travel_time_seconds"`
+	FreeFlowSeconds   float64  `json:"free_flow_seconds"`
+}
+
+// TravelTime is the estimated time to drive a corridor end to end
+type TravelTime struct {
+	LengthM           float64 `json:"length_m"`
+	TravelTimeSeconds float64 `json:"travel_time_seconds"`
+	FreeFlowSeconds   float64 `json:"free_flow_seconds"`
+	// DelaySeconds is the time lost against free flow, never negative
+	DelaySeconds float64 `json:"delay_seconds"`
+	// TravelTimeIndex is the travel time divided by the free-flow time
+	TravelTimeIndex float64 `json:"travel_time_index"`
+	// Coverage is the share of the length with a measured speed
+	Coverage float64             `json:"coverage"`
+	Segments []SegmentTravelTime `json:"segments"`
+}
+
+// seconds is the time to cover meters at km/h
+func seconds(meters, speed float64) float64 {
+	return meters / (max(speed, minSpeed) / 3.6)
+}
+
+// EstimateTravelTime sums the time to cross every segment at the speed its
+// sensor measured, given in km/h by sensor id
+func EstimateTravelTime(corridor Corridor, speeds map[int32]float64) TravelTime {
+	estimate := TravelTime{Segments: make([]SegmentTravelTime, len(corridor.Segments))}
+
+	measured := 0.0
+	for i, segment := range corridor.Segments {
+		freeFlow := corridor.FreeFlowSpeed
+		if segment.FreeFlowSpeed.Valid {
+			freeFlow = segment.FreeFlowSpeed.Float64
+		}
+
+		segmentEstimate := SegmentTravelTime{
+			Position:        segment.Position,
+			SensorID:        segment.SensorID,
+			LengthM:         segment.LengthM,
+			FreeFlowSpeed:   freeFlow,
+			FreeFlowSeconds: seconds(segment.LengthM, freeFlow),
+		}
+		segmentEstimate.TravelTimeSeconds = segmentEstimate.FreeFlowSeconds
+		if speed, ok := speeds[segment.SensorID]; ok {
+			segmentEstimate.Speed = &speed
+			segmentEstimate.TravelTimeSeconds = seconds(segment.LengthM, speed)
+			measured += segment.LengthM
+		}
+
+		estimate.LengthM += segment.LengthM
+		estimate.TravelTimeSeconds += segmentEstimate.TravelTimeSeconds
+		estimate.FreeFlowSeconds += segmentEstimate.FreeFlowSeconds
+		estimate.Segments[i] = segmentEstimate
+	}
+
+	estimate.DelaySeconds = max(estimate.TravelTimeSeconds-estimate.FreeFlowSeconds, 0)
+	if estimate.FreeFlowSeconds > 0 {
+		estimate.TravelTimeIndex = estimate.TravelTimeSeconds / estimate.FreeFlowSeconds
+	}
+	if estimate.LengthM > 0 {
+		estimate.Coverage = measured / estimate.LengthM
+	}
+	return estimate
+}
+
+// speedsByBucket groups series rows into the speeds of each bucket
+func speedsByBucket(rows []db.GetTrafficSeriesRow) map[int64]map[int32]float64 {
+	buckets := make(map[int64]map[int32]float64)
+	for _, row := range rows {
+		key := row.Bucket.Time.UnixMicro()
+		if buckets[key] == nil {
+			buckets[key] = make(map[int32]float64)
+		}
+		buckets[key][row.SensorID] = row.AvgSpeed
+	}
+	return buckets
+}
+
+// filledSpeedsByBucket is speedsByBucket for gapfilled rows with their
+// speeds filled as fill says, also returning the buckets in which a sensor
+// had no readings. Every bucket is kept, even without any speed.
+func filledSpeedsByBucket(rows []db.GetTrafficSeriesGapfillRow, fill series.Fill) (map[int64]map[int32]float64, map[int64]bool) {
+	buckets := make(map[int64]map[int32]float64)
+	filled := make(map[int64]bool)
+	for _, row := range rows {
+		key := row.Bucket.Time.UnixMicro()
+		if buckets[key] == nil {
+			buckets[key] = make(map[int32]float64)
+		}
+		if row.Readings == 0 {
+			filled[key] = true
+		}
+		if speed, ok := fill.Speed(row); ok {
+			buckets[key][row.SensorID] = speed
+		}
+	}
+	return buckets, filled
+}
@@ -0,0 +1,90 @@
+package corridor
+
+import (
+	db "smart_city/traffic_flow/db/sqlc"
+	"smart_city/traffic_flow/series"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func testCorridor() Corridor {
+	return Corridor{
+		Corridor: db.Corridor{CorridorID: 1, Name: "ring road", FreeFlowSpeed: 60},
+		Segments: []db.CorridorSegment{
+			{CorridorID: 1, Position: 0, SensorID: 10, LengthM: 1000},
+			{CorridorID: 1, Position: 1, SensorID: 11, LengthM: 2000, FreeFlowSpeed: pgtype.Float8{Float64: 90, Valid: true}},
+		},
+	}
+}
+
+func TestEstimateTravelTime(t *testing.T) {
+	estimate := EstimateTravelTime(testCorridor(), map[int32]float64{10: 30, 11: 90})
+
+	require.Equal(t, 3000.0, estimate.LengthM)
+	// 1 km at 60 km/h and 2 km at 90 km/h
+	require.InDelta(t, 60+80, estimate.FreeFlowSeconds, 1e-9)
+	// The first segment takes twice as long at 30 km/h
+	require.InDelta(t, 120+80, estimate.TravelTimeSeconds, 1e-9)
+	require.InDelta(t, 60, estimate.DelaySeconds, 1e-9)
+	require.InDelta(t, 200.0/140, estimate.TravelTimeIndex, 1e-9)
+	require.Equal(t, 1.0, estimate.Coverage)
+	require.Len(t, estimate.Segments, 2)
+	require.Equal(t, 90.0, estimate.Segments[1].FreeFlowSpeed)
+}
+
+func TestEstimateTravelTimeMissingAndFast(t *testing.T) {
+	// Without a reading the segment is assumed to flow freely
+	estimate := EstimateTravelTime(testCorridor(), map[int32]float64{11: 120})
+
+	require.Nil(t, estimate.Segments[0].Speed)
+	require.InDelta(t, 2000.0/3000, estimate.Coverage, 1e-9)
+	require.InDelta(t, 60+60, estimate.TravelTimeSeconds, 1e-9)
+	// Faster than free flow is no delay
+	require.Zero(t, estimate.DelaySeconds)
+
+	// Standing traffic is floored at minSpeed
+	estimate = EstimateTravelTime(testCorridor(), map[int32]float64{10: 0})
+	require.InDelta(t, 3600+80, estimate.TravelTimeSeconds, 1e-9)
+}
+
+func TestSpeedsByBucket(t *testing.T) {
+	first := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+	rows := []db.GetTrafficSeriesRow{
+		{Bucket: pgtype.Timestamp{Time: first, Valid: true}, SensorID: 10, AvgSpeed: 40},
+		{Bucket: pgtype.Timestamp{Time: first, Valid: true}, SensorID: 11, AvgSpeed: 80},
+		{Bucket: pgtype.Timestamp{Time: second, Valid: true}, SensorID: 11, AvgSpeed: 70},
+	}
+
+	buckets := speedsByBucket(rows)
+	require.Len(t, buckets, 2)
+	require.Equal(t, map[int32]float64{10: 40, 11: 80}, buckets[first.UnixMicro()])
+	require.Equal(t, map[int32]float64{11: 70}, buckets[second.UnixMicro()])
+}
+
+func TestFilledSpeedsByBucket(t *testing.T) {
+	first := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+	speed := func(value float64) pgtype.Float8 {
+		return pgtype.Float8{Float64: value, Valid: true}
+	}
+	rows := []db.GetTrafficSeriesGapfillRow{
+		{Bucket: pgtype.Timestamp{Time: first, Valid: true}, SensorID: 10, Readings: 2, AvgSpeed: speed(40), AvgSpeedLocf: speed(40)},
+		// No readings before the range, so nothing to carry forward
+		{Bucket: pgtype.Timestamp{Time: first, Valid: true}, SensorID: 11},
+		{Bucket: pgtype.Timestamp{Time: second, Valid: true}, SensorID: 10, AvgSpeedLocf: speed(40)},
+		{Bucket: pgtype.Timestamp{Time: second, Valid: true}, SensorID: 11, Readings: 1, AvgSpeed: speed(70), AvgSpeedLocf: speed(70)},
+	}
+
+	buckets, filled := filledSpeedsByBucket(rows, series.FillLOCF)
+	require.Equal(t, map[int32]float64{10: 40}, buckets[first.UnixMicro()])
+	require.Equal(t, map[int32]float64{10: 40, 11: 70}, buckets[second.UnixMicro()])
+	require.True(t, filled[first.UnixMicro()])
+	require.True(t, filled[second.UnixMicro()])
+
+	buckets, _ = filledSpeedsByBucket(rows, series.FillNull)
+	require.Equal(t, map[int32]float64{11: 70}, buckets[second.UnixMicro()])
+}
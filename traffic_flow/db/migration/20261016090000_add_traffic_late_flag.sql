@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "traffic_data" ADD COLUMN "is_late" boolean NOT NULL DEFAULT false;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "traffic_data" DROP COLUMN "is_late";
+-- +goose StatementEnd
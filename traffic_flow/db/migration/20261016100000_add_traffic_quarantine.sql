@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "sensors" ALTER COLUMN "status" TYPE VARCHAR(20);
+
+CREATE TABLE "traffic_data_quarantine" (
+  "quarantine_id" BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "sensor_id" int NOT NULL,
+  "timestamp" timestamp NOT NULL,
+  "traffic_volume" int NOT NULL,
+  "average_speed" float NOT NULL,
+  "congestion_level" congestion_level_type NOT NULL,
+  "is_late" boolean NOT NULL DEFAULT false,
+  "reason" VARCHAR(20) NOT NULL,
+  "sensor_status" VARCHAR(20),
+  "received_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX "traffic_data_quarantine_sensor_id_idx" ON "traffic_data_quarantine" ("sensor_id", "timestamp");
+CREATE INDEX "traffic_data_quarantine_received_at_idx" ON "traffic_data_quarantine" ("received_at");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "traffic_data_quarantine";
+ALTER TABLE "sensors" ALTER COLUMN "status" TYPE VARCHAR(10);
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "traffic_data" ADD COLUMN "reported_congestion_level" VARCHAR(20);
+ALTER TABLE "traffic_data_quarantine" ADD COLUMN "reported_congestion_level" VARCHAR(20);
+
+-- A row applies to a sensor, to every sensor of a type, or, with neither
+-- set, is the city-wide default. Speeds at or below and volumes at or above
+-- a threshold classify a reading as at least that level.
+CREATE TABLE "congestion_thresholds" (
+  "threshold_id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "type_id" INT REFERENCES "sensor_types" ("type_id") ON DELETE CASCADE,
+  "sensor_id" INT REFERENCES "sensors" ("sensor_id") ON DELETE CASCADE,
+  "moderate_speed" DOUBLE PRECISION NOT NULL,
+  "high_speed" DOUBLE PRECISION NOT NULL,
+  "moderate_volume" INT NOT NULL,
+  "high_volume" INT NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("type_id" IS NULL OR "sensor_id" IS NULL),
+  CHECK ("high_speed" <= "moderate_speed" AND "high_volume" >= "moderate_volume")
+);
+
+CREATE UNIQUE INDEX "congestion_thresholds_type_id_key" ON "congestion_thresholds" ("type_id") WHERE "type_id" IS NOT NULL;
+CREATE UNIQUE INDEX "congestion_thresholds_sensor_id_key" ON "congestion_thresholds" ("sensor_id") WHERE "sensor_id" IS NOT NULL;
+CREATE UNIQUE INDEX "congestion_thresholds_default_key" ON "congestion_thresholds" ((true)) WHERE "type_id" IS NULL AND "sensor_id" IS NULL;
+
+INSERT INTO "congestion_thresholds" ("moderate_speed", "high_speed", "moderate_volume", "high_volume")
+VALUES (35, 15, 350, 600);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "congestion_thresholds";
+ALTER TABLE "traffic_data_quarantine" DROP COLUMN "reported_congestion_level";
+ALTER TABLE "traffic_data" DROP COLUMN "reported_congestion_level";
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "sensors" ADD COLUMN "last_seen_at" timestamp;
+
+-- Silence thresholds per sensor type; types without a row use the
+-- defaults from the environment
+CREATE TABLE "sensor_liveness_thresholds" (
+  "type_id" INT PRIMARY KEY REFERENCES "sensor_types" ("type_id") ON DELETE CASCADE,
+  "stale_after_seconds" INT NOT NULL,
+  "silent_after_seconds" INT NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("stale_after_seconds" > 0 AND "silent_after_seconds" >= "stale_after_seconds")
+);
+
+CREATE TABLE "sensor_status_transitions" (
+  "transition_id" BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "sensor_id" INT NOT NULL REFERENCES "sensors" ("sensor_id") ON DELETE CASCADE,
+  "from_status" VARCHAR(20) NOT NULL,
+  "to_status" VARCHAR(20) NOT NULL,
+  "last_seen_at" timestamp,
+  "transitioned_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX "sensor_status_transitions_sensor_id_idx" ON "sensor_status_transitions" ("sensor_id", "transitioned_at");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "sensor_status_transitions";
+DROP TABLE "sensor_liveness_thresholds";
+ALTER TABLE "sensors" DROP COLUMN "last_seen_at";
+-- +goose StatementEnd
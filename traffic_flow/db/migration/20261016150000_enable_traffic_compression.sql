@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Readings of a sensor are stored together so per-sensor queries only
+-- decompress their own segments
+ALTER TABLE "traffic_data" SET (
+  timescaledb.compress,
+  timescaledb.compress_segmentby = 'sensor_id',
+  timescaledb.compress_orderby = 'timestamp DESC'
+);
+
+-- Well past the window in which late readings are accepted
+SELECT add_compression_policy('traffic_data', compress_after => INTERVAL '7 days');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT remove_compression_policy('traffic_data', if_exists => true);
+SELECT decompress_chunk(c, if_compressed => true) FROM show_chunks('traffic_data') c;
+ALTER TABLE "traffic_data" SET (timescaledb.compress = false);
+-- +goose StatementEnd
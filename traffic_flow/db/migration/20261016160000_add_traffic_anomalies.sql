@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "traffic_anomalies" (
+  "anomaly_id" BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "sensor_id" int NOT NULL REFERENCES "sensors" ("sensor_id") ON DELETE CASCADE,
+  "timestamp" timestamp NOT NULL,
+  "metric" VARCHAR(20) NOT NULL,
+  "kind" VARCHAR(20) NOT NULL,
+  "observed" float NOT NULL,
+  "expected" float NOT NULL,
+  "stddev" float NOT NULL,
+  "z_score" float NOT NULL,
+  "detected_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX "traffic_anomalies_sensor_id_idx" ON "traffic_anomalies" ("sensor_id", "timestamp");
+CREATE INDEX "traffic_anomalies_timestamp_idx" ON "traffic_anomalies" ("timestamp");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "traffic_anomalies";
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Backs bounding box lookups; queries must filter on the same expression
+CREATE INDEX "sensors_location_idx" ON "sensors" USING gist (point("longitude", "latitude"));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX "sensors_location_idx";
+-- +goose StatementEnd
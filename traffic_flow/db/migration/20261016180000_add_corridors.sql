@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A corridor is a stretch of road covered by an ordered list of sensors,
+-- each standing for a segment of known length
+CREATE TABLE "corridors" (
+  "corridor_id" SERIAL PRIMARY KEY,
+  "name" VARCHAR(100) NOT NULL UNIQUE,
+  "description" TEXT,
+  -- km/h, used for segments without their own
+  "free_flow_speed" FLOAT NOT NULL CHECK ("free_flow_speed" > 0),
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "corridor_segments" (
+  "corridor_id" INT NOT NULL REFERENCES "corridors" ("corridor_id") ON DELETE CASCADE,
+  "position" INT NOT NULL,
+  "sensor_id" INT NOT NULL REFERENCES "sensors" ("sensor_id") ON DELETE CASCADE,
+  "length_m" FLOAT NOT NULL CHECK ("length_m" > 0),
+  "free_flow_speed" FLOAT CHECK ("free_flow_speed" > 0),
+  PRIMARY KEY ("corridor_id", "position")
+);
+
+CREATE INDEX "corridor_segments_sensor_id_idx" ON "corridor_segments" ("sensor_id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "corridor_segments";
+DROP TABLE "corridors";
+-- +goose StatementEnd
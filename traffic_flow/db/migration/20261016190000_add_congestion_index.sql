@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The city-wide congestion index: the volume-weighted share of free-flow
+-- speed lost across active sensors, one row per computation interval.
+-- Intervals without readings have no index rather than a 0, which would
+-- read as free-flowing traffic.
+CREATE TABLE "congestion_index" (
+  "timestamp" timestamp NOT NULL PRIMARY KEY,
+  -- 0 when traffic flows freely, 100 when it stands still
+  "value" FLOAT NOT NULL,
+  "sensors" int NOT NULL CHECK ("sensors" > 0),
+  "volume" bigint NOT NULL,
+  "avg_speed" FLOAT NOT NULL,
+  "computed_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+SELECT create_hypertable('congestion_index', 'timestamp', chunk_time_interval => INTERVAL '30 days');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "congestion_index";
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- name: CreateTrafficAnomaly :one
+INSERT INTO traffic_anomalies (
+  sensor_id,
+  timestamp,
+  metric,
+  kind,
+  observed,
+  expected,
+  stddev,
+  z_score
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: ListTrafficAnomalies :many
+SELECT * FROM traffic_anomalies
+WHERE timestamp >= sqlc.arg(start_time)::timestamp
+AND timestamp < sqlc.arg(end_time)::timestamp
+AND (sqlc.narg(sensor_id)::int IS NULL OR sensor_id = sqlc.narg(sensor_id))
+AND (sqlc.narg(kind)::text IS NULL OR kind = sqlc.narg(kind))
+ORDER BY timestamp DESC, anomaly_id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: GetSeasonalBaselines :many
+-- Mean and standard deviation of every sensor's readings per hour of the
+-- week, Monday 00:00 being hour 0
+SELECT
+  sensor_id,
+  ((EXTRACT(ISODOW FROM timestamp)::int - 1) * 24 + EXTRACT(HOUR FROM timestamp)::int)::int AS hour_of_week,
+  COUNT(*)::int AS samples,
+  AVG(traffic_volume)::float8 AS mean_volume,
+  COALESCE(STDDEV_POP(traffic_volume), 0)::float8 AS stddev_volume,
+  AVG(average_speed)::float8 AS mean_speed,
+  COALESCE(STDDEV_POP(average_speed), 0)::float8 AS stddev_speed
+FROM traffic_data
+WHERE timestamp >= sqlc.arg(since)::timestamp
+GROUP BY 1, 2;
@@ -0,0 +1,57 @@
+-- name: ListCongestionThresholds :many
+SELECT * FROM congestion_thresholds
+ORDER BY threshold_id;
+
+-- name: UpdateDefaultCongestionThresholds :one
+UPDATE congestion_thresholds
+SET moderate_speed = $1,
+    high_speed = $2,
+    moderate_volume = $3,
+    high_volume = $4,
+    updated_at = now()
+WHERE type_id IS NULL AND sensor_id IS NULL
+RETURNING *;
+
+-- name: UpsertSensorTypeCongestionThresholds :one
+INSERT INTO congestion_thresholds (
+  type_id,
+  moderate_speed,
+  high_speed,
+  moderate_volume,
+  high_volume
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+ON CONFLICT (type_id) WHERE type_id IS NOT NULL DO UPDATE
+SET moderate_speed = EXCLUDED.moderate_speed,
+    high_speed = EXCLUDED.high_speed,
+    moderate_volume = EXCLUDED.moderate_volume,
+    high_volume = EXCLUDED.high_volume,
+    updated_at = now()
+RETURNING *;
+
+-- name: UpsertSensorCongestionThresholds :one
+INSERT INTO congestion_thresholds (
+  sensor_id,
+  moderate_speed,
+  high_speed,
+  moderate_volume,
+  high_volume
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+ON CONFLICT (sensor_id) WHERE sensor_id IS NOT NULL DO UPDATE
+SET moderate_speed = EXCLUDED.moderate_speed,
+    high_speed = EXCLUDED.high_speed,
+    moderate_volume = EXCLUDED.moderate_volume,
+    high_volume = EXCLUDED.high_volume,
+    updated_at = now()
+RETURNING *;
+
+-- name: DeleteSensorTypeCongestionThresholds :execrows
+DELETE FROM congestion_thresholds
+WHERE type_id = $1;
+
+-- name: DeleteSensorCongestionThresholds :execrows
+DELETE FROM congestion_thresholds
+WHERE sensor_id = $1;
@@ -0,0 +1,93 @@
+-- name: GetFreeFlowSpeeds :many
+-- A high percentile of every sensor's speeds, the speed it sees when
+-- traffic flows freely
+SELECT
+  sensor_id,
+  percentile_cont(@fraction::float8) WITHIN GROUP (ORDER BY average_speed)::float8 AS free_flow_speed
+FROM traffic_data
+WHERE timestamp >= @since::timestamp
+GROUP BY sensor_id;
+
+-- name: GetCorridorFreeFlowSpeeds :many
+-- The free-flow speed configured for every sensor on a corridor, the
+-- segment's own or else the corridor's. A sensor on several corridors
+-- takes the highest.
+SELECT
+  cs.sensor_id,
+  MAX(COALESCE(cs.free_flow_speed, c.free_flow_speed))::float8 AS free_flow_speed
+FROM corridor_segments cs
+JOIN corridors c ON c.corridor_id = cs.corridor_id
+GROUP BY cs.sensor_id;
+
+-- name: GetActiveSensorFlows :many
+SELECT
+  td.sensor_id,
+  COUNT(*) AS readings,
+  SUM(td.traffic_volume)::bigint AS volume,
+  AVG(td.average_speed)::float8 AS avg_speed
+FROM traffic_data td
+JOIN sensors s ON td.sensor_id = s.sensor_id
+WHERE s.status = 'active'
+AND td.timestamp >= @start_time::timestamp AND td.timestamp < @end_time::timestamp
+GROUP BY td.sensor_id;
+
+-- name: UpsertCongestionIndex :one
+INSERT INTO congestion_index (
+  timestamp,
+  value,
+  sensors,
+  volume,
+  avg_speed
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+ON CONFLICT (timestamp) DO UPDATE SET
+  value = EXCLUDED.value,
+  sensors = EXCLUDED.sensors,
+  volume = EXCLUDED.volume,
+  avg_speed = EXCLUDED.avg_speed,
+  computed_at = now()
+RETURNING *;
+
+-- name: GetLatestCongestionIndex :one
+SELECT * FROM congestion_index
+ORDER BY timestamp DESC
+LIMIT 1;
+
+-- name: GetSensorFlowBuckets :many
+-- The flows of GetActiveSensorFlows for every interval of bucket_width in
+-- the range, keyed by the end of the interval like the stored index.
+-- Readings count whatever the status of their sensor is now.
+SELECT
+  (time_bucket(@bucket_width::interval, timestamp) + @bucket_width::interval)::timestamp AS bucket,
+  sensor_id,
+  COUNT(*) AS readings,
+  SUM(traffic_volume)::bigint AS volume,
+  AVG(average_speed)::float8 AS avg_speed
+FROM traffic_data
+WHERE timestamp >= @start_time::timestamp AND timestamp < @end_time::timestamp
+AND (sqlc.narg(sensor_ids)::int[] IS NULL OR sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
+GROUP BY 1, 2
+ORDER BY 1, 2;
+
+-- name: GetTypicalCongestionIndex :one
+-- Average index at the same hour of the week, Monday 00:00 being hour 0
+SELECT
+  COUNT(*)::int AS samples,
+  COALESCE(AVG(value), 0)::float8 AS value
+FROM congestion_index
+WHERE timestamp >= @since::timestamp AND timestamp < @until::timestamp
+AND ((EXTRACT(ISODOW FROM timestamp)::int - 1) * 24 + EXTRACT(HOUR FROM timestamp)::int) = @hour_of_week::int;
+
+-- name: GetCongestionIndexBuckets :many
+SELECT
+  time_bucket(@bucket_width::interval, timestamp)::timestamp AS bucket,
+  COUNT(*) AS samples,
+  COALESCE(SUM(value * volume) / NULLIF(SUM(volume), 0), AVG(value))::float8 AS value,
+  MIN(value)::float8 AS min_value,
+  MAX(value)::float8 AS max_value,
+  SUM(volume)::bigint AS volume
+FROM congestion_index
+WHERE timestamp >= @start_time::timestamp AND timestamp < @end_time::timestamp
+GROUP BY bucket
+ORDER BY bucket;
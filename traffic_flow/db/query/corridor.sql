@@ -0,0 +1,59 @@
+-- name: CreateCorridor :one
+INSERT INTO corridors (
+  name,
+  description,
+  free_flow_speed
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: GetCorridor :one
+SELECT * FROM corridors
+WHERE corridor_id = $1;
+
+-- name: ListCorridors :many
+SELECT * FROM corridors
+ORDER BY name;
+
+-- name: UpdateCorridor :one
+UPDATE corridors
+SET name = $2,
+    description = $3,
+    free_flow_speed = $4,
+    updated_at = now()
+WHERE corridor_id = $1
+RETURNING *;
+
+-- name: DeleteCorridor :execrows
+DELETE FROM corridors
+WHERE corridor_id = $1;
+
+-- name: CreateCorridorSegment :one
+INSERT INTO corridor_segments (
+  corridor_id,
+  position,
+  sensor_id,
+  length_m,
+  free_flow_speed
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: DeleteCorridorSegments :exec
+DELETE FROM corridor_segments
+WHERE corridor_id = $1;
+
+-- name: ListCorridorSegments :many
+SELECT * FROM corridor_segments
+WHERE corridor_id = ANY(@corridor_ids::int[])
+ORDER BY corridor_id, position;
+
+-- name: GetLatestSensorSpeeds :many
+SELECT DISTINCT ON (sensor_id)
+  sensor_id,
+  timestamp,
+  average_speed
+FROM traffic_data
+WHERE sensor_id = ANY(@sensor_ids::int[])
+AND timestamp >= @since::timestamp
+ORDER BY sensor_id, timestamp DESC;
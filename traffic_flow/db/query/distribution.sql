@@ -0,0 +1,52 @@
+-- name: GetTrafficPercentiles :many
+SELECT
+  sensor_id,
+  COUNT(*) AS readings,
+  percentile_cont(@fractions::float8[]) WITHIN GROUP (ORDER BY average_speed)::float8[] AS speed_percentiles,
+  percentile_cont(@fractions::float8[]) WITHIN GROUP (ORDER BY traffic_volume)::float8[] AS volume_percentiles
+FROM traffic_data
+WHERE timestamp >= @start_time::timestamp AND timestamp < @end_time::timestamp
+AND (sqlc.narg(sensor_ids)::int[] IS NULL OR sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
+GROUP BY sensor_id
+ORDER BY sensor_id;
+
+-- name: GetCombinedTrafficPercentiles :one
+SELECT
+  COUNT(*) AS readings,
+  percentile_cont(@fractions::float8[]) WITHIN GROUP (ORDER BY average_speed)::float8[] AS speed_percentiles,
+  percentile_cont(@fractions::float8[]) WITHIN GROUP (ORDER BY traffic_volume)::float8[] AS volume_percentiles
+FROM traffic_data
+WHERE timestamp >= @start_time::timestamp AND timestamp < @end_time::timestamp
+AND (sqlc.narg(sensor_ids)::int[] IS NULL OR sensor_id = ANY(sqlc.narg(sensor_ids)::int[]));
+
+-- name: GetSpeedHistogram :many
+SELECT
+  floor(average_speed / @bin_width::float8)::bigint AS bin,
+  COUNT(*) AS readings
+FROM traffic_data
+WHERE timestamp >= @start_time::timestamp AND timestamp < @end_time::timestamp
+AND (sqlc.narg(sensor_ids)::int[] IS NULL OR sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
+GROUP BY 1
+ORDER BY 1;
+
+-- name: GetTrafficValueRange :one
+-- Bounds the values a histogram of the same readings has to bin
+SELECT
+  COUNT(*) AS readings,
+  COALESCE(MIN(average_speed), 0)::float8 AS min_speed,
+  COALESCE(MAX(average_speed), 0)::float8 AS max_speed,
+  COALESCE(MIN(traffic_volume), 0)::float8 AS min_volume,
+  COALESCE(MAX(traffic_volume), 0)::float8 AS max_volume
+FROM traffic_data
+WHERE timestamp >= @start_time::timestamp AND timestamp < @end_time::timestamp
+AND (sqlc.narg(sensor_ids)::int[] IS NULL OR sensor_id = ANY(sqlc.narg(sensor_ids)::int[]));
+
+-- name: GetVolumeHistogram :many
+SELECT
+  floor(traffic_volume / @bin_width::float8)::bigint AS bin,
+  COUNT(*) AS readings
+FROM traffic_data
+WHERE timestamp >= @start_time::timestamp AND timestamp < @end_time::timestamp
+AND (sqlc.narg(sensor_ids)::int[] IS NULL OR sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
+GROUP BY 1
+ORDER BY 1;
@@ -0,0 +1,43 @@
+-- name: ListCongestionEpisodes :many
+-- Runs of consecutive high congestion readings per sensor, split where
+-- readings are more than max_gap apart. reference_speed is the sensor's
+-- average speed over its other readings in the range, or 0 without any.
+WITH readings AS (
+  SELECT
+    sensor_id,
+    timestamp,
+    traffic_volume,
+    average_speed,
+    congestion_level,
+    COUNT(*) FILTER (WHERE congestion_level <> 'high')
+      OVER (PARTITION BY sensor_id ORDER BY timestamp) AS run,
+    AVG(average_speed) FILTER (WHERE congestion_level <> 'high')
+      OVER (PARTITION BY sensor_id) AS reference_speed
+  FROM traffic_data
+  WHERE timestamp >= @start_time::timestamp AND timestamp < @end_time::timestamp
+  AND (sqlc.narg(sensor_ids)::int[] IS NULL OR sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
+), high AS (
+  SELECT
+    *,
+    CASE WHEN timestamp - LAG(timestamp) OVER (PARTITION BY sensor_id, run ORDER BY timestamp) > @max_gap::interval
+      THEN 1 ELSE 0 END AS split
+  FROM readings
+  WHERE congestion_level = 'high'
+), episodes AS (
+  SELECT
+    *,
+    SUM(split) OVER (PARTITION BY sensor_id, run ORDER BY timestamp) AS part
+  FROM high
+)
+SELECT
+  sensor_id,
+  MIN(timestamp)::timestamp AS start_time,
+  MAX(timestamp)::timestamp AS end_time,
+  COUNT(*) AS readings,
+  SUM(traffic_volume)::bigint AS sum_volume,
+  AVG(average_speed)::float8 AS avg_speed,
+  MIN(average_speed)::float8 AS min_speed,
+  COALESCE(MAX(reference_speed), 0)::float8 AS reference_speed
+FROM episodes
+GROUP BY sensor_id, run, part
+ORDER BY sensor_id, start_time;
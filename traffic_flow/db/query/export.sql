@@ -0,0 +1,31 @@
+-- name: ExportTrafficData :many
+-- A page of readings in (timestamp, sensor_id) order, starting after the
+-- given reading. The plain bound on timestamp lets chunks be excluded.
+SELECT * FROM traffic_data
+WHERE timestamp >= @after_time::timestamp AND timestamp < @end_time::timestamp
+AND (timestamp, sensor_id) > (@after_time::timestamp, @after_sensor_id::int)
+AND (sqlc.narg(sensor_ids)::int[] IS NULL OR sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
+ORDER BY timestamp, sensor_id
+LIMIT sqlc.arg(row_limit);
+
+-- name: ExportTrafficDataWithSensors :many
+-- ExportTrafficData with the location and type of each reading's sensor
+SELECT
+  t.sensor_id,
+  t.timestamp,
+  t.traffic_volume,
+  t.average_speed,
+  t.congestion_level,
+  t.is_late,
+  t.reported_congestion_level,
+  s.latitude,
+  s.longitude,
+  st.type_name
+FROM traffic_data t
+JOIN sensors s ON t.sensor_id = s.sensor_id
+JOIN sensor_types st ON s.type_id = st.type_id
+WHERE t.timestamp >= @after_time::timestamp AND t.timestamp < @end_time::timestamp
+AND (t.timestamp, t.sensor_id) > (@after_time::timestamp, @after_sensor_id::int)
+AND (sqlc.narg(sensor_ids)::int[] IS NULL OR t.sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
+ORDER BY t.timestamp, t.sensor_id
+LIMIT sqlc.arg(row_limit);
@@ -0,0 +1,41 @@
+-- name: ListSensorsWithinBox :many
+SELECT
+  s.sensor_id,
+  s.latitude,
+  s.longitude,
+  s.installation_date,
+  s.status,
+  s.last_seen_at,
+  st.type_name
+FROM sensors s
+JOIN sensor_types st ON s.type_id = st.type_id
+WHERE point(s.longitude, s.latitude) <@ box(point(@min_lon::float8, @min_lat::float8), point(@max_lon::float8, @max_lat::float8))
+AND (sqlc.narg(status)::text IS NULL OR s.status = sqlc.narg(status))
+ORDER BY s.sensor_id
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListSensorsNear :many
+-- Sensors within radius_m meters of (lat, lon), nearest first. The box
+-- narrows the candidates through the location index before the
+-- great-circle distance is computed.
+SELECT * FROM (
+  SELECT
+    s.sensor_id,
+    s.latitude,
+    s.longitude,
+    s.installation_date,
+    s.status,
+    s.last_seen_at,
+    st.type_name,
+    (2 * 6371008.8 * asin(least(1, sqrt(
+      power(sin(radians(s.latitude - @lat::float8) / 2), 2) +
+      cos(radians(@lat::float8)) * cos(radians(s.latitude)) * power(sin(radians(s.longitude - @lon::float8) / 2), 2)
+    ))))::float8 AS distance_m
+  FROM sensors s
+  JOIN sensor_types st ON s.type_id = st.type_id
+  WHERE point(s.longitude, s.latitude) <@ box(point(@min_lon::float8, @min_lat::float8), point(@max_lon::float8, @max_lat::float8))
+  AND (sqlc.narg(status)::text IS NULL OR s.status = sqlc.narg(status))
+) nearby
+WHERE distance_m <= @radius_m::float8
+ORDER BY distance_m, sensor_id
+LIMIT sqlc.arg(row_limit);
@@ -0,0 +1,42 @@
+-- name: ClaimIdempotencyKey :one
+-- Claims a key for a new request. An expired entry is taken over; a live
+-- one is left untouched and no row is returned.
+INSERT INTO idempotency_keys (
+  idempotency_key,
+  scope,
+  request_hash,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (idempotency_key, scope) DO UPDATE
+SET request_hash = EXCLUDED.request_hash,
+    status_code = NULL,
+    content_type = NULL,
+    response_body = NULL,
+    created_at = now(),
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at < now()
+RETURNING *;
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE idempotency_key = $1 AND scope = $2;
+
+-- name: SaveIdempotentResponse :exec
+-- Stores the response of a claimed key and extends the claim's short lease
+-- to the replay period.
+UPDATE idempotency_keys
+SET status_code = $3,
+    content_type = $4,
+    response_body = $5,
+    expires_at = $6
+WHERE idempotency_key = $1 AND scope = $2;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE idempotency_key = $1 AND scope = $2;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at < now();
@@ -0,0 +1,63 @@
+-- name: UpdateSensorLastSeen :execrows
+-- Advances last_seen_at to the newest reading of every sensor, looking
+-- only at readings measured after since
+UPDATE sensors s
+SET last_seen_at = latest.last_seen_at
+FROM (
+  SELECT sensor_id, MAX(timestamp)::timestamp AS last_seen_at
+  FROM traffic_data
+  WHERE timestamp > @since::timestamp
+  GROUP BY sensor_id
+) latest
+WHERE s.sensor_id = latest.sensor_id
+AND (s.last_seen_at IS NULL OR s.last_seen_at < latest.last_seen_at);
+
+-- name: ListSensorLiveness :many
+SELECT sensor_id, type_id, status, installation_date, last_seen_at FROM sensors
+WHERE status = ANY(@statuses::text[])
+ORDER BY sensor_id;
+
+-- name: TransitionSensorStatus :one
+-- Only changes the status if nobody else changed it in the meantime
+UPDATE sensors
+SET status = @to_status
+WHERE sensor_id = @sensor_id AND status = @from_status
+RETURNING *;
+
+-- name: CreateSensorStatusTransition :one
+INSERT INTO sensor_status_transitions (
+  sensor_id,
+  from_status,
+  to_status,
+  last_seen_at
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListSensorStatusTransitions :many
+SELECT * FROM sensor_status_transitions
+WHERE sensor_id = $1
+ORDER BY transitioned_at DESC, transition_id DESC
+LIMIT $2;
+
+-- name: ListLivenessThresholds :many
+SELECT * FROM sensor_liveness_thresholds
+ORDER BY type_id;
+
+-- name: UpsertLivenessThresholds :one
+INSERT INTO sensor_liveness_thresholds (
+  type_id,
+  stale_after_seconds,
+  silent_after_seconds
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (type_id) DO UPDATE
+SET stale_after_seconds = EXCLUDED.stale_after_seconds,
+    silent_after_seconds = EXCLUDED.silent_after_seconds,
+    updated_at = now()
+RETURNING *;
+
+-- name: DeleteLivenessThresholds :execrows
+DELETE FROM sensor_liveness_thresholds
+WHERE type_id = $1;
@@ -0,0 +1,55 @@
+-- name: GetSensorQualityStats :many
+-- Per sensor counts behind the data quality checks. A flatline is a run of
+-- identical consecutive readings; flatlined counts the readings in runs of
+-- at least min_flatline.
+WITH ordered AS (
+  SELECT
+    sensor_id,
+    timestamp,
+    traffic_volume,
+    average_speed,
+    timestamp - LAG(timestamp) OVER w AS gap,
+    (traffic_volume = LAG(traffic_volume) OVER w AND average_speed = LAG(average_speed) OVER w) AS repeated
+  FROM traffic_data
+  WHERE timestamp >= @start_time::timestamp AND timestamp < @end_time::timestamp
+  AND (sqlc.narg(sensor_ids)::int[] IS NULL OR sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
+  WINDOW w AS (PARTITION BY sensor_id ORDER BY timestamp)
+), runs AS (
+  SELECT
+    sensor_id,
+    COUNT(*) FILTER (WHERE repeated IS NOT TRUE)
+      OVER (PARTITION BY sensor_id ORDER BY timestamp) AS run
+  FROM ordered
+), flatlines AS (
+  SELECT
+    sensor_id,
+    MAX(length) AS longest_flatline,
+    SUM(length) FILTER (WHERE length >= @min_flatline::int) AS flatlined
+  FROM (
+    SELECT sensor_id, run, COUNT(*) AS length
+    FROM runs
+    GROUP BY sensor_id, run
+  ) AS run_lengths
+  GROUP BY sensor_id
+)
+SELECT
+  o.sensor_id,
+  COUNT(*) AS readings,
+  MIN(o.timestamp)::timestamp AS first_reading,
+  MAX(o.timestamp)::timestamp AS last_reading,
+  COALESCE(EXTRACT(EPOCH FROM percentile_cont(0.5) WITHIN GROUP (ORDER BY o.gap)), 0)::float8 AS median_gap_seconds,
+  COALESCE(EXTRACT(EPOCH FROM MAX(o.gap)), 0)::float8 AS max_gap_seconds,
+  COUNT(*) FILTER (
+    WHERE o.average_speed < 0 OR o.average_speed > @max_speed::float8
+    OR o.traffic_volume < 0 OR o.traffic_volume > @max_volume::int
+  ) AS range_violations,
+  COUNT(*) FILTER (
+    WHERE (o.traffic_volume = 0 AND o.average_speed > 0)
+    OR (o.traffic_volume > 0 AND o.average_speed = 0)
+  ) AS inconsistent,
+  MAX(f.longest_flatline)::bigint AS longest_flatline,
+  COALESCE(MAX(f.flatlined), 0)::bigint AS flatlined
+FROM ordered o
+JOIN flatlines f ON f.sensor_id = o.sensor_id
+GROUP BY o.sensor_id
+ORDER BY o.sensor_id;
@@ -0,0 +1,71 @@
+-- name: QuarantineTrafficData :one
+INSERT INTO traffic_data_quarantine (
+  sensor_id,
+  timestamp,
+  traffic_volume,
+  average_speed,
+  congestion_level,
+  is_late,
+  reason,
+  sensor_status,
+  reported_congestion_level
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: CopyQuarantinedTrafficData :copyfrom
+INSERT INTO traffic_data_quarantine (
+  sensor_id,
+  timestamp,
+  traffic_volume,
+  average_speed,
+  congestion_level,
+  is_late,
+  reason,
+  sensor_status,
+  reported_congestion_level
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+);
+
+-- name: GetQuarantinedTrafficData :one
+SELECT * FROM traffic_data_quarantine
+WHERE quarantine_id = $1;
+
+-- name: ListQuarantinedTrafficData :many
+SELECT * FROM traffic_data_quarantine
+WHERE (sqlc.narg(sensor_id)::int IS NULL OR sensor_id = sqlc.narg(sensor_id))
+AND (sqlc.narg(reason)::text IS NULL OR reason = sqlc.narg(reason))
+ORDER BY quarantine_id DESC
+LIMIT sqlc.arg(row_limit)
+OFFSET sqlc.arg(row_offset);
+
+-- name: SummarizeQuarantine :many
+SELECT
+  sensor_id,
+  reason,
+  COUNT(*) AS readings,
+  MIN(timestamp)::timestamp AS first_timestamp,
+  MAX(timestamp)::timestamp AS last_timestamp
+FROM traffic_data_quarantine
+GROUP BY sensor_id, reason
+ORDER BY readings DESC, sensor_id;
+
+-- name: LockQuarantinedTrafficData :many
+SELECT * FROM traffic_data_quarantine
+WHERE (sqlc.narg(quarantine_ids)::bigint[] IS NULL OR quarantine_id = ANY(sqlc.narg(quarantine_ids)::bigint[]))
+AND (sqlc.narg(sensor_id)::int IS NULL OR sensor_id = sqlc.narg(sensor_id))
+ORDER BY quarantine_id
+LIMIT sqlc.arg(row_limit)
+FOR UPDATE;
+
+-- name: DeleteQuarantinedTrafficDataByIDs :exec
+DELETE FROM traffic_data_quarantine
+WHERE quarantine_id = ANY(@quarantine_ids::bigint[]);
+
+-- name: PurgeQuarantinedTrafficData :execrows
+DELETE FROM traffic_data_quarantine
+WHERE (sqlc.narg(quarantine_ids)::bigint[] IS NULL OR quarantine_id = ANY(sqlc.narg(quarantine_ids)::bigint[]))
+AND (sqlc.narg(sensor_id)::int IS NULL OR sensor_id = sqlc.narg(sensor_id))
+AND (sqlc.narg(reason)::text IS NULL OR reason = sqlc.narg(reason))
+AND (sqlc.narg(received_before)::timestamptz IS NULL OR received_at < sqlc.narg(received_before));
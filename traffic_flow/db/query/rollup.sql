@@ -0,0 +1,300 @@
+-- Analytics queries against the hourly and daily continuous aggregates.
+-- Their results have the same shape as the raw traffic_data queries.
+
+-- name: GetTrafficSeriesHourly :many
+SELECT
+  time_bucket(@bucket_width::interval, bucket)::timestamp AS bucket,
+  sensor_id,
+  SUM(readings)::bigint AS readings,
+  (SUM(sum_volume)::float8 / SUM(readings))::float8 AS avg_volume,
+  MIN(min_volume)::int AS min_volume,
+  MAX(max_volume)::int AS max_volume,
+  SUM(sum_volume)::bigint AS sum_volume,
+  (SUM(sum_speed) / SUM(readings))::float8 AS avg_speed,
+  MIN(min_speed)::float8 AS min_speed,
+  MAX(max_speed)::float8 AS max_speed,
+  SUM(sum_speed)::float8 AS sum_speed
+FROM traffic_data_hourly
+WHERE traffic_data_hourly.bucket >= @start_time::timestamp AND traffic_data_hourly.bucket < @end_time::timestamp
+AND (sqlc.narg(sensor_ids)::int[] IS NULL OR sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
+GROUP BY 1, 2
+ORDER BY 2, 1;
+
+-- name: GetTrafficSeriesDaily :many
+SELECT
+  time_bucket(@bucket_width::interval, bucket)::timestamp AS bucket,
+  sensor_id,
+  SUM(readings)::bigint AS readings,
+  (SUM(sum_volume)::float8 / SUM(readings))::float8 AS avg_volume,
+  MIN(min_volume)::int AS min_volume,
+  MAX(max_volume)::int AS max_volume,
+  SUM(sum_volume)::bigint AS sum_volume,
+  (SUM(sum_speed) / SUM(readings))::float8 AS avg_speed,
+  MIN(min_speed)::float8 AS min_speed,
+  MAX(max_speed)::float8 AS max_speed,
+  SUM(sum_speed)::float8 AS sum_speed
+FROM traffic_data_daily
+WHERE traffic_data_daily.bucket >= @start_time::timestamp AND traffic_data_daily.bucket < @end_time::timestamp
+AND (sqlc.narg(sensor_ids)::int[] IS NULL OR sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
+GROUP BY 1, 2
+ORDER BY 2, 1;
+
+-- name: GetTrafficSeriesGapfillHourly :many
+-- GetTrafficSeriesGapfill against the hourly rollup
+WITH gapfilled AS (
+  SELECT
+    time_bucket_gapfill(@bucket_width::interval, bucket, @start_time::timestamp, @end_time::timestamp) AS bucket,
+    sensor_id,
+    SUM(readings)::bigint AS readings,
+    (SUM(sum_volume)::float8 / SUM(readings))::float8 AS avg_volume,
+    locf((SUM(sum_volume)::float8 / SUM(readings))::float8)::float8 AS avg_volume_locf,
+    interpolate((SUM(sum_volume)::float8 / SUM(readings))::float8)::float8 AS avg_volume_interpolated,
+    MIN(min_volume)::int AS min_volume,
+    locf(MIN(min_volume)::int)::int AS min_volume_locf,
+    interpolate(MIN(min_volume)::int)::int AS min_volume_interpolated,
+    MAX(max_volume)::int AS max_volume,
+    locf(MAX(max_volume)::int)::int AS max_volume_locf,
+    interpolate(MAX(max_volume)::int)::int AS max_volume_interpolated,
+    SUM(sum_volume)::bigint AS sum_volume,
+    locf(SUM(sum_volume)::bigint)::bigint AS sum_volume_locf,
+    interpolate(SUM(sum_volume)::bigint)::bigint AS sum_volume_interpolated,
+    (SUM(sum_speed) / SUM(readings))::float8 AS avg_speed,
+    locf((SUM(sum_speed) / SUM(readings))::float8)::float8 AS avg_speed_locf,
+    interpolate((SUM(sum_speed) / SUM(readings))::float8)::float8 AS avg_speed_interpolated,
+    MIN(min_speed)::float8 AS min_speed,
+    locf(MIN(min_speed)::float8)::float8 AS min_speed_locf,
+    interpolate(MIN(min_speed)::float8)::float8 AS min_speed_interpolated,
+    MAX(max_speed)::float8 AS max_speed,
+    locf(MAX(max_speed)::float8)::float8 AS max_speed_locf,
+    interpolate(MAX(max_speed)::float8)::float8 AS max_speed_interpolated,
+    SUM(sum_speed)::float8 AS sum_speed,
+    locf(SUM(sum_speed)::float8)::float8 AS sum_speed_locf,
+    interpolate(SUM(sum_speed)::float8)::float8 AS sum_speed_interpolated
+  FROM traffic_data_hourly
+  WHERE bucket >= @start_time::timestamp AND bucket < @end_time::timestamp
+  AND (sqlc.narg(sensor_ids)::int[] IS NULL OR sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
+  GROUP BY 1, 2
+)
+SELECT
+  b.bucket::timestamp AS bucket,
+  s.sensor_id,
+  COALESCE(g.readings, 0)::bigint AS readings,
+  g.avg_volume,
+  g.avg_volume_locf,
+  g.avg_volume_interpolated,
+  g.min_volume,
+  g.min_volume_locf,
+  g.min_volume_interpolated,
+  g.max_volume,
+  g.max_volume_locf,
+  g.max_volume_interpolated,
+  g.sum_volume,
+  g.sum_volume_locf,
+  g.sum_volume_interpolated,
+  g.avg_speed,
+  g.avg_speed_locf,
+  g.avg_speed_interpolated,
+  g.min_speed,
+  g.min_speed_locf,
+  g.min_speed_interpolated,
+  g.max_speed,
+  g.max_speed_locf,
+  g.max_speed_interpolated,
+  g.sum_speed,
+  g.sum_speed_locf,
+  g.sum_speed_interpolated
+FROM sensors s
+CROSS JOIN generate_series(time_bucket(@bucket_width::interval, @start_time::timestamp), @end_time::timestamp, @bucket_width::interval) AS b(bucket)
+LEFT JOIN gapfilled g ON g.bucket = b.bucket AND g.sensor_id = s.sensor_id
+WHERE b.bucket < @end_time::timestamp
+AND (sqlc.narg(sensor_ids)::int[] IS NULL OR s.sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
+ORDER BY s.sensor_id, b.bucket;
+
+-- name: GetTrafficSeriesGapfillDaily :many
+-- GetTrafficSeriesGapfill against the daily rollup
+WITH gapfilled AS (
+  SELECT
+    time_bucket_gapfill(@bucket_width::interval, bucket, @start_time::timestamp, @end_time::timestamp) AS bucket,
+    sensor_id,
+    SUM(readings)::bigint AS readings,
+    (SUM(sum_volume)::float8 / SUM(readings))::float8 AS avg_volume,
+    locf((SUM(sum_volume)::float8 / SUM(readings))::float8)::float8 AS avg_volume_locf,
+    interpolate((SUM(sum_volume)::float8 / SUM(readings))::float8)::float8 AS avg_volume_interpolated,
+    MIN(min_volume)::int AS min_volume,
+    locf(MIN(min_volume)::int)::int AS min_volume_locf,
+    interpolate(MIN(min_volume)::int)::int AS min_volume_interpolated,
+    MAX(max_volume)::int AS max_volume,
+    locf(MAX(max_volume)::int)::int AS max_volume_locf,
+    interpolate(MAX(max_volume)::int)::int AS max_volume_interpolated,
+    SUM(sum_volume)::bigint AS sum_volume,
+    locf(SUM(sum_volume)::bigint)::bigint AS sum_volume_locf,
+    interpolate(SUM(sum_volume)::bigint)::bigint AS sum_volume_interpolated,
+    (SUM(sum_speed) / SUM(readings))::float8 AS avg_speed,
+    locf((SUM(sum_speed) / SUM(readings))::float8)::float8 AS avg_speed_locf,
+    interpolate((SUM(sum_speed) / SUM(readings))::float8)::float8 AS avg_speed_interpolated,
+    MIN(min_speed)::float8 AS min_speed,
+    locf(MIN(min_speed)::float8)::float8 AS min_speed_locf,
+    interpolate(MIN(min_speed)::float8)::float8 AS min_speed_interpolated,
+    MAX(max_speed)::float8 AS max_speed,
+    locf(MAX(max_speed)::float8)::float8 AS max_speed_locf,
+    interpolate(MAX(max_speed)::float8)::float8 AS max_speed_interpolated,
+    SUM(sum_speed)::float8 AS sum_speed,
+    locf(SUM(sum_speed)::float8)::float8 AS sum_speed_locf,
+    interpolate(SUM(sum_speed)::float8)::float8 AS sum_speed_interpolated
+  FROM traffic_data_daily
+  WHERE bucket >= @start_time::timestamp AND bucket < @end_time::timestamp
+  AND (sqlc.narg(sensor_ids)::int[] IS NULL OR sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
+  GROUP BY 1, 2
+)
+SELECT
+  b.bucket::timestamp AS bucket,
+  s.sensor_id,
+  COALESCE(g.readings, 0)::bigint AS readings,
+  g.avg_volume,
+  g.avg_volume_locf,
+  g.avg_volume_interpolated,
+  g.min_volume,
+  g.min_volume_locf,
+  g.min_volume_interpolated,
+  g.max_volume,
+  g.max_volume_locf,
+  g.max_volume_interpolated,
+  g.sum_volume,
+  g.sum_volume_locf,
+  g.sum_volume_interpolated,
+  g.avg_speed,
+  g.avg_speed_locf,
+  g.avg_speed_interpolated,
+  g.min_speed,
+  g.min_speed_locf,
+  g.min_speed_interpolated,
+  g.max_speed,
+  g.max_speed_locf,
+  g.max_speed_interpolated,
+  g.sum_speed,
+  g.sum_speed_locf,
+  g.sum_speed_interpolated
+FROM sensors s
+CROSS JOIN generate_series(time_bucket(@bucket_width::interval, @start_time::timestamp), @end_time::timestamp, @bucket_width::interval) AS b(bucket)
+LEFT JOIN gapfilled g ON g.bucket = b.bucket AND g.sensor_id = s.sensor_id
+WHERE b.bucket < @end_time::timestamp
+AND (sqlc.narg(sensor_ids)::int[] IS NULL OR s.sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
+ORDER BY s.sensor_id, b.bucket;
+
+-- name: GetTrafficAveragesHourly :one
+SELECT
+  (SUM(sum_volume)::float8 / SUM(readings))::float8 AS avg_volume,
+  (SUM(sum_speed) / SUM(readings))::float8 AS avg_speed,
+  sensor_id
+FROM traffic_data_hourly
+WHERE sensor_id = @sensor_id
+AND bucket >= @start_time::timestamp AND bucket < @end_time::timestamp
+GROUP BY sensor_id;
+
+-- name: GetTrafficAveragesDaily :one
+SELECT
+  (SUM(sum_volume)::float8 / SUM(readings))::float8 AS avg_volume,
+  (SUM(sum_speed) / SUM(readings))::float8 AS avg_speed,
+  sensor_id
+FROM traffic_data_daily
+WHERE sensor_id = @sensor_id
+AND bucket >= @start_time::timestamp AND bucket < @end_time::timestamp
+GROUP BY sensor_id;
+
+-- name: GetHighCongestionAreasHourly :many
+SELECT
+  r.sensor_id,
+  s.latitude,
+  s.longitude,
+  SUM(r.high_count)::bigint AS high_congestion_count
+FROM traffic_data_hourly r
+JOIN sensors s ON r.sensor_id = s.sensor_id
+WHERE r.bucket >= @start_time::timestamp AND r.bucket < @end_time::timestamp
+AND (sqlc.narg(sensor_ids)::int[] IS NULL OR r.sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
+GROUP BY r.sensor_id, s.latitude, s.longitude
+HAVING SUM(r.high_count) > 0
+ORDER BY high_congestion_count DESC
+LIMIT @row_limit;
+
+-- name: GetHighCongestionAreasDaily :many
+SELECT
+  r.sensor_id,
+  s.latitude,
+  s.longitude,
+  SUM(r.high_count)::bigint AS high_congestion_count
+FROM traffic_data_daily r
+JOIN sensors s ON r.sensor_id = s.sensor_id
+WHERE r.bucket >= @start_time::timestamp AND r.bucket < @end_time::timestamp
+AND (sqlc.narg(sensor_ids)::int[] IS NULL OR r.sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
+GROUP BY r.sensor_id, s.latitude, s.longitude
+HAVING SUM(r.high_count) > 0
+ORDER BY high_congestion_count DESC
+LIMIT @row_limit;
+
+-- name: GetSensorCongestionDistributionHourly :many
+SELECT
+  t.sensor_id,
+  level.congestion_level::congestion_level_type AS congestion_level,
+  level.count::bigint AS count
+FROM (
+  SELECT sensor_id, SUM(low_count) AS low, SUM(moderate_count) AS moderate, SUM(high_count) AS high
+  FROM traffic_data_hourly
+  WHERE bucket >= @start_time::timestamp AND bucket < @end_time::timestamp
+  AND (sqlc.narg(sensor_ids)::int[] IS NULL OR sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
+  GROUP BY sensor_id
+) t
+CROSS JOIN LATERAL (
+  VALUES ('low', t.low), ('moderate', t.moderate), ('high', t.high)
+) AS level (congestion_level, count)
+WHERE level.count > 0
+ORDER BY t.sensor_id, 2;
+
+-- name: GetSensorCongestionDistributionDaily :many
+SELECT
+  t.sensor_id,
+  level.congestion_level::congestion_level_type AS congestion_level,
+  level.count::bigint AS count
+FROM (
+  SELECT sensor_id, SUM(low_count) AS low, SUM(moderate_count) AS moderate, SUM(high_count) AS high
+  FROM traffic_data_daily
+  WHERE bucket >= @start_time::timestamp AND bucket < @end_time::timestamp
+  AND (sqlc.narg(sensor_ids)::int[] IS NULL OR sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
+  GROUP BY sensor_id
+) t
+CROSS JOIN LATERAL (
+  VALUES ('low', t.low), ('moderate', t.moderate), ('high', t.high)
+) AS level (congestion_level, count)
+WHERE level.count > 0
+ORDER BY t.sensor_id, 2;
+
+-- name: GetSensorTrafficSummariesHourly :many
+SELECT
+  r.sensor_id,
+  s.latitude,
+  s.longitude,
+  SUM(r.readings)::bigint AS readings,
+  SUM(r.sum_volume)::bigint AS sum_volume,
+  SUM(r.sum_speed)::float8 AS sum_speed,
+  SUM(r.high_count)::bigint AS high_count
+FROM traffic_data_hourly r
+JOIN sensors s ON r.sensor_id = s.sensor_id
+WHERE r.bucket >= @start_time::timestamp AND r.bucket < @end_time::timestamp
+AND point(s.longitude, s.latitude) <@ box(point(@min_lon::float8, @min_lat::float8), point(@max_lon::float8, @max_lat::float8))
+GROUP BY r.sensor_id, s.latitude, s.longitude
+ORDER BY r.sensor_id;
+
+-- name: GetSensorTrafficSummariesDaily :many
+SELECT
+  r.sensor_id,
+  s.latitude,
+  s.longitude,
+  SUM(r.readings)::bigint AS readings,
+  SUM(r.sum_volume)::bigint AS sum_volume,
+  SUM(r.sum_speed)::float8 AS sum_speed,
+  SUM(r.high_count)::bigint AS high_count
+FROM traffic_data_daily r
+JOIN sensors s ON r.sensor_id = s.sensor_id
+WHERE r.bucket >= @start_time::timestamp AND r.bucket < @end_time::timestamp
+AND point(s.longitude, s.latitude) <@ box(point(@min_lon::float8, @min_lat::float8), point(@max_lon::float8, @max_lat::float8))
+GROUP BY r.sensor_id, s.latitude, s.longitude
+ORDER BY r.sensor_id;
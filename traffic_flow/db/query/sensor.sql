@@ -80,6 +80,7 @@ SELECT
   s.longitude,
   s.installation_date,
   s.status,
+  s.last_seen_at,
   st.type_name
 FROM sensors s
 JOIN sensor_types st ON s.type_id = st.type_id
@@ -95,3 +96,14 @@ RETURNING *;
 -- name: DeleteSensor :exec
 DELETE FROM sensors
 WHERE sensor_id = $1;
+
+-- name: ListExistingSensorIDs :many
+SELECT sensor_id FROM sensors
+WHERE sensor_id = ANY(@sensor_ids::int[]);
+
+-- name: ListSensorStates :many
+SELECT sensor_id, type_id, status FROM sensors;
+
+-- name: GetSensorState :one
+SELECT type_id, status FROM sensors
+WHERE sensor_id = $1;
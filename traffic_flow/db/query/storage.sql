@@ -0,0 +1,55 @@
+-- name: ListStoragePolicies :many
+SELECT
+  j.job_id,
+  j.proc_name::text AS policy,
+  COALESCE(j.config->>'compress_after', j.config->>'drop_after')::text AS older_than,
+  j.schedule_interval::text AS schedule_interval,
+  j.scheduled,
+  s.last_run_status,
+  s.next_start
+FROM timescaledb_information.jobs j
+LEFT JOIN timescaledb_information.job_stats s ON s.job_id = j.job_id
+WHERE j.hypertable_name = 'traffic_data'
+AND j.proc_name IN ('policy_compression', 'policy_retention')
+ORDER BY j.job_id;
+
+-- name: AddCompressionPolicy :one
+SELECT add_compression_policy('traffic_data', compress_after => @compress_after::interval)::int AS job_id;
+
+-- name: RemoveCompressionPolicy :exec
+SELECT remove_compression_policy('traffic_data', if_exists => true);
+
+-- name: AddRetentionPolicy :one
+SELECT add_retention_policy('traffic_data', drop_after => @drop_after::interval)::int AS job_id;
+
+-- name: RemoveRetentionPolicy :exec
+SELECT remove_retention_policy('traffic_data', if_exists => true);
+
+-- name: ListTrafficChunks :many
+SELECT
+  (c.chunk_schema || '.' || c.chunk_name)::text AS chunk_name,
+  c.range_start,
+  c.range_end,
+  c.is_compressed,
+  d.total_bytes,
+  s.before_compression_total_bytes AS before_compression_bytes,
+  s.after_compression_total_bytes AS after_compression_bytes
+FROM timescaledb_information.chunks c
+LEFT JOIN chunks_detailed_size('traffic_data') d
+  ON d.chunk_schema = c.chunk_schema AND d.chunk_name = c.chunk_name
+LEFT JOIN chunk_compression_stats('traffic_data') s
+  ON s.chunk_schema = c.chunk_schema AND s.chunk_name = c.chunk_name
+WHERE c.hypertable_name = 'traffic_data'
+ORDER BY c.range_start;
+
+-- name: ListTrafficChunksOlderThan :many
+SELECT c::text AS chunk_name
+FROM show_chunks('traffic_data', older_than => @older_than::interval) c;
+
+-- name: CompressTrafficChunks :many
+SELECT compress_chunk(c, if_not_compressed => true)::text AS chunk_name
+FROM show_chunks('traffic_data', older_than => @older_than::interval) c;
+
+-- name: DropTrafficChunks :many
+SELECT c::text AS chunk_name
+FROM drop_chunks('traffic_data', older_than => @older_than::interval) c;
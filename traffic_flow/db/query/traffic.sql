@@ -4,11 +4,54 @@ INSERT INTO traffic_data (
   timestamp,
   traffic_volume,
   average_speed,
-  congestion_level
+  congestion_level,
+  is_late,
+  reported_congestion_level
 ) VALUES (
-  $1, $2, $3, $4, $5
+  $1, $2, $3, $4, $5, $6, $7
 ) RETURNING *;
 
+-- name: UpsertTrafficData :one
+-- overwritten is true when an existing reading was updated; xmax is only
+-- set on a row version that replaced another one
+INSERT INTO traffic_data (
+  sensor_id,
+  timestamp,
+  traffic_volume,
+  average_speed,
+  congestion_level,
+  is_late,
+  reported_congestion_level
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (timestamp, sensor_id) DO UPDATE
+SET traffic_volume = EXCLUDED.traffic_volume,
+    average_speed = EXCLUDED.average_speed,
+    congestion_level = EXCLUDED.congestion_level,
+    is_late = EXCLUDED.is_late,
+    reported_congestion_level = EXCLUDED.reported_congestion_level
+RETURNING *, (xmax <> 0)::bool AS overwritten;
+
+-- name: RecordTrafficDataIfAbsent :one
+INSERT INTO traffic_data (
+  sensor_id,
+  timestamp,
+  traffic_volume,
+  average_speed,
+  congestion_level,
+  is_late,
+  reported_congestion_level
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (timestamp, sensor_id) DO NOTHING
+RETURNING *;
+
+-- name: GetTrafficDatum :one
+SELECT * FROM traffic_data
+WHERE timestamp = $1 AND sensor_id = $2;
+
 -- name: GetTrafficDataBySensor :many
 SELECT * FROM traffic_data
 WHERE sensor_id = $1
@@ -33,7 +76,7 @@ SELECT
   sensor_id
 FROM traffic_data
 WHERE sensor_id = $1
-AND timestamp BETWEEN $2 AND $3
+AND timestamp >= $2 AND timestamp < $3
 GROUP BY sensor_id;
 
 -- name: GetHighCongestionAreas :many
@@ -45,23 +88,107 @@ SELECT
 FROM traffic_data td
 JOIN sensors s ON td.sensor_id = s.sensor_id
 WHERE td.congestion_level = 'high'
-AND td.timestamp BETWEEN $1 AND $2
+AND td.timestamp >= @start_time::timestamp AND td.timestamp < @end_time::timestamp
+AND (sqlc.narg(sensor_ids)::int[] IS NULL OR td.sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
 GROUP BY td.sensor_id, s.latitude, s.longitude
 ORDER BY high_congestion_count DESC
-LIMIT $3;
+LIMIT @row_limit;
 
--- name: GetDailyTrafficStats :many
+-- name: GetTrafficSeries :many
 SELECT
-  DATE_TRUNC('day', timestamp) as day,
+  time_bucket(@bucket_width::interval, timestamp)::timestamp AS bucket,
   sensor_id,
-  AVG(traffic_volume) as avg_volume,
-  MAX(traffic_volume) as max_volume,
-  MIN(traffic_volume) as min_volume,
-  AVG(average_speed) as avg_speed
+  COUNT(*) AS readings,
+  AVG(traffic_volume)::float8 AS avg_volume,
+  MIN(traffic_volume)::int AS min_volume,
+  MAX(traffic_volume)::int AS max_volume,
+  SUM(traffic_volume)::bigint AS sum_volume,
+  AVG(average_speed)::float8 AS avg_speed,
+  MIN(average_speed)::float8 AS min_speed,
+  MAX(average_speed)::float8 AS max_speed,
+  SUM(average_speed)::float8 AS sum_speed
 FROM traffic_data
-WHERE timestamp BETWEEN $1 AND $2
-GROUP BY day, sensor_id
-ORDER BY day, sensor_id;
+WHERE timestamp >= @start_time::timestamp AND timestamp < @end_time::timestamp
+AND (sqlc.narg(sensor_ids)::int[] IS NULL OR sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
+GROUP BY bucket, sensor_id
+ORDER BY sensor_id, bucket;
+
+-- name: GetTrafficSeriesGapfill :many
+-- GetTrafficSeries with a row for every bucket from start_time to end_time
+-- and every sensor asked for, or every sensor without sensor_ids, whether it
+-- reported or not. Buckets without readings have 0 readings and null
+-- aggregates, which the _locf and _interpolated columns fill with TimescaleDB's
+-- locf() and interpolate(). Both leave the buckets before a sensor's first
+-- reading null, and interpolate() also those after its last. Sensors without
+-- any reading in the range come from the bucket grid alone, with no values.
+WITH gapfilled AS (
+  SELECT
+    time_bucket_gapfill(@bucket_width::interval, timestamp, @start_time::timestamp, @end_time::timestamp) AS bucket,
+    sensor_id,
+    COUNT(*) AS readings,
+    AVG(traffic_volume)::float8 AS avg_volume,
+    locf(AVG(traffic_volume)::float8)::float8 AS avg_volume_locf,
+    interpolate(AVG(traffic_volume)::float8)::float8 AS avg_volume_interpolated,
+    MIN(traffic_volume)::int AS min_volume,
+    locf(MIN(traffic_volume)::int)::int AS min_volume_locf,
+    interpolate(MIN(traffic_volume)::int)::int AS min_volume_interpolated,
+    MAX(traffic_volume)::int AS max_volume,
+    locf(MAX(traffic_volume)::int)::int AS max_volume_locf,
+    interpolate(MAX(traffic_volume)::int)::int AS max_volume_interpolated,
+    SUM(traffic_volume)::bigint AS sum_volume,
+    locf(SUM(traffic_volume)::bigint)::bigint AS sum_volume_locf,
+    interpolate(SUM(traffic_volume)::bigint)::bigint AS sum_volume_interpolated,
+    AVG(average_speed)::float8 AS avg_speed,
+    locf(AVG(average_speed)::float8)::float8 AS avg_speed_locf,
+    interpolate(AVG(average_speed)::float8)::float8 AS avg_speed_interpolated,
+    MIN(average_speed)::float8 AS min_speed,
+    locf(MIN(average_speed)::float8)::float8 AS min_speed_locf,
+    interpolate(MIN(average_speed)::float8)::float8 AS min_speed_interpolated,
+    MAX(average_speed)::float8 AS max_speed,
+    locf(MAX(average_speed)::float8)::float8 AS max_speed_locf,
+    interpolate(MAX(average_speed)::float8)::float8 AS max_speed_interpolated,
+    SUM(average_speed)::float8 AS sum_speed,
+    locf(SUM(average_speed)::float8)::float8 AS sum_speed_locf,
+    interpolate(SUM(average_speed)::float8)::float8 AS sum_speed_interpolated
+  FROM traffic_data
+  WHERE timestamp >= @start_time::timestamp AND timestamp < @end_time::timestamp
+  AND (sqlc.narg(sensor_ids)::int[] IS NULL OR sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
+  GROUP BY 1, 2
+)
+SELECT
+  b.bucket::timestamp AS bucket,
+  s.sensor_id,
+  COALESCE(g.readings, 0)::bigint AS readings,
+  g.avg_volume,
+  g.avg_volume_locf,
+  g.avg_volume_interpolated,
+  g.min_volume,
+  g.min_volume_locf,
+  g.min_volume_interpolated,
+  g.max_volume,
+  g.max_volume_locf,
+  g.max_volume_interpolated,
+  g.sum_volume,
+  g.sum_volume_locf,
+  g.sum_volume_interpolated,
+  g.avg_speed,
+  g.avg_speed_locf,
+  g.avg_speed_interpolated,
+  g.min_speed,
+  g.min_speed_locf,
+  g.min_speed_interpolated,
+  g.max_speed,
+  g.max_speed_locf,
+  g.max_speed_interpolated,
+  g.sum_speed,
+  g.sum_speed_locf,
+  g.sum_speed_interpolated
+FROM sensors s
+CROSS JOIN generate_series(time_bucket(@bucket_width::interval, @start_time::timestamp), @end_time::timestamp, @bucket_width::interval) AS b(bucket)
+LEFT JOIN gapfilled g ON g.bucket = b.bucket AND g.sensor_id = s.sensor_id
+WHERE b.bucket < @end_time::timestamp
+AND (sqlc.narg(sensor_ids)::int[] IS NULL OR s.sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
+ORDER BY s.sensor_id, b.bucket;
 
 -- name: GetSensorCongestionDistribution :many
 SELECT
@@ -69,6 +196,47 @@ SELECT
   congestion_level,
   COUNT(*) as count
 FROM traffic_data
-WHERE timestamp BETWEEN $1 AND $2
+WHERE timestamp >= @start_time::timestamp AND timestamp < @end_time::timestamp
+AND (sqlc.narg(sensor_ids)::int[] IS NULL OR sensor_id = ANY(sqlc.narg(sensor_ids)::int[]))
 GROUP BY sensor_id, congestion_level
 ORDER BY sensor_id, congestion_level;
+
+-- name: GetSensorTrafficSummaries :many
+SELECT
+  t.sensor_id,
+  s.latitude,
+  s.longitude,
+  COUNT(*) AS readings,
+  SUM(t.traffic_volume)::bigint AS sum_volume,
+  SUM(t.average_speed)::float8 AS sum_speed,
+  COUNT(*) FILTER (WHERE t.congestion_level = 'high') AS high_count
+FROM traffic_data t
+JOIN sensors s ON t.sensor_id = s.sensor_id
+WHERE t.timestamp >= @start_time::timestamp AND t.timestamp < @end_time::timestamp
+AND point(s.longitude, s.latitude) <@ box(point(@min_lon::float8, @min_lat::float8), point(@max_lon::float8, @max_lat::float8))
+GROUP BY t.sensor_id, s.latitude, s.longitude
+ORDER BY t.sensor_id;
+
+-- name: CopyTrafficData :copyfrom
+INSERT INTO traffic_data (
+  sensor_id,
+  timestamp,
+  traffic_volume,
+  average_speed,
+  congestion_level,
+  is_late,
+  reported_congestion_level
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+);
+
+-- name: ListExistingTrafficKeys :many
+SELECT timestamp, sensor_id FROM traffic_data
+WHERE sensor_id = ANY(@sensor_ids::int[])
+AND timestamp = ANY(@timestamps::timestamp[]);
+
+-- name: DeleteTrafficDataByKeys :exec
+DELETE FROM traffic_data
+WHERE (timestamp, sensor_id) IN (
+  SELECT unnest(@timestamps::timestamp[]), unnest(@sensor_ids::int[])
+);
@@ -0,0 +1,63 @@
+-- The TimescaleDB views and functions the queries read, declared so that
+-- sqlc can type them. The extension creates the real ones; this file is
+-- only read by sqlc and never run against a database.
+
+CREATE SCHEMA timescaledb_information;
+
+CREATE TABLE timescaledb_information.jobs (
+  "job_id" int NOT NULL,
+  "proc_name" name NOT NULL,
+  "schedule_interval" interval NOT NULL,
+  "config" jsonb,
+  "scheduled" boolean NOT NULL,
+  "hypertable_name" name
+);
+
+CREATE TABLE timescaledb_information.job_stats (
+  "job_id" int NOT NULL,
+  "last_run_status" text,
+  "next_start" timestamptz
+);
+
+CREATE TABLE timescaledb_information.chunks (
+  "hypertable_name" name NOT NULL,
+  "chunk_schema" name NOT NULL,
+  "chunk_name" name NOT NULL,
+  "range_start" timestamptz,
+  "range_end" timestamptz,
+  "is_compressed" boolean NOT NULL
+);
+
+-- Row types of the set-returning functions below
+CREATE TABLE timescaledb_information.chunk_size (
+  "chunk_schema" name,
+  "chunk_name" name,
+  "table_bytes" bigint,
+  "index_bytes" bigint,
+  "toast_bytes" bigint,
+  "total_bytes" bigint,
+  "node_name" name
+);
+
+CREATE TABLE timescaledb_information.chunk_compression (
+  "chunk_schema" name,
+  "chunk_name" name,
+  "compression_status" text,
+  "before_compression_table_bytes" bigint,
+  "before_compression_index_bytes" bigint,
+  "before_compression_toast_bytes" bigint,
+  "before_compression_total_bytes" bigint,
+  "after_compression_table_bytes" bigint,
+  "after_compression_index_bytes" bigint,
+  "after_compression_toast_bytes" bigint,
+  "after_compression_total_bytes" bigint,
+  "node_name" name
+);
+
+CREATE FUNCTION chunks_detailed_size(hypertable regclass)
+RETURNS SETOF timescaledb_information.chunk_size
+LANGUAGE sql AS $$ SELECT * FROM timescaledb_information.chunk_size $$;
+
+CREATE FUNCTION chunk_compression_stats(hypertable regclass)
+RETURNS SETOF timescaledb_information.chunk_compression
+LANGUAGE sql AS $$ SELECT * FROM timescaledb_information.chunk_compression $$;
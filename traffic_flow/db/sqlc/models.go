@@ -54,13 +54,78 @@ func (ns NullCongestionLevelType) Value() (driver.Value, error) {
 	return string(ns.CongestionLevelType), nil
 }
 
+type CongestionIndex struct {
+	Timestamp  pgtype.Timestamp   `json:"timestamp"`
+	Value      float64            `json:"value"`
+	Sensors    int32              `json:"sensors"`
+	Volume     int64              `json:"volume"`
+	AvgSpeed   float64            `json:"avg_speed"`
+	ComputedAt pgtype.Timestamptz `json:"computed_at"`
+}
+
+type CongestionThreshold struct {
+	ThresholdID    int32              `json:"threshold_id"`
+	TypeID         pgtype.Int4        `json:"type_id"`
+	SensorID       pgtype.Int4        `json:"sensor_id"`
+	ModerateSpeed  float64            `json:"moderate_speed"`
+	HighSpeed      float64            `json:"high_speed"`
+	ModerateVolume int32              `json:"moderate_volume"`
+	HighVolume     int32              `json:"high_volume"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+type Corridor struct {
+	CorridorID    int32              `json:"corridor_id"`
+	Name          string             `json:"name"`
+	Description   pgtype.Text        `json:"description"`
+	FreeFlowSpeed float64            `json:"free_flow_speed"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+}
+
+type CorridorSegment struct {
+	CorridorID    int32         `json:"corridor_id"`
+	Position      int32         `json:"position"`
+	SensorID      int32         `json:"sensor_id"`
+	LengthM       float64       `json:"length_m"`
+	FreeFlowSpeed pgtype.Float8 `json:"free_flow_speed"`
+}
+
+type IdempotencyKey struct {
+	IdempotencyKey string             `json:"idempotency_key"`
+	Scope          string             `json:"scope"`
+	RequestHash    []byte             `json:"request_hash"`
+	StatusCode     pgtype.Int4        `json:"status_code"`
+	ContentType    pgtype.Text        `json:"content_type"`
+	ResponseBody   []byte             `json:"response_body"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	ExpiresAt      pgtype.Timestamptz `json:"expires_at"`
+}
+
 type Sensor struct {
-	SensorID         int32       `json:"sensor_id"`
-	Latitude         float64     `json:"latitude"`
-	Longitude        float64     `json:"longitude"`
-	TypeID           int32       `json:"type_id"`
-	InstallationDate pgtype.Date `json:"installation_date"`
-	Status           string      `json:"status"`
+	SensorID         int32            `json:"sensor_id"`
+	Latitude         float64          `json:"latitude"`
+	Longitude        float64          `json:"longitude"`
+	TypeID           int32            `json:"type_id"`
+	InstallationDate pgtype.Date      `json:"installation_date"`
+	Status           string           `json:"status"`
+	LastSeenAt       pgtype.Timestamp `json:"last_seen_at"`
+}
+
+type SensorLivenessThreshold struct {
+	TypeID             int32              `json:"type_id"`
+	StaleAfterSeconds  int32              `json:"stale_after_seconds"`
+	SilentAfterSeconds int32              `json:"silent_after_seconds"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+}
+
+type SensorStatusTransition struct {
+	TransitionID   int64              `json:"transition_id"`
+	SensorID       int32              `json:"sensor_id"`
+	FromStatus     string             `json:"from_status"`
+	ToStatus       string             `json:"to_status"`
+	LastSeenAt     pgtype.Timestamp   `json:"last_seen_at"`
+	TransitionedAt pgtype.Timestamptz `json:"transitioned_at"`
 }
 
 type SensorType struct {
@@ -69,10 +134,118 @@ type SensorType struct {
 	Description pgtype.Text `json:"description"`
 }
 
+type TimescaledbInformationChunk struct {
+	HypertableName string             `json:"hypertable_name"`
+	ChunkSchema    string             `json:"chunk_schema"`
+	ChunkName      string             `json:"chunk_name"`
+	RangeStart     pgtype.Timestamptz `json:"range_start"`
+	RangeEnd       pgtype.Timestamptz `json:"range_end"`
+	IsCompressed   bool               `json:"is_compressed"`
+}
+
+type TimescaledbInformationChunkCompression struct {
+	ChunkSchema                 pgtype.Text `json:"chunk_schema"`
+	ChunkName                   pgtype.Text `json:"chunk_name"`
+	CompressionStatus           pgtype.Text `json:"compression_status"`
+	BeforeCompressionTableBytes pgtype.Int8 `json:"before_compression_table_bytes"`
+	BeforeCompressionIndexBytes pgtype.Int8 `json:"before_compression_index_bytes"`
+	BeforeCompressionToastBytes pgtype.Int8 `json:"before_compression_toast_bytes"`
+	BeforeCompressionTotalBytes pgtype.Int8 `json:"before_compression_total_bytes"`
+	AfterCompressionTableBytes  pgtype.Int8 `json:"after_compression_table_bytes"`
+	AfterCompressionIndexBytes  pgtype.Int8 `json:"after_compression_index_bytes"`
+	AfterCompressionToastBytes  pgtype.Int8 `json:"after_compression_toast_bytes"`
+	AfterCompressionTotalBytes  pgtype.Int8 `json:"after_compression_total_bytes"`
+	NodeName                    pgtype.Text `json:"node_name"`
+}
+
+type TimescaledbInformationChunkSize struct {
+	ChunkSchema pgtype.Text `json:"chunk_schema"`
+	ChunkName   pgtype.Text `json:"chunk_name"`
+	TableBytes  pgtype.Int8 `json:"table_bytes"`
+	IndexBytes  pgtype.Int8 `json:"index_bytes"`
+	ToastBytes  pgtype.Int8 `json:"toast_bytes"`
+	TotalBytes  pgtype.Int8 `json:"total_bytes"`
+	NodeName    pgtype.Text `json:"node_name"`
+}
+
+type TimescaledbInformationJob struct {
+	JobID            int32           `json:"job_id"`
+	ProcName         string          `json:"proc_name"`
+	ScheduleInterval pgtype.Interval `json:"schedule_interval"`
+	Config           []byte          `json:"config"`
+	Scheduled        bool            `json:"scheduled"`
+	HypertableName   pgtype.Text     `json:"hypertable_name"`
+}
+
+type TimescaledbInformationJobStat struct {
+	JobID         int32              `json:"job_id"`
+	LastRunStatus pgtype.Text        `json:"last_run_status"`
+	NextStart     pgtype.Timestamptz `json:"next_start"`
+}
+
+type TrafficAnomaly struct {
+	AnomalyID  int64              `json:"anomaly_id"`
+	SensorID   int32              `json:"sensor_id"`
+	Timestamp  pgtype.Timestamp   `json:"timestamp"`
+	Metric     string             `json:"metric"`
+	Kind       string             `json:"kind"`
+	Observed   float64            `json:"observed"`
+	Expected   float64            `json:"expected"`
+	Stddev     float64            `json:"stddev"`
+	ZScore     float64            `json:"z_score"`
+	DetectedAt pgtype.Timestamptz `json:"detected_at"`
+}
+
+type TrafficDataDaily struct {
+	Bucket        interface{} `json:"bucket"`
+	SensorID      int32       `json:"sensor_id"`
+	Readings      int64       `json:"readings"`
+	SumVolume     int64       `json:"sum_volume"`
+	MinVolume     interface{} `json:"min_volume"`
+	MaxVolume     interface{} `json:"max_volume"`
+	SumSpeed      int64       `json:"sum_speed"`
+	MinSpeed      interface{} `json:"min_speed"`
+	MaxSpeed      interface{} `json:"max_speed"`
+	LowCount      int64       `json:"low_count"`
+	ModerateCount int64       `json:"moderate_count"`
+	HighCount     int64       `json:"high_count"`
+}
+
+type TrafficDataHourly struct {
+	Bucket        interface{} `json:"bucket"`
+	SensorID      int32       `json:"sensor_id"`
+	Readings      int64       `json:"readings"`
+	SumVolume     int64       `json:"sum_volume"`
+	MinVolume     interface{} `json:"min_volume"`
+	MaxVolume     interface{} `json:"max_volume"`
+	SumSpeed      int64       `json:"sum_speed"`
+	MinSpeed      interface{} `json:"min_speed"`
+	MaxSpeed      interface{} `json:"max_speed"`
+	LowCount      int64       `json:"low_count"`
+	ModerateCount int64       `json:"moderate_count"`
+	HighCount     int64       `json:"high_count"`
+}
+
+type TrafficDataQuarantine struct {
+	QuarantineID            int64               `json:"quarantine_id"`
+	SensorID                int32               `json:"sensor_id"`
+	Timestamp               pgtype.Timestamp    `json:"timestamp"`
+	TrafficVolume           int32               `json:"traffic_volume"`
+	AverageSpeed            float64             `json:"average_speed"`
+	CongestionLevel         CongestionLevelType `json:"congestion_level"`
+	IsLate                  bool                `json:"is_late"`
+	Reason                  string              `json:"reason"`
+	SensorStatus            pgtype.Text         `json:"sensor_status"`
+	ReceivedAt              pgtype.Timestamptz  `json:"received_at"`
+	ReportedCongestionLevel pgtype.Text         `json:"reported_congestion_level"`
+}
+
 type TrafficDatum struct {
-	SensorID        int32               `json:"sensor_id"`
-	Timestamp       pgtype.Timestamp    `json:"timestamp"`
-	TrafficVolume   int32               `json:"traffic_volume"`
-	AverageSpeed    float64             `json:"average_speed"`
-	CongestionLevel CongestionLevelType `json:"congestion_level"`
+	SensorID                int32               `json:"sensor_id"`
+	Timestamp               pgtype.Timestamp    `json:"timestamp"`
+	TrafficVolume           int32               `json:"traffic_volume"`
+	AverageSpeed            float64             `json:"average_speed"`
+	CongestionLevel         CongestionLevelType `json:"congestion_level"`
+	IsLate                  bool                `json:"is_late"`
+	ReportedCongestionLevel pgtype.Text         `json:"reported_congestion_level"`
 }
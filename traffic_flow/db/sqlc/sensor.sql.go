@@ -20,7 +20,7 @@ INSERT INTO sensors (
   status
 ) VALUES (
   $1, $2, $3, $4, $5
-) RETURNING sensor_id, latitude, longitude, type_id, installation_date, status
+) RETURNING sensor_id, latitude, longitude, type_id, installation_date, status, last_seen_at
 `
 
 type CreateSensorParams struct {
@@ -47,6 +47,7 @@ func (q *Queries) CreateSensor(ctx context.Context, arg CreateSensorParams) (Sen
 		&i.TypeID,
 		&i.InstallationDate,
 		&i.Status,
+		&i.LastSeenAt,
 	)
 	return i, err
 }
@@ -99,6 +100,7 @@ SELECT
   s.longitude,
   s.installation_date,
   s.status,
+  s.last_seen_at,
   st.type_name
 FROM sensors s
 JOIN sensor_types st ON s.type_id = st.type_id
@@ -107,12 +109,13 @@ ORDER BY s.sensor_id
 `
 
 type GetActiveSensorsRow struct {
-	SensorID         int32       `json:"sensor_id"`
-	Latitude         float64     `json:"latitude"`
-	Longitude        float64     `json:"longitude"`
-	InstallationDate pgtype.Date `json:"installation_date"`
-	Status           string      `json:"status"`
-	TypeName         string      `json:"type_name"`
+	SensorID         int32            `json:"sensor_id"`
+	Latitude         float64          `json:"latitude"`
+	Longitude        float64          `json:"longitude"`
+	InstallationDate pgtype.Date      `json:"installation_date"`
+	Status           string           `json:"status"`
+	LastSeenAt       pgtype.Timestamp `json:"last_seen_at"`
+	TypeName         string           `json:"type_name"`
 }
 
 func (q *Queries) GetActiveSensors(ctx context.Context) ([]GetActiveSensorsRow, error) {
@@ -130,6 +133,7 @@ func (q *Queries) GetActiveSensors(ctx context.Context) ([]GetActiveSensorsRow,
 			&i.Longitude,
 			&i.InstallationDate,
 			&i.Status,
+			&i.LastSeenAt,
 			&i.TypeName,
 		); err != nil {
 			return nil, err
@@ -181,6 +185,23 @@ func (q *Queries) GetSensor(ctx context.Context, sensorID int32) (GetSensorRow,
 	return i, err
 }
 
+const getSensorState = `-- name: GetSensorState :one
+SELECT type_id, status FROM sensors
+WHERE sensor_id = $1
+`
+
+type GetSensorStateRow struct {
+	TypeID int32  `json:"type_id"`
+	Status string `json:"status"`
+}
+
+func (q *Queries) GetSensorState(ctx context.Context, sensorID int32) (GetSensorStateRow, error) {
+	row := q.db.QueryRow(ctx, getSensorState, sensorID)
+	var i GetSensorStateRow
+	err := row.Scan(&i.TypeID, &i.Status)
+	return i, err
+}
+
 const getSensorType = `-- name: GetSensorType :one
 SELECT type_id, type_name, description FROM sensor_types
 WHERE type_id = $1
@@ -239,6 +260,61 @@ func (q *Queries) GetSensorsByType(ctx context.Context, typeID int32) ([]GetSens
 	return items, nil
 }
 
+const listExistingSensorIDs = `-- name: ListExistingSensorIDs :many
+SELECT sensor_id FROM sensors
+WHERE sensor_id = ANY($1::int[])
+`
+
+func (q *Queries) ListExistingSensorIDs(ctx context.Context, sensorIds []int32) ([]int32, error) {
+	rows, err := q.db.Query(ctx, listExistingSensorIDs, sensorIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int32{}
+	for rows.Next() {
+		var sensor_id int32
+		if err := rows.Scan(&sensor_id); err != nil {
+			return nil, err
+		}
+		items = append(items, sensor_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSensorStates = `-- name: ListSensorStates :many
+SELECT sensor_id, type_id, status FROM sensors
+`
+
+type ListSensorStatesRow struct {
+	SensorID int32  `json:"sensor_id"`
+	TypeID   int32  `json:"type_id"`
+	Status   string `json:"status"`
+}
+
+func (q *Queries) ListSensorStates(ctx context.Context) ([]ListSensorStatesRow, error) {
+	rows, err := q.db.Query(ctx, listSensorStates)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSensorStatesRow{}
+	for rows.Next() {
+		var i ListSensorStatesRow
+		if err := rows.Scan(&i.SensorID, &i.TypeID, &i.Status); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listSensorTypes = `-- name: ListSensorTypes :many
 SELECT type_id, type_name, description FROM sensor_types
 ORDER BY type_name
@@ -320,7 +396,7 @@ const updateSensorStatus = `-- name: UpdateSensorStatus :one
 UPDATE sensors
 SET status = $2
 WHERE sensor_id = $1
-RETURNING sensor_id, latitude, longitude, type_id, installation_date, status
+RETURNING sensor_id, latitude, longitude, type_id, installation_date, status, last_seen_at
 `
 
 type UpdateSensorStatusParams struct {
@@ -338,6 +414,7 @@ func (q *Queries) UpdateSensorStatus(ctx context.Context, arg UpdateSensorStatus
 		&i.TypeID,
 		&i.InstallationDate,
 		&i.Status,
+		&i.LastSeenAt,
 	)
 	return i, err
 }
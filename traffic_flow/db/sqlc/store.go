@@ -1,9 +1,45 @@
 package db
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// PostgreSQL error codes for constraint violations, shared by the services
+// that map them to their own errors
+const (
+	ForeignKeyViolation = "23503"
+	UniqueViolation     = "23505"
+)
+
+var (
+	ErrUnknownSensor    = errors.New("unknown sensor_id")
+	ErrDuplicateReading = errors.New("duplicate reading for (timestamp, sensor_id)")
+)
+
+// constraintError translates constraint violations on traffic_data into the
+// store's sentinel errors and returns any other error unchanged
+func constraintError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	switch pgErr.Code {
+	case ForeignKeyViolation:
+		return ErrUnknownSensor
+	case UniqueViolation:
+		return ErrDuplicateReading
+	default:
+		return err
+	}
+}
+
 type Store struct {
 	*Queries
 	db *pgxpool.Pool
@@ -15,3 +51,525 @@ func NewStore(db *pgxpool.Pool) *Store {
 		Queries: New(db),
 	}
 }
+
+// RegisterEnumTypes loads the custom enum types into the connection's type
+// map so pgx can encode them in the binary COPY protocol. It is meant to be
+// used as pgxpool.Config.AfterConnect.
+func RegisterEnumTypes(ctx context.Context, conn *pgx.Conn) error {
+	for _, name := range []string{"congestion_level_type"} {
+		dataType, err := conn.LoadType(ctx, name)
+		if err != nil {
+			return fmt.Errorf("cannot load type %s: %w", name, err)
+		}
+		conn.TypeMap().RegisterType(dataType)
+	}
+	return nil
+}
+
+// execTx executes a function within a database transaction
+func (store *Store) execTx(ctx context.Context, fn func(*Queries) error) error {
+	tx, err := store.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	q := New(tx)
+	err = fn(q)
+	if err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// DuplicatePolicy decides what happens when a reading collides with an
+// existing (timestamp, sensor_id) primary key.
+type DuplicatePolicy string
+
+const (
+	DuplicateReject    DuplicatePolicy = "reject"
+	DuplicateOverwrite DuplicatePolicy = "overwrite"
+	DuplicateKeepFirst DuplicatePolicy = "keep_first"
+)
+
+// RowOutcome describes what happened to a single reading
+type RowOutcome string
+
+const (
+	RowCreated     RowOutcome = "created"
+	RowOverwritten RowOutcome = "overwritten"
+	RowSkipped     RowOutcome = "skipped"
+	RowRejected    RowOutcome = "rejected"
+	// RowQuarantined readings were diverted to traffic_data_quarantine
+	RowQuarantined RowOutcome = "quarantined"
+)
+
+// RecordTrafficDataWithPolicy inserts a single reading and resolves a primary
+// key collision according to policy. With DuplicateOverwrite a replaced
+// reading is reported as RowOverwritten, as in a batch; with
+// DuplicateKeepFirst the existing reading is returned together with
+// RowSkipped.
+func (store *Store) RecordTrafficDataWithPolicy(ctx context.Context, arg RecordTrafficDataParams, policy DuplicatePolicy) (TrafficDatum, RowOutcome, error) {
+	switch policy {
+	case DuplicateOverwrite:
+		row, err := store.UpsertTrafficData(ctx, UpsertTrafficDataParams(arg))
+		trafficData := TrafficDatum{
+			SensorID:                row.SensorID,
+			Timestamp:               row.Timestamp,
+			TrafficVolume:           row.TrafficVolume,
+			AverageSpeed:            row.AverageSpeed,
+			CongestionLevel:         row.CongestionLevel,
+			IsLate:                  row.IsLate,
+			ReportedCongestionLevel: row.ReportedCongestionLevel,
+		}
+		if err != nil {
+			return trafficData, RowRejected, constraintError(err)
+		}
+		if row.Overwritten {
+			return trafficData, RowOverwritten, nil
+		}
+		return trafficData, RowCreated, nil
+	case DuplicateKeepFirst:
+		trafficData, err := store.RecordTrafficDataIfAbsent(ctx, RecordTrafficDataIfAbsentParams(arg))
+		if errors.Is(err, pgx.ErrNoRows) {
+			existing, err := store.GetTrafficDatum(ctx, GetTrafficDatumParams{
+				Timestamp: arg.Timestamp,
+				SensorID:  arg.SensorID,
+			})
+			return existing, RowSkipped, err
+		}
+		if err != nil {
+			return trafficData, RowRejected, constraintError(err)
+		}
+		return trafficData, RowCreated, nil
+	default:
+		trafficData, err := store.RecordTrafficData(ctx, arg)
+		if err != nil {
+			return trafficData, RowRejected, constraintError(err)
+		}
+		return trafficData, RowCreated, nil
+	}
+}
+
+type BulkRecordTrafficDataTxParams struct {
+	Rows        []CopyTrafficDataParams
+	OnDuplicate DuplicatePolicy
+	// Quarantine rows are copied to traffic_data_quarantine in the same transaction
+	Quarantine []CopyQuarantinedTrafficDataParams
+}
+
+type BulkRecordTrafficDataTxResult struct {
+	// Outcomes and Errors are aligned with the input rows
+	Outcomes []RowOutcome
+	Errors   []error
+	Inserted []TrafficDatum
+}
+
+// BulkRecordTrafficDataTx writes a batch of readings with a single COPY.
+// Rows referencing unknown sensors are rejected individually instead of
+// failing the whole COPY. Rows colliding with an existing reading, or with
+// an earlier row of the same batch, are handled according to OnDuplicate.
+func (store *Store) BulkRecordTrafficDataTx(ctx context.Context, arg BulkRecordTrafficDataTxParams) (BulkRecordTrafficDataTxResult, error) {
+	var result BulkRecordTrafficDataTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = bulkRecordTrafficData(ctx, q, arg)
+		if err != nil {
+			return err
+		}
+
+		if len(arg.Quarantine) > 0 {
+			_, err = q.CopyQuarantinedTrafficData(ctx, arg.Quarantine)
+		}
+		return err
+	})
+
+	return result, err
+}
+
+// bulkRecordAttempts bounds how often a batch is checked and copied again
+// after a concurrent transaction committed one of its keys in between
+const bulkRecordAttempts = 3
+
+const (
+	savepointBulkRecord         = `SAVEPOINT bulk_record`
+	releaseSavepointBulkRecord  = `RELEASE SAVEPOINT bulk_record`
+	rollbackSavepointBulkRecord = `ROLLBACK TO SAVEPOINT bulk_record`
+)
+
+// bulkRecordTrafficData copies a batch inside a savepoint. A reading
+// committed by another transaction after the existing keys were listed makes
+// the COPY fail with a unique violation; the savepoint is then rolled back
+// and the batch checked again, so that the new key is handled by the
+// duplicate policy instead of failing the transaction.
+func bulkRecordTrafficData(ctx context.Context, q *Queries, arg BulkRecordTrafficDataTxParams) (BulkRecordTrafficDataTxResult, error) {
+	for attempt := 1; ; attempt++ {
+		if _, err := q.db.Exec(ctx, savepointBulkRecord); err != nil {
+			return BulkRecordTrafficDataTxResult{}, err
+		}
+
+		result, err := checkAndCopyTrafficData(ctx, q, arg)
+		if err == nil {
+			_, err = q.db.Exec(ctx, releaseSavepointBulkRecord)
+			return result, err
+		}
+
+		var pgErr *pgconn.PgError
+		if !errors.As(err, &pgErr) || pgErr.Code != UniqueViolation {
+			return result, err
+		}
+		if attempt == bulkRecordAttempts {
+			return result, ErrDuplicateReading
+		}
+		if _, err := q.db.Exec(ctx, rollbackSavepointBulkRecord); err != nil {
+			return result, err
+		}
+	}
+}
+
+func checkAndCopyTrafficData(ctx context.Context, q *Queries, arg BulkRecordTrafficDataTxParams) (BulkRecordTrafficDataTxResult, error) {
+	result := BulkRecordTrafficDataTxResult{
+		Outcomes: make([]RowOutcome, len(arg.Rows)),
+		Errors:   make([]error, len(arg.Rows)),
+		Inserted: []TrafficDatum{},
+	}
+	if len(arg.Rows) == 0 {
+		return result, nil
+	}
+
+	sensorIDs := make([]int32, 0, len(arg.Rows))
+	timestamps := make([]pgtype.Timestamp, 0, len(arg.Rows))
+	for _, row := range arg.Rows {
+		sensorIDs = append(sensorIDs, row.SensorID)
+		timestamps = append(timestamps, row.Timestamp)
+	}
+
+	existingSensors, err := q.ListExistingSensorIDs(ctx, sensorIDs)
+	if err != nil {
+		return result, err
+	}
+	knownSensors := make(map[int32]bool, len(existingSensors))
+	for _, id := range existingSensors {
+		knownSensors[id] = true
+	}
+
+	existingKeys, err := q.ListExistingTrafficKeys(ctx, ListExistingTrafficKeysParams{
+		SensorIds:  sensorIDs,
+		Timestamps: timestamps,
+	})
+	if err != nil {
+		return result, err
+	}
+	stored := make(map[trafficKey]bool, len(existingKeys))
+	for _, key := range existingKeys {
+		stored[newTrafficKey(key.Timestamp, key.SensorID)] = true
+	}
+
+	// pending maps a key to the input index of the row that will be copied
+	pending := make(map[trafficKey]int, len(arg.Rows))
+	order := make([]trafficKey, 0, len(arg.Rows))
+	for i, row := range arg.Rows {
+		if !knownSensors[row.SensorID] {
+			result.Outcomes[i], result.Errors[i] = RowRejected, ErrUnknownSensor
+			continue
+		}
+
+		key := newTrafficKey(row.Timestamp, row.SensorID)
+		prev, inBatch := pending[key]
+		if !inBatch && !stored[key] {
+			pending[key] = i
+			order = append(order, key)
+			result.Outcomes[i] = RowCreated
+			continue
+		}
+
+		switch arg.OnDuplicate {
+		case DuplicateOverwrite:
+			if inBatch {
+				// the later row wins; the earlier one never reaches the table
+				result.Outcomes[prev] = RowSkipped
+			} else {
+				order = append(order, key)
+			}
+			pending[key] = i
+			result.Outcomes[i] = RowOverwritten
+		case DuplicateKeepFirst:
+			result.Outcomes[i] = RowSkipped
+		default:
+			result.Outcomes[i], result.Errors[i] = RowRejected, ErrDuplicateReading
+		}
+	}
+
+	if len(order) == 0 {
+		return result, nil
+	}
+
+	if arg.OnDuplicate == DuplicateOverwrite {
+		deleteArg := DeleteTrafficDataByKeysParams{}
+		for _, key := range order {
+			if stored[key] {
+				row := arg.Rows[pending[key]]
+				deleteArg.Timestamps = append(deleteArg.Timestamps, row.Timestamp)
+				deleteArg.SensorIds = append(deleteArg.SensorIds, row.SensorID)
+			}
+		}
+		if len(deleteArg.SensorIds) > 0 {
+			if err := q.DeleteTrafficDataByKeys(ctx, deleteArg); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	rows := make([]CopyTrafficDataParams, 0, len(order))
+	for _, key := range order {
+		rows = append(rows, arg.Rows[pending[key]])
+	}
+
+	if _, err := q.CopyTrafficData(ctx, rows); err != nil {
+		return result, err
+	}
+
+	for _, row := range rows {
+		result.Inserted = append(result.Inserted, TrafficDatum(row))
+	}
+	return result, nil
+}
+
+type ReleaseQuarantinedTrafficTxParams struct {
+	// QuarantineIDs and SensorID select the rows to release; nil selects all
+	QuarantineIDs []int64
+	SensorID      pgtype.Int4
+	Limit         int32
+	OnDuplicate   DuplicatePolicy
+}
+
+type ReleaseQuarantinedTrafficTxResult struct {
+	// Outcomes and Errors are aligned with Rows
+	Rows     []TrafficDataQuarantine
+	Outcomes []RowOutcome
+	Errors   []error
+	Inserted []TrafficDatum
+}
+
+// ReleaseQuarantinedTrafficTx moves quarantined readings into traffic_data.
+// Rows that still cannot be stored (e.g. the sensor does not exist) stay in
+// quarantine; all others, including skipped duplicates, are removed from it.
+func (store *Store) ReleaseQuarantinedTrafficTx(ctx context.Context, arg ReleaseQuarantinedTrafficTxParams) (ReleaseQuarantinedTrafficTxResult, error) {
+	var result ReleaseQuarantinedTrafficTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Rows, err = q.LockQuarantinedTrafficData(ctx, LockQuarantinedTrafficDataParams{
+			QuarantineIds: arg.QuarantineIDs,
+			SensorID:      arg.SensorID,
+			RowLimit:      arg.Limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		rows := make([]CopyTrafficDataParams, len(result.Rows))
+		for i, row := range result.Rows {
+			rows[i] = CopyTrafficDataParams{
+				SensorID:        row.SensorID,
+				Timestamp:       row.Timestamp,
+				TrafficVolume:   row.TrafficVolume,
+				AverageSpeed:    row.AverageSpeed,
+				CongestionLevel: row.CongestionLevel,
+				IsLate:          row.IsLate,
+
+				ReportedCongestionLevel: row.ReportedCongestionLevel,
+			}
+		}
+
+		bulk, err := bulkRecordTrafficData(ctx, q, BulkRecordTrafficDataTxParams{
+			Rows:        rows,
+			OnDuplicate: arg.OnDuplicate,
+		})
+		if err != nil {
+			return err
+		}
+		result.Outcomes, result.Errors, result.Inserted = bulk.Outcomes, bulk.Errors, bulk.Inserted
+
+		released := make([]int64, 0, len(result.Rows))
+		for i, row := range result.Rows {
+			if result.Outcomes[i] != RowRejected {
+				released = append(released, row.QuarantineID)
+			}
+		}
+		if len(released) == 0 {
+			return nil
+		}
+		return q.DeleteQuarantinedTrafficDataByIDs(ctx, released)
+	})
+
+	return result, err
+}
+
+type TransitionSensorStatusTxParams struct {
+	SensorID   int32
+	FromStatus string
+	ToStatus   string
+	LastSeenAt pgtype.Timestamp
+}
+
+// TransitionSensorStatusTx changes a sensor's status and records the
+// transition. It returns pgx.ErrNoRows when the sensor no longer has
+// FromStatus, e.g. because it was updated concurrently.
+func (store *Store) TransitionSensorStatusTx(ctx context.Context, arg TransitionSensorStatusTxParams) (Sensor, SensorStatusTransition, error) {
+	var (
+		sensor     Sensor
+		transition SensorStatusTransition
+	)
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		sensor, err = q.TransitionSensorStatus(ctx, TransitionSensorStatusParams{
+			ToStatus:   arg.ToStatus,
+			SensorID:   arg.SensorID,
+			FromStatus: arg.FromStatus,
+		})
+		if err != nil {
+			return err
+		}
+
+		transition, err = q.CreateSensorStatusTransition(ctx, CreateSensorStatusTransitionParams(arg))
+		return err
+	})
+
+	return sensor, transition, err
+}
+
+type trafficKey struct {
+	timestamp int64
+	sensorID  int32
+}
+
+func newTrafficKey(timestamp pgtype.Timestamp, sensorID int32) trafficKey {
+	return trafficKey{timestamp: timestamp.Time.UnixMicro(), sensorID: sensorID}
+}
+
+// Continuous aggregates over traffic_data; the daily one is built on the
+// hourly one
+const (
+	RollupHourly = "traffic_data_hourly"
+	RollupDaily  = "traffic_data_daily"
+)
+
+const refreshRollup = `CALL refresh_continuous_aggregate($1::regclass, $2::timestamp, $3::timestamp)`
+
+// RefreshRollup materializes a continuous aggregate for the window from
+// start to end; an invalid bound leaves that side of the window open.
+// TimescaleDB refuses to refresh inside a transaction, so the call is sent
+// with the simple protocol.
+func (store *Store) RefreshRollup(ctx context.Context, view string, start, end pgtype.Timestamp) error {
+	_, err := store.db.Exec(ctx, refreshRollup, pgx.QueryExecModeSimpleProtocol, view, start, end)
+	return err
+}
+
+// SetCompressionPolicyTx replaces the compression policy of traffic_data
+// with one compressing chunks older than compressAfter and returns the id
+// of its background job
+func (store *Store) SetCompressionPolicyTx(ctx context.Context, compressAfter pgtype.Interval) (int32, error) {
+	var jobID int32
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.RemoveCompressionPolicy(ctx); err != nil {
+			return err
+		}
+		var err error
+		jobID, err = q.AddCompressionPolicy(ctx, compressAfter)
+		return err
+	})
+	return jobID, err
+}
+
+// SetRetentionPolicyTx replaces the retention policy of traffic_data with
+// one dropping chunks older than dropAfter and returns the id of its
+// background job
+func (store *Store) SetRetentionPolicyTx(ctx context.Context, dropAfter pgtype.Interval) (int32, error) {
+	var jobID int32
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.RemoveRetentionPolicy(ctx); err != nil {
+			return err
+		}
+		var err error
+		jobID, err = q.AddRetentionPolicy(ctx, dropAfter)
+		return err
+	})
+	return jobID, err
+}
+
+// CorridorSegmentParams describes one segment of a corridor; its position
+// is its index in the list
+type CorridorSegmentParams struct {
+	SensorID      int32
+	LengthM       float64
+	FreeFlowSpeed pgtype.Float8
+}
+
+func createCorridorSegments(ctx context.Context, q *Queries, corridorID int32, segments []CorridorSegmentParams) ([]CorridorSegment, error) {
+	created := make([]CorridorSegment, 0, len(segments))
+	for position, segment := range segments {
+		row, err := q.CreateCorridorSegment(ctx, CreateCorridorSegmentParams{
+			CorridorID:    corridorID,
+			Position:      int32(position),
+			SensorID:      segment.SensorID,
+			LengthM:       segment.LengthM,
+			FreeFlowSpeed: segment.FreeFlowSpeed,
+		})
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, row)
+	}
+	return created, nil
+}
+
+// CreateCorridorTx creates a corridor together with its segments
+func (store *Store) CreateCorridorTx(ctx context.Context, arg CreateCorridorParams, segments []CorridorSegmentParams) (Corridor, []CorridorSegment, error) {
+	var (
+		corridor Corridor
+		created  []CorridorSegment
+	)
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		corridor, err = q.CreateCorridor(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		created, err = createCorridorSegments(ctx, q, corridor.CorridorID, segments)
+		return err
+	})
+
+	return corridor, created, err
+}
+
+// UpdateCorridorTx updates a corridor and replaces all of its segments
+func (store *Store) UpdateCorridorTx(ctx context.Context, arg UpdateCorridorParams, segments []CorridorSegmentParams) (Corridor, []CorridorSegment, error) {
+	var (
+		corridor Corridor
+		created  []CorridorSegment
+	)
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		corridor, err = q.UpdateCorridor(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		if err := q.DeleteCorridorSegments(ctx, corridor.CorridorID); err != nil {
+			return err
+		}
+		created, err = createCorridorSegments(ctx, q, corridor.CorridorID, segments)
+		return err
+	})
+
+	return corridor, created, err
+}
@@ -11,59 +11,31 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const getDailyTrafficStats = `-- name: GetDailyTrafficStats :many
-SELECT
-  DATE_TRUNC('day', timestamp) as day,
-  sensor_id,
-  AVG(traffic_volume) as avg_volume,
-  MAX(traffic_volume) as max_volume,
-  MIN(traffic_volume) as min_volume,
-  AVG(average_speed) as avg_speed
-FROM traffic_data
-WHERE timestamp BETWEEN $1 AND $2
-GROUP BY day, sensor_id
-ORDER BY day, sensor_id
-`
-
-type GetDailyTrafficStatsParams struct {
-	Timestamp   pgtype.Timestamp `json:"timestamp"`
-	Timestamp_2 pgtype.Timestamp `json:"timestamp_2"`
+type CopyTrafficDataParams struct {
+	SensorID                int32               `json:"sensor_id"`
+	Timestamp               pgtype.Timestamp    `json:"timestamp"`
+	TrafficVolume           int32               `json:"traffic_volume"`
+	AverageSpeed            float64             `json:"average_speed"`
+	CongestionLevel         CongestionLevelType `json:"congestion_level"`
+	IsLate                  bool                `json:"is_late"`
+	ReportedCongestionLevel pgtype.Text         `json:"reported_congestion_level"`
 }
 
-type GetDailyTrafficStatsRow struct {
-	Day       pgtype.Interval `json:"day"`
-	SensorID  int32           `json:"sensor_id"`
-	AvgVolume float64         `json:"avg_volume"`
-	MaxVolume interface{}     `json:"max_volume"`
-	MinVolume interface{}     `json:"min_volume"`
-	AvgSpeed  float64         `json:"avg_speed"`
+const deleteTrafficDataByKeys = `-- name: DeleteTrafficDataByKeys :exec
+DELETE FROM traffic_data
+WHERE (timestamp, sensor_id) IN (
+  SELECT unnest($1::timestamp[]), unnest($2::int[])
+)
+`
+
+type DeleteTrafficDataByKeysParams struct {
+	Timestamps []pgtype.Timestamp `json:"timestamps"`
+	SensorIds  []int32            `json:"sensor_ids"`
 }
 
-func (q *Queries) GetDailyTrafficStats(ctx context.Context, arg GetDailyTrafficStatsParams) ([]GetDailyTrafficStatsRow, error) {
-	rows, err := q.db.Query(ctx, getDailyTrafficStats, arg.Timestamp, arg.Timestamp_2)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []GetDailyTrafficStatsRow{}
-	for rows.Next() {
-		var i GetDailyTrafficStatsRow
-		if err := rows.Scan(
-			&i.Day,
-			&i.SensorID,
-			&i.AvgVolume,
-			&i.MaxVolume,
-			&i.MinVolume,
-			&i.AvgSpeed,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+func (q *Queries) DeleteTrafficDataByKeys(ctx context.Context, arg DeleteTrafficDataByKeysParams) error {
+	_, err := q.db.Exec(ctx, deleteTrafficDataByKeys, arg.Timestamps, arg.SensorIds)
+	return err
 }
 
 const getHighCongestionAreas = `-- name: GetHighCongestionAreas :many
@@ -75,16 +47,18 @@ SELECT
 FROM traffic_data td
 JOIN sensors s ON td.sensor_id = s.sensor_id
 WHERE td.congestion_level = 'high'
-AND td.timestamp BETWEEN $1 AND $2
+AND td.timestamp >= $1::timestamp AND td.timestamp < $2::timestamp
+AND ($3::int[] IS NULL OR td.sensor_id = ANY($3::int[]))
 GROUP BY td.sensor_id, s.latitude, s.longitude
 ORDER BY high_congestion_count DESC
-LIMIT $3
+LIMIT $4
 `
 
 type GetHighCongestionAreasParams struct {
-	Timestamp   pgtype.Timestamp `json:"timestamp"`
-	Timestamp_2 pgtype.Timestamp `json:"timestamp_2"`
-	Limit       int32            `json:"limit"`
+	StartTime pgtype.Timestamp `json:"start_time"`
+	EndTime   pgtype.Timestamp `json:"end_time"`
+	SensorIds []int32          `json:"sensor_ids"`
+	RowLimit  int32            `json:"row_limit"`
 }
 
 type GetHighCongestionAreasRow struct {
@@ -95,7 +69,12 @@ type GetHighCongestionAreasRow struct {
 }
 
 func (q *Queries) GetHighCongestionAreas(ctx context.Context, arg GetHighCongestionAreasParams) ([]GetHighCongestionAreasRow, error) {
-	rows, err := q.db.Query(ctx, getHighCongestionAreas, arg.Timestamp, arg.Timestamp_2, arg.Limit)
+	rows, err := q.db.Query(ctx, getHighCongestionAreas,
+		arg.StartTime,
+		arg.EndTime,
+		arg.SensorIds,
+		arg.RowLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -121,7 +100,7 @@ func (q *Queries) GetHighCongestionAreas(ctx context.Context, arg GetHighCongest
 
 const getLatestTrafficData = `-- name: GetLatestTrafficData :many
 SELECT 
-  td.sensor_id, td.timestamp, td.traffic_volume, td.average_speed, td.congestion_level,
+  td.sensor_id, td.timestamp, td.traffic_volume, td.average_speed, td.congestion_level, td.is_late, td.reported_congestion_level,
   s.latitude,
   s.longitude
 FROM traffic_data td
@@ -132,13 +111,15 @@ LIMIT $1
 `
 
 type GetLatestTrafficDataRow struct {
-	SensorID        int32               `json:"sensor_id"`
-	Timestamp       pgtype.Timestamp    `json:"timestamp"`
-	TrafficVolume   int32               `json:"traffic_volume"`
-	AverageSpeed    float64             `json:"average_speed"`
-	CongestionLevel CongestionLevelType `json:"congestion_level"`
-	Latitude        float64             `json:"latitude"`
-	Longitude       float64             `json:"longitude"`
+	SensorID                int32               `json:"sensor_id"`
+	Timestamp               pgtype.Timestamp    `json:"timestamp"`
+	TrafficVolume           int32               `json:"traffic_volume"`
+	AverageSpeed            float64             `json:"average_speed"`
+	CongestionLevel         CongestionLevelType `json:"congestion_level"`
+	IsLate                  bool                `json:"is_late"`
+	ReportedCongestionLevel pgtype.Text         `json:"reported_congestion_level"`
+	Latitude                float64             `json:"latitude"`
+	Longitude               float64             `json:"longitude"`
 }
 
 func (q *Queries) GetLatestTrafficData(ctx context.Context, limit int32) ([]GetLatestTrafficDataRow, error) {
@@ -156,6 +137,8 @@ func (q *Queries) GetLatestTrafficData(ctx context.Context, limit int32) ([]GetL
 			&i.TrafficVolume,
 			&i.AverageSpeed,
 			&i.CongestionLevel,
+			&i.IsLate,
+			&i.ReportedCongestionLevel,
 			&i.Latitude,
 			&i.Longitude,
 		); err != nil {
@@ -175,14 +158,16 @@ SELECT
   congestion_level,
   COUNT(*) as count
 FROM traffic_data
-WHERE timestamp BETWEEN $1 AND $2
+WHERE timestamp >= $1::timestamp AND timestamp < $2::timestamp
+AND ($3::int[] IS NULL OR sensor_id = ANY($3::int[]))
 GROUP BY sensor_id, congestion_level
 ORDER BY sensor_id, congestion_level
 `
 
 type GetSensorCongestionDistributionParams struct {
-	Timestamp   pgtype.Timestamp `json:"timestamp"`
-	Timestamp_2 pgtype.Timestamp `json:"timestamp_2"`
+	StartTime pgtype.Timestamp `json:"start_time"`
+	EndTime   pgtype.Timestamp `json:"end_time"`
+	SensorIds []int32          `json:"sensor_ids"`
 }
 
 type GetSensorCongestionDistributionRow struct {
@@ -192,7 +177,7 @@ type GetSensorCongestionDistributionRow struct {
 }
 
 func (q *Queries) GetSensorCongestionDistribution(ctx context.Context, arg GetSensorCongestionDistributionParams) ([]GetSensorCongestionDistributionRow, error) {
-	rows, err := q.db.Query(ctx, getSensorCongestionDistribution, arg.Timestamp, arg.Timestamp_2)
+	rows, err := q.db.Query(ctx, getSensorCongestionDistribution, arg.StartTime, arg.EndTime, arg.SensorIds)
 	if err != nil {
 		return nil, err
 	}
@@ -211,6 +196,77 @@ func (q *Queries) GetSensorCongestionDistribution(ctx context.Context, arg GetSe
 	return items, nil
 }
 
+const getSensorTrafficSummaries = `-- name: GetSensorTrafficSummaries :many
+SELECT
+  t.sensor_id,
+  s.latitude,
+  s.longitude,
+  COUNT(*) AS readings,
+  SUM(t.traffic_volume)::bigint AS sum_volume,
+  SUM(t.average_speed)::float8 AS sum_speed,
+  COUNT(*) FILTER (WHERE t.congestion_level = 'high') AS high_count
+FROM traffic_data t
+JOIN sensors s ON t.sensor_id = s.sensor_id
+WHERE t.timestamp >= $1::timestamp AND t.timestamp < $2::timestamp
+AND point(s.longitude, s.latitude) <@ box(point($3::float8, $4::float8), point($5::float8, $6::float8))
+GROUP BY t.sensor_id, s.latitude, s.longitude
+ORDER BY t.sensor_id
+`
+
+type GetSensorTrafficSummariesParams struct {
+	StartTime pgtype.Timestamp `json:"start_time"`
+	EndTime   pgtype.Timestamp `json:"end_time"`
+	MinLon    float64          `json:"min_lon"`
+	MinLat    float64          `json:"min_lat"`
+	MaxLon    float64          `json:"max_lon"`
+	MaxLat    float64          `json:"max_lat"`
+}
+
+type GetSensorTrafficSummariesRow struct {
+	SensorID  int32   `json:"sensor_id"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Readings  int64   `json:"readings"`
+	SumVolume int64   `json:"sum_volume"`
+	SumSpeed  float64 `json:"sum_speed"`
+	HighCount int64   `json:"high_count"`
+}
+
+func (q *Queries) GetSensorTrafficSummaries(ctx context.Context, arg GetSensorTrafficSummariesParams) ([]GetSensorTrafficSummariesRow, error) {
+	rows, err := q.db.Query(ctx, getSensorTrafficSummaries,
+		arg.StartTime,
+		arg.EndTime,
+		arg.MinLon,
+		arg.MinLat,
+		arg.MaxLon,
+		arg.MaxLat,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetSensorTrafficSummariesRow{}
+	for rows.Next() {
+		var i GetSensorTrafficSummariesRow
+		if err := rows.Scan(
+			&i.SensorID,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Readings,
+			&i.SumVolume,
+			&i.SumSpeed,
+			&i.HighCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTrafficAverages = `-- name: GetTrafficAverages :one
 SELECT 
   AVG(traffic_volume) as avg_volume,
@@ -218,7 +274,7 @@ SELECT
   sensor_id
 FROM traffic_data
 WHERE sensor_id = $1
-AND timestamp BETWEEN $2 AND $3
+AND timestamp >= $2 AND timestamp < $3
 GROUP BY sensor_id
 `
 
@@ -242,7 +298,7 @@ func (q *Queries) GetTrafficAverages(ctx context.Context, arg GetTrafficAverages
 }
 
 const getTrafficDataBySensor = `-- name: GetTrafficDataBySensor :many
-SELECT sensor_id, timestamp, traffic_volume, average_speed, congestion_level FROM traffic_data
+SELECT sensor_id, timestamp, traffic_volume, average_speed, congestion_level, is_late, reported_congestion_level FROM traffic_data
 WHERE sensor_id = $1
 AND timestamp BETWEEN $2 AND $3
 ORDER BY timestamp DESC
@@ -269,6 +325,8 @@ func (q *Queries) GetTrafficDataBySensor(ctx context.Context, arg GetTrafficData
 			&i.TrafficVolume,
 			&i.AverageSpeed,
 			&i.CongestionLevel,
+			&i.IsLate,
+			&i.ReportedCongestionLevel,
 		); err != nil {
 			return nil, err
 		}
@@ -280,24 +338,335 @@ func (q *Queries) GetTrafficDataBySensor(ctx context.Context, arg GetTrafficData
 	return items, nil
 }
 
+const getTrafficDatum = `-- name: GetTrafficDatum :one
+SELECT sensor_id, timestamp, traffic_volume, average_speed, congestion_level, is_late, reported_congestion_level FROM traffic_data
+WHERE timestamp = $1 AND sensor_id = $2
+`
+
+type GetTrafficDatumParams struct {
+	Timestamp pgtype.Timestamp `json:"timestamp"`
+	SensorID  int32            `json:"sensor_id"`
+}
+
+func (q *Queries) GetTrafficDatum(ctx context.Context, arg GetTrafficDatumParams) (TrafficDatum, error) {
+	row := q.db.QueryRow(ctx, getTrafficDatum, arg.Timestamp, arg.SensorID)
+	var i TrafficDatum
+	err := row.Scan(
+		&i.SensorID,
+		&i.Timestamp,
+		&i.TrafficVolume,
+		&i.AverageSpeed,
+		&i.CongestionLevel,
+		&i.IsLate,
+		&i.ReportedCongestionLevel,
+	)
+	return i, err
+}
+
+const getTrafficSeries = `-- name: GetTrafficSeries :many
+SELECT
+  time_bucket($1::interval, timestamp)::timestamp AS bucket,
+  sensor_id,
+  COUNT(*) AS readings,
+  AVG(traffic_volume)::float8 AS avg_volume,
+  MIN(traffic_volume)::int AS min_volume,
+  MAX(traffic_volume)::int AS max_volume,
+  SUM(traffic_volume)::bigint AS sum_volume,
+  AVG(average_speed)::float8 AS avg_speed,
+  MIN(average_speed)::float8 AS min_speed,
+  MAX(average_speed)::float8 AS max_speed,
+  SUM(average_speed)::float8 AS sum_speed
+FROM traffic_data
+WHERE timestamp >= $2::timestamp AND timestamp < $3::timestamp
+AND ($4::int[] IS NULL OR sensor_id = ANY($4::int[]))
+GROUP BY bucket, sensor_id
+ORDER BY sensor_id, bucket
+`
+
+type GetTrafficSeriesParams struct {
+	BucketWidth pgtype.Interval  `json:"bucket_width"`
+	StartTime   pgtype.Timestamp `json:"start_time"`
+	EndTime     pgtype.Timestamp `json:"end_time"`
+	SensorIds   []int32          `json:"sensor_ids"`
+}
+
+type GetTrafficSeriesRow struct {
+	Bucket    pgtype.Timestamp `json:"bucket"`
+	SensorID  int32            `json:"sensor_id"`
+	Readings  int64            `json:"readings"`
+	AvgVolume float64          `json:"avg_volume"`
+	MinVolume int32            `json:"min_volume"`
+	MaxVolume int32            `json:"max_volume"`
+	SumVolume int64            `json:"sum_volume"`
+	AvgSpeed  float64          `json:"avg_speed"`
+	MinSpeed  float64          `json:"min_speed"`
+	MaxSpeed  float64          `json:"max_speed"`
+	SumSpeed  float64          `json:"sum_speed"`
+}
+
+func (q *Queries) GetTrafficSeries(ctx context.Context, arg GetTrafficSeriesParams) ([]GetTrafficSeriesRow, error) {
+	rows, err := q.db.Query(ctx, getTrafficSeries,
+		arg.BucketWidth,
+		arg.StartTime,
+		arg.EndTime,
+		arg.SensorIds,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTrafficSeriesRow{}
+	for rows.Next() {
+		var i GetTrafficSeriesRow
+		if err := rows.Scan(
+			&i.Bucket,
+			&i.SensorID,
+			&i.Readings,
+			&i.AvgVolume,
+			&i.MinVolume,
+			&i.MaxVolume,
+			&i.SumVolume,
+			&i.AvgSpeed,
+			&i.MinSpeed,
+			&i.MaxSpeed,
+			&i.SumSpeed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTrafficSeriesGapfill = `-- name: GetTrafficSeriesGapfill :many
+WITH gapfilled AS (
+  SELECT
+    time_bucket_gapfill($1::interval, timestamp, $2::timestamp, $3::timestamp) AS bucket,
+    sensor_id,
+    COUNT(*) AS readings,
+    AVG(traffic_volume)::float8 AS avg_volume,
+    locf(AVG(traffic_volume)::float8)::float8 AS avg_volume_locf,
+    interpolate(AVG(traffic_volume)::float8)::float8 AS avg_volume_interpolated,
+    MIN(traffic_volume)::int AS min_volume,
+    locf(MIN(traffic_volume)::int)::int AS min_volume_locf,
+    interpolate(MIN(traffic_volume)::int)::int AS min_volume_interpolated,
+    MAX(traffic_volume)::int AS max_volume,
+    locf(MAX(traffic_volume)::int)::int AS max_volume_locf,
+    interpolate(MAX(traffic_volume)::int)::int AS max_volume_interpolated,
+    SUM(traffic_volume)::bigint AS sum_volume,
+    locf(SUM(traffic_volume)::bigint)::bigint AS sum_volume_locf,
+    interpolate(SUM(traffic_volume)::bigint)::bigint AS sum_volume_interpolated,
+    AVG(average_speed)::float8 AS avg_speed,
+    locf(AVG(average_speed)::float8)::float8 AS avg_speed_locf,
+    interpolate(AVG(average_speed)::float8)::float8 AS avg_speed_interpolated,
+    MIN(average_speed)::float8 AS min_speed,
+    locf(MIN(average_speed)::float8)::float8 AS min_speed_locf,
+    interpolate(MIN(average_speed)::float8)::float8 AS min_speed_interpolated,
+    MAX(average_speed)::float8 AS max_speed,
+    locf(MAX(average_speed)::float8)::float8 AS max_speed_locf,
+    interpolate(MAX(average_speed)::float8)::float8 AS max_speed_interpolated,
+    SUM(average_speed)::float8 AS sum_speed,
+    locf(SUM(average_speed)::float8)::float8 AS sum_speed_locf,
+    interpolate(SUM(average_speed)::float8)::float8 AS sum_speed_interpolated
+  FROM traffic_data
+  WHERE timestamp >= $2::timestamp AND timestamp < $3::timestamp
+  AND ($4::int[] IS NULL OR sensor_id = ANY($4::int[]))
+  GROUP BY 1, 2
+)
+SELECT
+  b.bucket::timestamp AS bucket,
+  s.sensor_id,
+  COALESCE(g.readings, 0)::bigint AS readings,
+  g.avg_volume,
+  g.avg_volume_locf,
+  g.avg_volume_interpolated,
+  g.min_volume,
+  g.min_volume_locf,
+  g.min_volume_interpolated,
+  g.max_volume,
+  g.max_volume_locf,
+  g.max_volume_interpolated,
+  g.sum_volume,
+  g.sum_volume_locf,
+  g.sum_volume_interpolated,
+  g.avg_speed,
+  g.avg_speed_locf,
+  g.avg_speed_interpolated,
+  g.min_speed,
+  g.min_speed_locf,
+  g.min_speed_interpolated,
+  g.max_speed,
+  g.max_speed_locf,
+  g.max_speed_interpolated,
+  g.sum_speed,
+  g.sum_speed_locf,
+  g.sum_speed_interpolated
+FROM sensors s
+CROSS JOIN generate_series(time_bucket($1::interval, $2::timestamp), $3::timestamp, $1::interval) AS b(bucket)
+LEFT JOIN gapfilled g ON g.bucket = b.bucket AND g.sensor_id = s.sensor_id
+WHERE b.bucket < $3::timestamp
+AND ($4::int[] IS NULL OR s.sensor_id = ANY($4::int[]))
+ORDER BY s.sensor_id, b.bucket
+`
+
+type GetTrafficSeriesGapfillParams struct {
+	BucketWidth pgtype.Interval  `json:"bucket_width"`
+	StartTime   pgtype.Timestamp `json:"start_time"`
+	EndTime     pgtype.Timestamp `json:"end_time"`
+	SensorIds   []int32          `json:"sensor_ids"`
+}
+
+type GetTrafficSeriesGapfillRow struct {
+	Bucket                pgtype.Timestamp `json:"bucket"`
+	SensorID              int32            `json:"sensor_id"`
+	Readings              int64            `json:"readings"`
+	AvgVolume             pgtype.Float8    `json:"avg_volume"`
+	AvgVolumeLocf         pgtype.Float8    `json:"avg_volume_locf"`
+	AvgVolumeInterpolated pgtype.Float8    `json:"avg_volume_interpolated"`
+	MinVolume             pgtype.Int4      `json:"min_volume"`
+	MinVolumeLocf         pgtype.Int4      `json:"min_volume_locf"`
+	MinVolumeInterpolated pgtype.Int4      `json:"min_volume_interpolated"`
+	MaxVolume             pgtype.Int4      `json:"max_volume"`
+	MaxVolumeLocf         pgtype.Int4      `json:"max_volume_locf"`
+	MaxVolumeInterpolated pgtype.Int4      `json:"max_volume_interpolated"`
+	SumVolume             pgtype.Int8      `json:"sum_volume"`
+	SumVolumeLocf         pgtype.Int8      `json:"sum_volume_locf"`
+	SumVolumeInterpolated pgtype.Int8      `json:"sum_volume_interpolated"`
+	AvgSpeed              pgtype.Float8    `json:"avg_speed"`
+	AvgSpeedLocf          pgtype.Float8    `json:"avg_speed_locf"`
+	AvgSpeedInterpolated  pgtype.Float8    `json:"avg_speed_interpolated"`
+	MinSpeed              pgtype.Float8    `json:"min_speed"`
+	MinSpeedLocf          pgtype.Float8    `json:"min_speed_locf"`
+	MinSpeedInterpolated  pgtype.Float8    `json:"min_speed_interpolated"`
+	MaxSpeed              pgtype.Float8    `json:"max_speed"`
+	MaxSpeedLocf          pgtype.Float8    `json:"max_speed_locf"`
+	MaxSpeedInterpolated  pgtype.Float8    `json:"max_speed_interpolated"`
+	SumSpeed              pgtype.Float8    `json:"sum_speed"`
+	SumSpeedLocf          pgtype.Float8    `json:"sum_speed_locf"`
+	SumSpeedInterpolated  pgtype.Float8    `json:"sum_speed_interpolated"`
+}
+
+// GetTrafficSeries with a row for every bucket from start_time to end_time
+// and every sensor asked for, or every sensor without sensor_ids, whether it
+// reported or not. Buckets without readings have 0 readings and null
+// aggregates, which the _locf and _interpolated columns fill with TimescaleDB's
+// locf() and interpolate(). Both leave the buckets before a sensor's first
+// reading null, and interpolate() also those after its last. Sensors without
+// any reading in the range come from the bucket grid alone, with no values.
+func (q *Queries) GetTrafficSeriesGapfill(ctx context.Context, arg GetTrafficSeriesGapfillParams) ([]GetTrafficSeriesGapfillRow, error) {
+	rows, err := q.db.Query(ctx, getTrafficSeriesGapfill,
+		arg.BucketWidth,
+		arg.StartTime,
+		arg.EndTime,
+		arg.SensorIds,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTrafficSeriesGapfillRow{}
+	for rows.Next() {
+		var i GetTrafficSeriesGapfillRow
+		if err := rows.Scan(
+			&i.Bucket,
+			&i.SensorID,
+			&i.Readings,
+			&i.AvgVolume,
+			&i.AvgVolumeLocf,
+			&i.AvgVolumeInterpolated,
+			&i.MinVolume,
+			&i.MinVolumeLocf,
+			&i.MinVolumeInterpolated,
+			&i.MaxVolume,
+			&i.MaxVolumeLocf,
+			&i.MaxVolumeInterpolated,
+			&i.SumVolume,
+			&i.SumVolumeLocf,
+			&i.SumVolumeInterpolated,
+			&i.AvgSpeed,
+			&i.AvgSpeedLocf,
+			&i.AvgSpeedInterpolated,
+			&i.MinSpeed,
+			&i.MinSpeedLocf,
+			&i.MinSpeedInterpolated,
+			&i.MaxSpeed,
+			&i.MaxSpeedLocf,
+			&i.MaxSpeedInterpolated,
+			&i.SumSpeed,
+			&i.SumSpeedLocf,
+			&i.SumSpeedInterpolated,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExistingTrafficKeys = `-- name: ListExistingTrafficKeys :many
+SELECT timestamp, sensor_id FROM traffic_data
+WHERE sensor_id = ANY($1::int[])
+AND timestamp = ANY($2::timestamp[])
+`
+
+type ListExistingTrafficKeysParams struct {
+	SensorIds  []int32            `json:"sensor_ids"`
+	Timestamps []pgtype.Timestamp `json:"timestamps"`
+}
+
+type ListExistingTrafficKeysRow struct {
+	Timestamp pgtype.Timestamp `json:"timestamp"`
+	SensorID  int32            `json:"sensor_id"`
+}
+
+func (q *Queries) ListExistingTrafficKeys(ctx context.Context, arg ListExistingTrafficKeysParams) ([]ListExistingTrafficKeysRow, error) {
+	rows, err := q.db.Query(ctx, listExistingTrafficKeys, arg.SensorIds, arg.Timestamps)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListExistingTrafficKeysRow{}
+	for rows.Next() {
+		var i ListExistingTrafficKeysRow
+		if err := rows.Scan(&i.Timestamp, &i.SensorID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const recordTrafficData = `-- name: RecordTrafficData :one
 INSERT INTO traffic_data (
   sensor_id,
   timestamp,
   traffic_volume,
   average_speed,
-  congestion_level
+  congestion_level,
+  is_late,
+  reported_congestion_level
 ) VALUES (
-  $1, $2, $3, $4, $5
-) RETURNING sensor_id, timestamp, traffic_volume, average_speed, congestion_level
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING sensor_id, timestamp, traffic_volume, average_speed, congestion_level, is_late, reported_congestion_level
 `
 
 type RecordTrafficDataParams struct {
-	SensorID        int32               `json:"sensor_id"`
-	Timestamp       pgtype.Timestamp    `json:"timestamp"`
-	TrafficVolume   int32               `json:"traffic_volume"`
-	AverageSpeed    float64             `json:"average_speed"`
-	CongestionLevel CongestionLevelType `json:"congestion_level"`
+	SensorID                int32               `json:"sensor_id"`
+	Timestamp               pgtype.Timestamp    `json:"timestamp"`
+	TrafficVolume           int32               `json:"traffic_volume"`
+	AverageSpeed            float64             `json:"average_speed"`
+	CongestionLevel         CongestionLevelType `json:"congestion_level"`
+	IsLate                  bool                `json:"is_late"`
+	ReportedCongestionLevel pgtype.Text         `json:"reported_congestion_level"`
 }
 
 func (q *Queries) RecordTrafficData(ctx context.Context, arg RecordTrafficDataParams) (TrafficDatum, error) {
@@ -307,6 +676,8 @@ func (q *Queries) RecordTrafficData(ctx context.Context, arg RecordTrafficDataPa
 		arg.TrafficVolume,
 		arg.AverageSpeed,
 		arg.CongestionLevel,
+		arg.IsLate,
+		arg.ReportedCongestionLevel,
 	)
 	var i TrafficDatum
 	err := row.Scan(
@@ -315,6 +686,125 @@ func (q *Queries) RecordTrafficData(ctx context.Context, arg RecordTrafficDataPa
 		&i.TrafficVolume,
 		&i.AverageSpeed,
 		&i.CongestionLevel,
+		&i.IsLate,
+		&i.ReportedCongestionLevel,
+	)
+	return i, err
+}
+
+const recordTrafficDataIfAbsent = `-- name: RecordTrafficDataIfAbsent :one
+INSERT INTO traffic_data (
+  sensor_id,
+  timestamp,
+  traffic_volume,
+  average_speed,
+  congestion_level,
+  is_late,
+  reported_congestion_level
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (timestamp, sensor_id) DO NOTHING
+RETURNING sensor_id, timestamp, traffic_volume, average_speed, congestion_level, is_late, reported_congestion_level
+`
+
+type RecordTrafficDataIfAbsentParams struct {
+	SensorID                int32               `json:"sensor_id"`
+	Timestamp               pgtype.Timestamp    `json:"timestamp"`
+	TrafficVolume           int32               `json:"traffic_volume"`
+	AverageSpeed            float64             `json:"average_speed"`
+	CongestionLevel         CongestionLevelType `json:"congestion_level"`
+	IsLate                  bool                `json:"is_late"`
+	ReportedCongestionLevel pgtype.Text         `json:"reported_congestion_level"`
+}
+
+func (q *Queries) RecordTrafficDataIfAbsent(ctx context.Context, arg RecordTrafficDataIfAbsentParams) (TrafficDatum, error) {
+	row := q.db.QueryRow(ctx, recordTrafficDataIfAbsent,
+		arg.SensorID,
+		arg.Timestamp,
+		arg.TrafficVolume,
+		arg.AverageSpeed,
+		arg.CongestionLevel,
+		arg.IsLate,
+		arg.ReportedCongestionLevel,
+	)
+	var i TrafficDatum
+	err := row.Scan(
+		&i.SensorID,
+		&i.Timestamp,
+		&i.TrafficVolume,
+		&i.AverageSpeed,
+		&i.CongestionLevel,
+		&i.IsLate,
+		&i.ReportedCongestionLevel,
+	)
+	return i, err
+}
+
+const upsertTrafficData = `-- name: UpsertTrafficData :one
+INSERT INTO traffic_data (
+  sensor_id,
+  timestamp,
+  traffic_volume,
+  average_speed,
+  congestion_level,
+  is_late,
+  reported_congestion_level
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (timestamp, sensor_id) DO UPDATE
+SET traffic_volume = EXCLUDED.traffic_volume,
+    average_speed = EXCLUDED.average_speed,
+    congestion_level = EXCLUDED.congestion_level,
+    is_late = EXCLUDED.is_late,
+    reported_congestion_level = EXCLUDED.reported_congestion_level
+RETURNING sensor_id, timestamp, traffic_volume, average_speed, congestion_level, is_late, reported_congestion_level, (xmax <> 0)::bool AS overwritten
+`
+
+type UpsertTrafficDataParams struct {
+	SensorID                int32               `json:"sensor_id"`
+	Timestamp               pgtype.Timestamp    `json:"timestamp"`
+	TrafficVolume           int32               `json:"traffic_volume"`
+	AverageSpeed            float64             `json:"average_speed"`
+	CongestionLevel         CongestionLevelType `json:"congestion_level"`
+	IsLate                  bool                `json:"is_late"`
+	ReportedCongestionLevel pgtype.Text         `json:"reported_congestion_level"`
+}
+
+type UpsertTrafficDataRow struct {
+	SensorID                int32               `json:"sensor_id"`
+	Timestamp               pgtype.Timestamp    `json:"timestamp"`
+	TrafficVolume           int32               `json:"traffic_volume"`
+	AverageSpeed            float64             `json:"average_speed"`
+	CongestionLevel         CongestionLevelType `json:"congestion_level"`
+	IsLate                  bool                `json:"is_late"`
+	ReportedCongestionLevel pgtype.Text         `json:"reported_congestion_level"`
+	Overwritten             bool                `json:"overwritten"`
+}
+
+// overwritten is true when an existing reading was updated; xmax is only
+// set on a row version that replaced another one
+func (q *Queries) UpsertTrafficData(ctx context.Context, arg UpsertTrafficDataParams) (UpsertTrafficDataRow, error) {
+	row := q.db.QueryRow(ctx, upsertTrafficData,
+		arg.SensorID,
+		arg.Timestamp,
+		arg.TrafficVolume,
+		arg.AverageSpeed,
+		arg.CongestionLevel,
+		arg.IsLate,
+		arg.ReportedCongestionLevel,
+	)
+	var i UpsertTrafficDataRow
+	err := row.Scan(
+		&i.SensorID,
+		&i.Timestamp,
+		&i.TrafficVolume,
+		&i.AverageSpeed,
+		&i.CongestionLevel,
+		&i.IsLate,
+		&i.ReportedCongestionLevel,
+		&i.Overwritten,
 	)
 	return i, err
 }
@@ -0,0 +1,57 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePercentiles(t *testing.T) {
+	percentiles, err := ParsePercentiles(nil)
+	require.NoError(t, err)
+	require.Equal(t, DefaultPercentiles, percentiles)
+
+	percentiles, err = ParsePercentiles([]string{"95,p50", "85", "50", "99.9"})
+	require.NoError(t, err)
+	require.Equal(t, []float64{50, 85, 95, 99.9}, percentiles)
+	require.InDeltaSlice(t, []float64{0.5, 0.85, 0.95, 0.999}, Fractions(percentiles), 1e-9)
+
+	for _, value := range []string{"0", "100", "-5", "median", "NaN", "pnan", "Inf"} {
+		_, err = ParsePercentiles([]string{value})
+		require.ErrorIs(t, err, ErrInvalidPercentile, value)
+	}
+}
+
+func TestNamed(t *testing.T) {
+	named := Named([]float64{50, 99.9}, []float64{42, 97.5})
+	require.Equal(t, map[string]float64{"p50": 42, "p99.9": 97.5}, named)
+	require.Empty(t, Named([]float64{50}, nil))
+}
+
+func TestBuild(t *testing.T) {
+	bins, err := Build([]Count{{Bin: 2, Readings: 1}, {Bin: 4, Readings: 3}}, 5)
+	require.NoError(t, err)
+	require.Equal(t, []Bin{
+		{Start: 10, End: 15, Readings: 1, Share: 0.25},
+		{Start: 15, End: 20, Readings: 0, Share: 0},
+		{Start: 20, End: 25, Readings: 3, Share: 0.75},
+	}, bins)
+
+	bins, err = Build(nil, 5)
+	require.NoError(t, err)
+	require.Empty(t, bins)
+
+	_, err = Build([]Count{{Bin: 0, Readings: 1}, {Bin: MaxBins, Readings: 1}}, 1)
+	require.ErrorIs(t, err, ErrTooManyBins)
+
+	_, err = Build(nil, 0)
+	require.ErrorIs(t, err, ErrInvalidBinWidth)
+}
+
+func TestCheckRange(t *testing.T) {
+	require.NoError(t, CheckRange(0, 140, 5))
+	require.NoError(t, CheckRange(0, MaxBins-0.5, 1))
+	require.ErrorIs(t, CheckRange(0, MaxBins, 1), ErrTooManyBins)
+	require.ErrorIs(t, CheckRange(0, 140, 1e-9), ErrTooManyBins)
+	require.ErrorIs(t, CheckRange(0, 140, 0), ErrInvalidBinWidth)
+}
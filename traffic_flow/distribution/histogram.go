@@ -0,0 +1,110 @@
+package distribution
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	ErrInvalidMetric   = errors.New("invalid metric")
+	ErrInvalidBinWidth = errors.New("invalid bin width")
+	ErrTooManyBins     = errors.New("histogram holds too many bins")
+)
+
+// MaxBins caps the number of bins of a histogram
+const MaxBins = 1000
+
+// Metric is the column a histogram is built over
+type Metric string
+
+const (
+	MetricSpeed  Metric = "speed"
+	MetricVolume Metric = "volume"
+)
+
+func ParseMetric(value string) (Metric, error) {
+	switch metric := Metric(value); metric {
+	case MetricSpeed, MetricVolume:
+		return metric, nil
+	default:
+		return "", fmt.Errorf("%w %q, must be speed or volume", ErrInvalidMetric, value)
+	}
+}
+
+// DefaultBinWidth is used when a request gives none
+func (metric Metric) DefaultBinWidth() float64 {
+	if metric == MetricVolume {
+		return 10
+	}
+	return 5
+}
+
+// Bin counts the readings from Start up to but excluding End
+type Bin struct {
+	Start    float64 `json:"start"`
+	End      float64 `json:"end"`
+	Readings int64   `json:"readings"`
+	// Share is the fraction of all readings that fell into the bin
+	Share float64 `json:"share"`
+}
+
+// Count is the number of readings in bin number Bin, the one starting at
+// Bin times the bin width
+type Count struct {
+	Bin      int64
+	Readings int64
+}
+
+// CheckRange fails with ErrTooManyBins when binning values from low to high
+// would take more than MaxBins bins. It lets a histogram be refused before
+// its readings are counted.
+func CheckRange(low, high, width float64) error {
+	if width <= 0 {
+		return fmt.Errorf("%w %v, must be positive", ErrInvalidBinWidth, width)
+	}
+	bins := math.Floor(high/width) - math.Floor(low/width) + 1
+	if bins > MaxBins {
+		return fmt.Errorf("%w, %.0f bins of width %v exceed %d", ErrTooManyBins, bins, width, MaxBins)
+	}
+	return nil
+}
+
+// Build turns counts ordered by bin into a histogram without holes: bins
+// between the lowest and highest occupied one are included with zero
+// readings
+func Build(counts []Count, width float64) ([]Bin, error) {
+	if width <= 0 {
+		return nil, fmt.Errorf("%w %v, must be positive", ErrInvalidBinWidth, width)
+	}
+	bins := []Bin{}
+	if len(counts) == 0 {
+		return bins, nil
+	}
+
+	first, last := counts[0].Bin, counts[len(counts)-1].Bin
+	if last-first+1 > MaxBins {
+		return nil, fmt.Errorf("%w, %d bins of width %v exceed %d", ErrTooManyBins, last-first+1, width, MaxBins)
+	}
+
+	total := int64(0)
+	for _, count := range counts {
+		total += count.Readings
+	}
+
+	next := 0
+	for bin := first; bin <= last; bin++ {
+		readings := int64(0)
+		if next < len(counts) && counts[next].Bin == bin {
+			readings = counts[next].Readings
+			next++
+		}
+		bins = append(bins, Bin{
+			Start:    float64(bin) * width,
+			End:      float64(bin+1) * width,
+			Readings: readings,
+			Share:    float64(readings) / float64(total),
+		})
+	}
+	return bins, nil
+}
@@ -0,0 +1,73 @@
+package distribution
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidPercentile = errors.New("invalid percentile")
+
+// DefaultPercentiles are used when a request names none. p85 is the
+// traffic engineering design speed.
+var DefaultPercentiles = []float64{50, 85, 95}
+
+// ParsePercentiles reads percentiles such as "85" or "p85" given as
+// repeated and/or comma separated values, sorted and without duplicates
+func ParsePercentiles(values []string) ([]float64, error) {
+	percentiles := []float64{}
+	seen := make(map[float64]bool)
+	for _, value := range values {
+		for _, field := range strings.Split(value, ",") {
+			field = strings.TrimPrefix(strings.TrimSpace(field), "p")
+			if field == "" {
+				continue
+			}
+			percentile, err := strconv.ParseFloat(field, 64)
+			// NaN compares false with every bound, so it is ruled out by name
+			if err != nil || math.IsNaN(percentile) || percentile <= 0 || percentile >= 100 {
+				return nil, fmt.Errorf("%w %q, must be between 0 and 100", ErrInvalidPercentile, field)
+			}
+			if seen[percentile] {
+				continue
+			}
+			seen[percentile] = true
+			percentiles = append(percentiles, percentile)
+		}
+	}
+
+	if len(percentiles) == 0 {
+		return DefaultPercentiles, nil
+	}
+	sort.Float64s(percentiles)
+	return percentiles, nil
+}
+
+// Label names a percentile the way responses key it, e.g. "p85" or "p99.9"
+func Label(percentile float64) string {
+	return "p" + strconv.FormatFloat(percentile, 'f', -1, 64)
+}
+
+// Fractions converts percentiles to the fractions percentile_cont takes
+func Fractions(percentiles []float64) []float64 {
+	fractions := make([]float64, len(percentiles))
+	for i, percentile := range percentiles {
+		fractions[i] = percentile / 100
+	}
+	return fractions
+}
+
+// Named keys the values percentile_cont returned by their percentile's
+// label; without readings there are no values and the map is empty
+func Named(percentiles, values []float64) map[string]float64 {
+	named := make(map[string]float64, len(values))
+	for i, value := range values {
+		if i < len(percentiles) {
+			named[Label(percentiles[i])] = value
+		}
+	}
+	return named
+}
@@ -0,0 +1,134 @@
+package episode
+
+import (
+	db "smart_city/traffic_flow/db/sqlc"
+	"sort"
+	"time"
+)
+
+// Episode is a stretch of uninterrupted high congestion at one sensor.
+// It ends at its last high reading, so a single reading lasts 0 seconds.
+type Episode struct {
+	SensorID        int32     `json:"sensor_id"`
+	StartTime       time.Time `json:"start_time"`
+	EndTime         time.Time `json:"end_time"`
+	DurationSeconds float64   `json:"duration_seconds"`
+	Readings        int64     `json:"readings"`
+	SumVolume       int64     `json:"sum_volume"`
+	AvgSpeed        float64   `json:"avg_speed"`
+	MinSpeed        float64   `json:"min_speed"`
+	// ReferenceSpeed is the sensor's average speed outside high
+	// congestion over the same range, nil when it had no such reading
+	ReferenceSpeed *float64 `json:"reference_speed"`
+	// Severity is the share of the reference speed lost, from 0 to 1
+	Severity *float64 `json:"severity"`
+}
+
+func (episode Episode) Duration() time.Duration {
+	return episode.EndTime.Sub(episode.StartTime)
+}
+
+// FromRows converts query rows, keeping episodes lasting at least
+// minDuration
+func FromRows(rows []db.ListCongestionEpisodesRow, minDuration time.Duration) []Episode {
+	episodes := []Episode{}
+	for _, row := range rows {
+		episode := Episode{
+			SensorID:  row.SensorID,
+			StartTime: row.StartTime.Time,
+			EndTime:   row.EndTime.Time,
+			Readings:  row.Readings,
+			SumVolume: row.SumVolume,
+			AvgSpeed:  row.AvgSpeed,
+			MinSpeed:  row.MinSpeed,
+		}
+		if episode.Duration() < minDuration {
+			continue
+		}
+		episode.DurationSeconds = episode.Duration().Seconds()
+
+		if row.ReferenceSpeed > 0 {
+			reference := row.ReferenceSpeed
+			severity := min(max(1-row.AvgSpeed/reference, 0), 1)
+			episode.ReferenceSpeed = &reference
+			episode.Severity = &severity
+		}
+		episodes = append(episodes, episode)
+	}
+	return episodes
+}
+
+// SensorEpisodes counts the episodes of one sensor
+type SensorEpisodes struct {
+	SensorID             int32   `json:"sensor_id"`
+	Episodes             int     `json:"episodes"`
+	TotalDurationSeconds float64 `json:"total_duration_seconds"`
+	LongestSeconds       float64 `json:"longest_seconds"`
+	// AvgSeverity is over the episodes with a severity
+	AvgSeverity *float64 `json:"avg_severity"`
+}
+
+// Summary is the city-wide view of the episodes in a range
+type Summary struct {
+	Episodes             int     `json:"episodes"`
+	Sensors              int     `json:"sensors"`
+	TotalDurationSeconds float64 `json:"total_duration_seconds"`
+	// Longest are the longest episodes, longest first
+	Longest []Episode `json:"longest"`
+	// MostFrequent are the sensors with the most episodes, most first
+	MostFrequent []SensorEpisodes `json:"most_frequent"`
+}
+
+// Summarize ranks the longest episodes and the sensors with the most
+// episodes, keeping limit of each
+func Summarize(episodes []Episode, limit int) Summary {
+	summary := Summary{Episodes: len(episodes)}
+
+	bySensor := make(map[int32]*SensorEpisodes)
+	severities := make(map[int32][]float64)
+	for _, episode := range episodes {
+		sensor, ok := bySensor[episode.SensorID]
+		if !ok {
+			sensor = &SensorEpisodes{SensorID: episode.SensorID}
+			bySensor[episode.SensorID] = sensor
+		}
+		sensor.Episodes++
+		sensor.TotalDurationSeconds += episode.DurationSeconds
+		sensor.LongestSeconds = max(sensor.LongestSeconds, episode.DurationSeconds)
+		if episode.Severity != nil {
+			severities[episode.SensorID] = append(severities[episode.SensorID], *episode.Severity)
+		}
+		summary.TotalDurationSeconds += episode.DurationSeconds
+	}
+	summary.Sensors = len(bySensor)
+
+	longest := append([]Episode(nil), episodes...)
+	sort.SliceStable(longest, func(i, j int) bool {
+		return longest[i].DurationSeconds > longest[j].DurationSeconds
+	})
+	summary.Longest = longest[:min(limit, len(longest))]
+
+	frequent := make([]SensorEpisodes, 0, len(bySensor))
+	for sensorID, sensor := range bySensor {
+		if values := severities[sensorID]; len(values) > 0 {
+			total := 0.0
+			for _, value := range values {
+				total += value
+			}
+			average := total / float64(len(values))
+			sensor.AvgSeverity = &average
+		}
+		frequent = append(frequent, *sensor)
+	}
+	sort.Slice(frequent, func(i, j int) bool {
+		if frequent[i].Episodes != frequent[j].Episodes {
+			return frequent[i].Episodes > frequent[j].Episodes
+		}
+		if frequent[i].TotalDurationSeconds != frequent[j].TotalDurationSeconds {
+			return frequent[i].TotalDurationSeconds > frequent[j].TotalDurationSeconds
+		}
+		return frequent[i].SensorID < frequent[j].SensorID
+	})
+	summary.MostFrequent = frequent[:min(limit, len(frequent))]
+	return summary
+}
@@ -0,0 +1,74 @@
+package episode
+
+import (
+	db "smart_city/traffic_flow/db/sqlc"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func episodeRow(sensorID int32, start time.Time, duration time.Duration, avgSpeed float64, reference *float64) db.ListCongestionEpisodesRow {
+	row := db.ListCongestionEpisodesRow{
+		SensorID:  sensorID,
+		StartTime: pgtype.Timestamp{Time: start, Valid: true},
+		EndTime:   pgtype.Timestamp{Time: start.Add(duration), Valid: true},
+		Readings:  int64(duration/time.Minute) + 1,
+		AvgSpeed:  avgSpeed,
+		MinSpeed:  avgSpeed,
+	}
+	if reference != nil {
+		row.ReferenceSpeed = *reference
+	}
+	return row
+}
+
+func TestFromRows(t *testing.T) {
+	start := time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC)
+	reference := 60.0
+	rows := []db.ListCongestionEpisodesRow{
+		episodeRow(1, start, 30*time.Minute, 15, &reference),
+		episodeRow(1, start.Add(2*time.Hour), 0, 20, &reference),
+		episodeRow(2, start, time.Hour, 90, &reference),
+		episodeRow(3, start, time.Hour, 10, nil),
+	}
+
+	episodes := FromRows(rows, 0)
+	require.Len(t, episodes, 4)
+	require.Equal(t, 1800.0, episodes[0].DurationSeconds)
+	require.InDelta(t, 0.75, *episodes[0].Severity, 1e-9)
+	// Faster than usual is no loss
+	require.Zero(t, *episodes[2].Severity)
+	require.Nil(t, episodes[3].Severity)
+
+	// Single readings are dropped by a minimum duration
+	require.Len(t, FromRows(rows, time.Minute), 3)
+}
+
+func TestSummarize(t *testing.T) {
+	start := time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC)
+	reference := 50.0
+	episodes := FromRows([]db.ListCongestionEpisodesRow{
+		episodeRow(1, start, 10*time.Minute, 25, &reference),
+		episodeRow(1, start.Add(time.Hour), 20*time.Minute, 40, &reference),
+		episodeRow(2, start, 90*time.Minute, 10, &reference),
+		episodeRow(3, start, 5*time.Minute, 10, nil),
+	}, 0)
+
+	summary := Summarize(episodes, 2)
+	require.Equal(t, 4, summary.Episodes)
+	require.Equal(t, 3, summary.Sensors)
+	require.Equal(t, float64(125*60), summary.TotalDurationSeconds)
+
+	require.Len(t, summary.Longest, 2)
+	require.Equal(t, int32(2), summary.Longest[0].SensorID)
+	require.Equal(t, 1200.0, summary.Longest[1].DurationSeconds)
+
+	require.Len(t, summary.MostFrequent, 2)
+	require.Equal(t, int32(1), summary.MostFrequent[0].SensorID)
+	require.Equal(t, 2, summary.MostFrequent[0].Episodes)
+	require.InDelta(t, 0.35, *summary.MostFrequent[0].AvgSeverity, 1e-9)
+	require.Equal(t, 1200.0, summary.MostFrequent[0].LongestSeconds)
+	require.Equal(t, int32(2), summary.MostFrequent[1].SensorID)
+}
@@ -0,0 +1,56 @@
+package episode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "smart_city/traffic_flow/db/sqlc"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrInvalidGap = errors.New("invalid max_gap")
+
+// MaxGap bounds how far apart two high readings of an episode may be
+const MaxGap = 24 * time.Hour
+
+// Service finds congestion episodes in the raw readings
+type Service struct {
+	store *db.Store
+}
+
+func NewService(store *db.Store) *Service {
+	return &Service{store: store}
+}
+
+// List returns the episodes from start to end of the given sensors, or of
+// all sensors when none are given, in sensor and time order. Readings more
+// than maxGap apart split an episode, and episodes shorter than minDuration
+// are left out. Episodes already running at start are cut off there.
+func (service *Service) List(ctx context.Context, start, end time.Time, sensorIDs []int32, maxGap, minDuration time.Duration) ([]Episode, error) {
+	if maxGap <= 0 || maxGap > MaxGap {
+		return nil, fmt.Errorf("%w %s, must be positive and at most %s", ErrInvalidGap, maxGap, MaxGap)
+	}
+
+	rows, err := service.store.ListCongestionEpisodes(ctx, db.ListCongestionEpisodesParams{
+		StartTime: pgtype.Timestamp{Time: start, Valid: true},
+		EndTime:   pgtype.Timestamp{Time: end, Valid: true},
+		SensorIds: sensorIDs,
+		MaxGap:    pgtype.Interval{Microseconds: maxGap.Microseconds(), Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return FromRows(rows, minDuration), nil
+}
+
+// Summary ranks the longest episodes and most affected sensors among the
+// given sensors, or city-wide when none are given
+func (service *Service) Summary(ctx context.Context, start, end time.Time, sensorIDs []int32, maxGap, minDuration time.Duration, limit int) (Summary, error) {
+	episodes, err := service.List(ctx, start, end, sensorIDs, maxGap, minDuration)
+	if err != nil {
+		return Summary{}, err
+	}
+	return Summarize(episodes, limit), nil
+}
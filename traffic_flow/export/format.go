@@ -0,0 +1,227 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+var ErrInvalidFormat = errors.New("invalid format")
+
+// Format is the file format of an export
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatNDJSON  Format = "ndjson"
+	FormatParquet Format = "parquet"
+)
+
+// ParseFormat reads an export format
+func ParseFormat(value string) (Format, error) {
+	switch format := Format(value); format {
+	case FormatCSV, FormatNDJSON, FormatParquet:
+		return format, nil
+	default:
+		return "", fmt.Errorf("%w %q, must be %s, %s or %s", ErrInvalidFormat, value, FormatCSV, FormatNDJSON, FormatParquet)
+	}
+}
+
+// ContentType is the media type of an export in the format
+func (format Format) ContentType() string {
+	switch format {
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatParquet:
+		return "application/vnd.apache.parquet"
+	default:
+		return "text/csv"
+	}
+}
+
+// Record is one exported reading. Its timestamp and sensor_id make up the
+// cursor from which an interrupted export is resumed.
+type Record struct {
+	Timestamp               time.Time `json:"timestamp" parquet:"timestamp,timestamp(microsecond)"`
+	SensorID                int32     `json:"sensor_id" parquet:"sensor_id"`
+	TrafficVolume           int32     `json:"traffic_volume" parquet:"traffic_volume"`
+	AverageSpeed            float64   `json:"average_speed" parquet:"average_speed"`
+	CongestionLevel         string    `json:"congestion_level" parquet:"congestion_level"`
+	IsLate                  bool      `json:"is_late" parquet:"is_late"`
+	ReportedCongestionLevel *string   `json:"reported_congestion_level" parquet:"reported_congestion_level,optional"`
+}
+
+// SensorRecord is a Record joined with its sensor's location and type
+type SensorRecord struct {
+	Record
+	Latitude   float64 `json:"latitude" parquet:"latitude"`
+	Longitude  float64 `json:"longitude" parquet:"longitude"`
+	SensorType string  `json:"sensor_type" parquet:"sensor_type"`
+}
+
+// Cursor is the position of a reading in an export
+func (record Record) Cursor() Cursor {
+	return Cursor{Timestamp: record.Timestamp, SensorID: record.SensorID}
+}
+
+// Writer encodes records to an export file. Flush hands the records
+// written so far to the underlying writer where the format allows; Close
+// writes whatever ends the file but does not close the underlying writer.
+type Writer interface {
+	Write(record SensorRecord) error
+	Flush() error
+	Close() error
+}
+
+// NewWriter starts an export in the format. Without withSensors the
+// sensor columns are left out.
+func NewWriter(w io.Writer, format Format, withSensors bool) (Writer, error) {
+	switch format {
+	case FormatNDJSON:
+		return NewNDJSONWriter(w, withSensors), nil
+	case FormatParquet:
+		return NewParquetWriter(w, withSensors), nil
+	default:
+		return NewCSVWriter(w, withSensors, true)
+	}
+}
+
+var (
+	csvHeader       = []string{"timestamp", "sensor_id", "traffic_volume", "average_speed", "congestion_level", "is_late", "reported_congestion_level"}
+	csvSensorHeader = []string{"latitude", "longitude", "sensor_type"}
+)
+
+type csvWriter struct {
+	writer      *csv.Writer
+	withSensors bool
+	fields      []string
+}
+
+// NewCSVWriter writes records as CSV, the header first unless header is
+// false, as when appending to an interrupted export
+func NewCSVWriter(w io.Writer, withSensors, header bool) (Writer, error) {
+	writer := &csvWriter{writer: csv.NewWriter(w), withSensors: withSensors}
+	if header {
+		fields := csvHeader
+		if withSensors {
+			fields = append(fields[:len(fields):len(fields)], csvSensorHeader...)
+		}
+		if err := writer.writer.Write(fields); err != nil {
+			return nil, err
+		}
+	}
+	return writer, nil
+}
+
+func (writer *csvWriter) Write(record SensorRecord) error {
+	reported := ""
+	if record.ReportedCongestionLevel != nil {
+		reported = *record.ReportedCongestionLevel
+	}
+	writer.fields = append(writer.fields[:0],
+		record.Timestamp.Format(time.RFC3339Nano),
+		strconv.FormatInt(int64(record.SensorID), 10),
+		strconv.FormatInt(int64(record.TrafficVolume), 10),
+		strconv.FormatFloat(record.AverageSpeed, 'f', -1, 64),
+		record.CongestionLevel,
+		strconv.FormatBool(record.IsLate),
+		reported,
+	)
+	if writer.withSensors {
+		writer.fields = append(writer.fields,
+			strconv.FormatFloat(record.Latitude, 'f', -1, 64),
+			strconv.FormatFloat(record.Longitude, 'f', -1, 64),
+			record.SensorType,
+		)
+	}
+	return writer.writer.Write(writer.fields)
+}
+
+func (writer *csvWriter) Flush() error {
+	writer.writer.Flush()
+	return writer.writer.Error()
+}
+
+func (writer *csvWriter) Close() error {
+	return writer.Flush()
+}
+
+type ndjsonWriter struct {
+	buffer      *bufio.Writer
+	encoder     *json.Encoder
+	withSensors bool
+}
+
+// NewNDJSONWriter writes records as JSON objects, one per line
+func NewNDJSONWriter(w io.Writer, withSensors bool) Writer {
+	buffer := bufio.NewWriter(w)
+	return &ndjsonWriter{buffer: buffer, encoder: json.NewEncoder(buffer), withSensors: withSensors}
+}
+
+func (writer *ndjsonWriter) Write(record SensorRecord) error {
+	if writer.withSensors {
+		return writer.encoder.Encode(record)
+	}
+	return writer.encoder.Encode(record.Record)
+}
+
+func (writer *ndjsonWriter) Flush() error {
+	return writer.buffer.Flush()
+}
+
+func (writer *ndjsonWriter) Close() error {
+	return writer.Flush()
+}
+
+// RowGroupSize is the number of rows of a Parquet row group, which is
+// buffered in memory until it is complete
+const RowGroupSize = 100_000
+
+type parquetWriter struct {
+	writer      *parquet.Writer
+	withSensors bool
+	rows        int
+}
+
+// NewParquetWriter writes records as a Parquet file. Row groups are only
+// written once complete, so Flush leaves the current one buffered.
+func NewParquetWriter(w io.Writer, withSensors bool) Writer {
+	schema := parquet.SchemaOf(new(Record))
+	if withSensors {
+		schema = parquet.SchemaOf(new(SensorRecord))
+	}
+	return &parquetWriter{writer: parquet.NewWriter(w, schema), withSensors: withSensors}
+}
+
+func (writer *parquetWriter) Write(record SensorRecord) error {
+	var err error
+	if writer.withSensors {
+		err = writer.writer.Write(&record)
+	} else {
+		err = writer.writer.Write(&record.Record)
+	}
+	if err != nil {
+		return err
+	}
+
+	writer.rows++
+	if writer.rows%RowGroupSize == 0 {
+		return writer.writer.Flush()
+	}
+	return nil
+}
+
+func (writer *parquetWriter) Flush() error {
+	return nil
+}
+
+func (writer *parquetWriter) Close() error {
+	return writer.writer.Close()
+}
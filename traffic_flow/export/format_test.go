@@ -0,0 +1,110 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/require"
+)
+
+func testRecords() []SensorRecord {
+	reported := "high"
+	start := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	return []SensorRecord{
+		{
+			Record:     Record{Timestamp: start, SensorID: 1, TrafficVolume: 12, AverageSpeed: 48.5, CongestionLevel: "low"},
+			Latitude:   40.4168,
+			Longitude:  -3.7038,
+			SensorType: "loop",
+		},
+		{
+			Record:     Record{Timestamp: start.Add(time.Minute), SensorID: 2, TrafficVolume: 40, AverageSpeed: 12, CongestionLevel: "high", IsLate: true, ReportedCongestionLevel: &reported},
+			Latitude:   40.42,
+			Longitude:  -3.7,
+			SensorType: "radar",
+		},
+	}
+}
+
+func writeAll(t *testing.T, format Format, withSensors bool) []byte {
+	var buffer bytes.Buffer
+	writer, err := NewWriter(&buffer, format, withSensors)
+	require.NoError(t, err)
+	for _, record := range testRecords() {
+		require.NoError(t, writer.Write(record))
+	}
+	require.NoError(t, writer.Close())
+	return buffer.Bytes()
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("parquet")
+	require.NoError(t, err)
+	require.Equal(t, FormatParquet, format)
+
+	_, err = ParseFormat("xlsx")
+	require.ErrorIs(t, err, ErrInvalidFormat)
+}
+
+func TestCSVWriter(t *testing.T) {
+	require.Equal(t, "timestamp,sensor_id,traffic_volume,average_speed,congestion_level,is_late,reported_congestion_level\n"+
+		"2026-10-01T08:00:00Z,1,12,48.5,low,false,\n"+
+		"2026-10-01T08:01:00Z,2,40,12,high,true,high\n",
+		string(writeAll(t, FormatCSV, false)))
+
+	require.Equal(t, "timestamp,sensor_id,traffic_volume,average_speed,congestion_level,is_late,reported_congestion_level,latitude,longitude,sensor_type\n"+
+		"2026-10-01T08:00:00Z,1,12,48.5,low,false,,40.4168,-3.7038,loop\n"+
+		"2026-10-01T08:01:00Z,2,40,12,high,true,high,40.42,-3.7,radar\n",
+		string(writeAll(t, FormatCSV, true)))
+}
+
+func TestNDJSONWriter(t *testing.T) {
+	require.Equal(t, `{"timestamp":"2026-10-01T08:00:00Z","sensor_id":1,"traffic_volume":12,"average_speed":48.5,"congestion_level":"low","is_late":false,"reported_congestion_level":null}`+"\n"+
+		`{"timestamp":"2026-10-01T08:01:00Z","sensor_id":2,"traffic_volume":40,"average_speed":12,"congestion_level":"high","is_late":true,"reported_congestion_level":"high"}`+"\n",
+		string(writeAll(t, FormatNDJSON, false)))
+
+	require.Contains(t, string(writeAll(t, FormatNDJSON, true)), `"reported_congestion_level":null,"latitude":40.4168,"longitude":-3.7038,"sensor_type":"loop"}`)
+}
+
+func TestParquetWriter(t *testing.T) {
+	data := writeAll(t, FormatParquet, true)
+	records, err := parquet.Read[SensorRecord](bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	for i, want := range testRecords() {
+		require.True(t, want.Timestamp.Equal(records[i].Timestamp))
+		// Null strings are read back as empty ones
+		records[i].Timestamp = want.Timestamp
+		records[i].ReportedCongestionLevel = want.ReportedCongestionLevel
+		require.Equal(t, want, records[i])
+	}
+
+	data = writeAll(t, FormatParquet, false)
+	file, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	require.EqualValues(t, 2, file.NumRows())
+	_, found := file.Schema().Lookup("latitude")
+	require.False(t, found)
+
+	rows := make([]parquet.Row, 2)
+	n, _ := file.RowGroups()[0].Rows().ReadRows(rows)
+	require.Equal(t, 2, n)
+	require.True(t, rows[0][6].IsNull())
+	require.Equal(t, "high", rows[1][6].String())
+}
+
+func TestCursor(t *testing.T) {
+	cursor := Cursor{Timestamp: time.Date(2026, 10, 1, 8, 0, 0, 500, time.UTC), SensorID: 7}
+	require.Equal(t, "2026-10-01T08:00:00.0000005Z,7", cursor.String())
+
+	parsed, err := ParseCursor(cursor.String())
+	require.NoError(t, err)
+	require.Equal(t, cursor, parsed)
+
+	for _, value := range []string{"", "2026-10-01T08:00:00Z", "yesterday,7", "2026-10-01T08:00:00Z,x"} {
+		_, err := ParseCursor(value)
+		require.ErrorIs(t, err, ErrInvalidCursor)
+	}
+}
@@ -0,0 +1,81 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+var ErrNotResumable = errors.New("export cannot be resumed")
+
+// resumeTail is how much of the end of a file is searched for its last
+// complete row
+const resumeTail = 64 * 1024
+
+// Resume prepares an interrupted CSV or NDJSON export file for appending:
+// a partly written last row is cut off and the file positioned at its end.
+// It returns the cursor of the last complete row, nil when the file has no
+// rows yet. Parquet files end in a footer and cannot be appended to.
+func Resume(file *os.File, format Format) (*Cursor, error) {
+	if format == FormatParquet {
+		return nil, fmt.Errorf("%w: %s files cannot be appended to, start a new file from the last cursor", ErrNotResumable, format)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	offset := max(info.Size()-resumeTail, 0)
+	tail := make([]byte, info.Size()-offset)
+	if _, err := file.ReadAt(tail, offset); err != nil {
+		return nil, err
+	}
+
+	end := bytes.LastIndexByte(tail, '\n')
+	if end < 0 && offset > 0 {
+		return nil, fmt.Errorf("%w: no complete row in the last %d bytes", ErrNotResumable, resumeTail)
+	}
+	if err := file.Truncate(offset + int64(end) + 1); err != nil {
+		return nil, err
+	}
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		return nil, err
+	}
+	if end < 0 {
+		return nil, nil
+	}
+
+	start := bytes.LastIndexByte(tail[:end], '\n') + 1
+	if start == 0 && offset > 0 {
+		return nil, fmt.Errorf("%w: no complete row in the last %d bytes", ErrNotResumable, resumeTail)
+	}
+	return lastCursor(tail[start:end], format)
+}
+
+// lastCursor reads the cursor of a row, nil for a CSV header
+func lastCursor(line []byte, format Format) (*Cursor, error) {
+	if format == FormatNDJSON {
+		var record Record
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, fmt.Errorf("%w: cannot parse last row: %w", ErrNotResumable, err)
+		}
+		cursor := record.Cursor()
+		return &cursor, nil
+	}
+
+	fields := bytes.SplitN(line, []byte(","), 3)
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("%w: cannot parse last row %q", ErrNotResumable, line)
+	}
+	if string(fields[0]) == csvHeader[0] {
+		return nil, nil
+	}
+	cursor, err := ParseCursor(string(fields[0]) + "," + string(fields[1]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotResumable, err)
+	}
+	return &cursor, nil
+}
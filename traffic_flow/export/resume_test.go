@@ -0,0 +1,55 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func resumeFile(t *testing.T, content string, format Format) (*Cursor, string) {
+	path := filepath.Join(t.TempDir(), "export")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	require.NoError(t, err)
+	defer file.Close()
+
+	cursor, err := Resume(file, format)
+	require.NoError(t, err)
+	_, err = file.WriteString("next\n")
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return cursor, string(data)
+}
+
+func TestResume(t *testing.T) {
+	header := "timestamp,sensor_id,traffic_volume,average_speed,congestion_level,is_late,reported_congestion_level\n"
+	row := "2026-10-01T08:00:00Z,1,12,48.5,low,false,\n"
+	want := &Cursor{Timestamp: time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC), SensorID: 1}
+
+	// The partly written row is cut off
+	cursor, content := resumeFile(t, header+row+"2026-10-01T08:01", FormatCSV)
+	require.Equal(t, want, cursor)
+	require.Equal(t, header+row+"next\n", content)
+
+	cursor, content = resumeFile(t, header, FormatCSV)
+	require.Nil(t, cursor)
+	require.Equal(t, header+"next\n", content)
+
+	cursor, content = resumeFile(t, "timestamp,sens", FormatCSV)
+	require.Nil(t, cursor)
+	require.Equal(t, "next\n", content)
+
+	line := `{"timestamp":"2026-10-01T08:00:00Z","sensor_id":1,"traffic_volume":12}` + "\n"
+	cursor, content = resumeFile(t, line+`{"timestamp":"2026-10-01T08:01:00Z","sens`, FormatNDJSON)
+	require.Equal(t, want, cursor)
+	require.Equal(t, line+"next\n", content)
+
+	_, err := Resume(nil, FormatParquet)
+	require.ErrorIs(t, err, ErrNotResumable)
+}
@@ -0,0 +1,180 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "smart_city/traffic_flow/db/sqlc"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PageSize is the number of readings read from the DB at a time, which
+// bounds the memory an export takes whatever its range
+const PageSize = 5000
+
+// Cursor is the position of a reading in the (timestamp, sensor_id) order
+// of an export. Written as "timestamp,sensor_id", it is the first two
+// columns of a CSV row.
+type Cursor struct {
+	Timestamp time.Time
+	SensorID  int32
+}
+
+func (cursor Cursor) String() string {
+	return cursor.Timestamp.UTC().Format(time.RFC3339Nano) + "," + strconv.FormatInt(int64(cursor.SensorID), 10)
+}
+
+// ParseCursor reads a cursor written as "timestamp,sensor_id"
+func ParseCursor(value string) (Cursor, error) {
+	timestamp, sensorID, found := strings.Cut(value, ",")
+	if !found {
+		return Cursor{}, fmt.Errorf("%w %q, must be timestamp,sensor_id", ErrInvalidCursor, value)
+	}
+	parsedTime, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(timestamp))
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w %q: %w", ErrInvalidCursor, value, err)
+	}
+	id, err := strconv.ParseInt(strings.TrimSpace(sensorID), 10, 32)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w %q: %w", ErrInvalidCursor, value, err)
+	}
+	return Cursor{Timestamp: parsedTime.UTC(), SensorID: int32(id)}, nil
+}
+
+// Request selects the readings of an export
+type Request struct {
+	StartTime time.Time
+	EndTime   time.Time
+	// SensorIDs limits the export to these sensors; nil exports all
+	SensorIDs []int32
+	// WithSensors joins in each reading's sensor location and type
+	WithSensors bool
+	// After resumes an interrupted export after the reading at the cursor
+	After *Cursor
+}
+
+// Result tells how far an export got
+type Result struct {
+	Rows int64
+	// Last is the cursor of the last reading written, nil if none was
+	Last *Cursor
+}
+
+// Service streams raw readings out of the DB
+type Service struct {
+	store *db.Store
+}
+
+func NewService(store *db.Store) *Service {
+	return &Service{store: store}
+}
+
+// Export writes the requested readings in (timestamp, sensor_id) order,
+// reading them a page at a time and flushing the writer after each page.
+// It does not close the writer. On error the result still tells where to
+// resume from.
+func (service *Service) Export(ctx context.Context, req Request, writer Writer) (Result, error) {
+	var result Result
+
+	after := Cursor{Timestamp: req.StartTime}
+	if req.After != nil && !req.After.Timestamp.Before(req.StartTime) {
+		after = *req.After
+	}
+
+	for {
+		records, err := service.page(ctx, req, after)
+		if err != nil {
+			return result, err
+		}
+		for _, record := range records {
+			if err := writer.Write(record); err != nil {
+				return result, err
+			}
+			last := record.Cursor()
+			result.Rows++
+			result.Last = &last
+		}
+		if err := writer.Flush(); err != nil {
+			return result, err
+		}
+
+		if len(records) < PageSize {
+			return result, nil
+		}
+		after = records[len(records)-1].Cursor()
+	}
+}
+
+// page reads the readings following after
+func (service *Service) page(ctx context.Context, req Request, after Cursor) ([]SensorRecord, error) {
+	afterTime := pgtype.Timestamp{Time: after.Timestamp, Valid: true}
+	endTime := pgtype.Timestamp{Time: req.EndTime, Valid: true}
+
+	if !req.WithSensors {
+		rows, err := service.store.ExportTrafficData(ctx, db.ExportTrafficDataParams{
+			AfterTime:     afterTime,
+			EndTime:       endTime,
+			AfterSensorID: after.SensorID,
+			SensorIds:     req.SensorIDs,
+			RowLimit:      PageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		records := make([]SensorRecord, len(rows))
+		for i, row := range rows {
+			records[i].Record = newRecord(row)
+		}
+		return records, nil
+	}
+
+	rows, err := service.store.ExportTrafficDataWithSensors(ctx, db.ExportTrafficDataWithSensorsParams{
+		AfterTime:     afterTime,
+		EndTime:       endTime,
+		AfterSensorID: after.SensorID,
+		SensorIds:     req.SensorIDs,
+		RowLimit:      PageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	records := make([]SensorRecord, len(rows))
+	for i, row := range rows {
+		records[i] = SensorRecord{
+			Record: newRecord(db.TrafficDatum{
+				SensorID:                row.SensorID,
+				Timestamp:               row.Timestamp,
+				TrafficVolume:           row.TrafficVolume,
+				AverageSpeed:            row.AverageSpeed,
+				CongestionLevel:         row.CongestionLevel,
+				IsLate:                  row.IsLate,
+				ReportedCongestionLevel: row.ReportedCongestionLevel,
+			}),
+			Latitude:   row.Latitude,
+			Longitude:  row.Longitude,
+			SensorType: row.TypeName,
+		}
+	}
+	return records, nil
+}
+
+func newRecord(datum db.TrafficDatum) Record {
+	record := Record{
+		Timestamp:       datum.Timestamp.Time.UTC(),
+		SensorID:        datum.SensorID,
+		TrafficVolume:   datum.TrafficVolume,
+		AverageSpeed:    datum.AverageSpeed,
+		CongestionLevel: string(datum.CongestionLevel),
+		IsLate:          datum.IsLate,
+	}
+	if datum.ReportedCongestionLevel.Valid {
+		record.ReportedCongestionLevel = &datum.ReportedCongestionLevel.String
+	}
+	return record
+}
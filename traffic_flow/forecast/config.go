@@ -0,0 +1,88 @@
+package forecast
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config controls how forecasting models are fitted
+type Config struct {
+	// Enabled refits the models of all sensors in the background; when
+	// false models are only fitted on request
+	Enabled bool
+	// Step is the width of the buckets readings are averaged into before
+	// fitting, and the resolution of the forecasts
+	Step time.Duration
+	// Season is the length of the seasonal cycle, a multiple of Step
+	Season time.Duration
+	// History is how much of traffic_data a model is fitted to; it must
+	// cover at least two seasons
+	History time.Duration
+	// RefitInterval is how long a model is used before it is fitted again
+	RefitInterval time.Duration
+	// MaxHorizon is the longest horizon that may be requested
+	MaxHorizon time.Duration
+	// Backtest is the most recent part of the history over which forecasts
+	// are compared with the actual readings; the smoothing factors are
+	// chosen on the history before it
+	Backtest time.Duration
+}
+
+// LoadConfig reads the forecasting settings from the environment, falling
+// back to defaults for unset variables
+func LoadConfig() (Config, error) {
+	config := Config{
+		Enabled:       true,
+		Step:          15 * time.Minute,
+		Season:        24 * time.Hour,
+		History:       14 * 24 * time.Hour,
+		RefitInterval: 15 * time.Minute,
+		MaxHorizon:    3 * time.Hour,
+		Backtest:      24 * time.Hour,
+	}
+
+	if value := os.Getenv("FORECAST_ENABLED"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return config, fmt.Errorf("cannot parse FORECAST_ENABLED: %w", err)
+		}
+		config.Enabled = enabled
+	}
+
+	durations := map[string]*time.Duration{
+		"FORECAST_STEP":           &config.Step,
+		"FORECAST_SEASON":         &config.Season,
+		"FORECAST_HISTORY":        &config.History,
+		"FORECAST_REFIT_INTERVAL": &config.RefitInterval,
+		"FORECAST_MAX_HORIZON":    &config.MaxHorizon,
+		"FORECAST_BACKTEST":       &config.Backtest,
+	}
+	for key, target := range durations {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return config, fmt.Errorf("cannot parse %s: %w", key, err)
+		}
+		*target = duration
+	}
+
+	if config.Step <= 0 || config.RefitInterval <= 0 || config.MaxHorizon <= 0 {
+		return config, fmt.Errorf("FORECAST_STEP, FORECAST_REFIT_INTERVAL and FORECAST_MAX_HORIZON must be positive")
+	}
+	if config.Season < config.Step || config.Season%config.Step != 0 {
+		return config, fmt.Errorf("FORECAST_SEASON must be a multiple of FORECAST_STEP")
+	}
+	if config.History < 2*config.Season {
+		return config, fmt.Errorf("FORECAST_HISTORY must cover at least two seasons")
+	}
+	if config.Backtest < 0 || config.Backtest > config.History-2*config.Season {
+		return config, fmt.Errorf("FORECAST_BACKTEST must not exceed FORECAST_HISTORY minus two seasons")
+	}
+
+	return config, nil
+}
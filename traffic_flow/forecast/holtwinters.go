@@ -0,0 +1,211 @@
+package forecast
+
+import (
+	"errors"
+	"math"
+)
+
+var ErrInsufficientHistory = errors.New("not enough history to fit a forecasting model")
+
+// Params are the smoothing factors of the level, trend and season
+type Params struct {
+	Alpha float64 `json:"alpha"`
+	Beta  float64 `json:"beta"`
+	Gamma float64 `json:"gamma"`
+}
+
+// grid is searched for the parameters with the smallest one-step error
+var grid = func() []Params {
+	params := []Params{}
+	for _, alpha := range []float64{0.1, 0.3, 0.5, 0.8} {
+		for _, beta := range []float64{0, 0.01, 0.05} {
+			for _, gamma := range []float64{0.05, 0.2, 0.4} {
+				params = append(params, Params{Alpha: alpha, Beta: beta, Gamma: gamma})
+			}
+		}
+	}
+	return params
+}()
+
+// Model is an additive Holt-Winters model of one metric of a sensor. Gaps
+// in the history, NaN values, carry the level forward along the trend.
+type Model struct {
+	Params Params `json:"params"`
+	period int
+	level  float64
+	trend  float64
+	season []float64
+	// next is the index in the history of the step forecast(1) predicts
+	next int
+	// sigma is the standard deviation of the one-step errors
+	sigma float64
+	// MAPE is the mean absolute percentage error of the backtest forecasts,
+	// nil when no backtest forecast could be scored
+	MAPE *float64 `json:"mape"`
+	// BacktestPoints is the number of forecasts the MAPE is computed from
+	BacktestPoints int `json:"backtest_points"`
+}
+
+func mean(values []float64) (float64, bool) {
+	sum, count := 0.0, 0
+	for _, value := range values {
+		if !math.IsNaN(value) {
+			sum += value
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return sum / float64(count), true
+}
+
+// initialize derives the level and trend from the means of the first two
+// seasons and the seasonal offsets from the first one
+func initialize(values []float64, period int, params Params) (*Model, bool) {
+	first, ok := mean(values[:period])
+	if !ok {
+		return nil, false
+	}
+	second, ok := mean(values[period : 2*period])
+	if !ok {
+		return nil, false
+	}
+
+	model := &Model{
+		Params: params,
+		period: period,
+		level:  first,
+		trend:  (second - first) / float64(period),
+		season: make([]float64, period),
+		next:   period,
+	}
+	for i, value := range values[:period] {
+		if !math.IsNaN(value) {
+			model.season[i] = value - first
+		}
+	}
+	return model, true
+}
+
+// forecast predicts the value h steps ahead
+func (model *Model) forecast(h int) float64 {
+	return model.level + float64(h)*model.trend + model.season[(model.next+h-1)%model.period]
+}
+
+func (model *Model) update(value float64) {
+	i := model.next % model.period
+	model.next++
+
+	if math.IsNaN(value) {
+		model.level += model.trend
+		return
+	}
+
+	alpha, beta, gamma := model.Params.Alpha, model.Params.Beta, model.Params.Gamma
+	season := model.season[i]
+	level := alpha*(value-season) + (1-alpha)*(model.level+model.trend)
+	model.trend = beta*(level-model.level) + (1-beta)*model.trend
+	model.season[i] = gamma*(value-level) + (1-gamma)*season
+	model.level = level
+}
+
+// stddev is the standard deviation of the error of a forecast h steps
+// ahead, following the additive Holt-Winters state space model
+func (model *Model) stddev(h int) float64 {
+	alpha, beta, gamma := model.Params.Alpha, model.Params.Beta, model.Params.Gamma
+	variance := 1.0
+	for j := 1; j < h; j++ {
+		c := alpha * (1 + float64(j)*beta)
+		if j%model.period == 0 {
+			c += gamma
+		}
+		variance += c * c
+	}
+	return model.sigma * math.Sqrt(variance)
+}
+
+// backtest scores forecasts of up to horizon steps made from every origin
+// at or after from
+type backtest struct {
+	from    int
+	horizon int
+	sum     float64
+	count   int
+}
+
+func (test *backtest) add(model *Model, actual []float64) {
+	for h := 1; h <= test.horizon && h <= len(actual); h++ {
+		value := actual[h-1]
+		// Percentage errors of zero readings are undefined
+		if math.IsNaN(value) || value <= 0 {
+			continue
+		}
+		predicted := max(model.forecast(h), 0)
+		test.sum += math.Abs(value-predicted) / value
+		test.count++
+	}
+}
+
+// run fits a model with the given parameters to values and returns the sum
+// of squared one-step errors
+func run(values []float64, period int, params Params, test *backtest) (*Model, float64, bool) {
+	model, ok := initialize(values, period, params)
+	if !ok {
+		return nil, 0, false
+	}
+
+	sse, n := 0.0, 0
+	for t := period; t < len(values); t++ {
+		if test != nil && t >= test.from {
+			test.add(model, values[t:])
+		}
+		if value := values[t]; !math.IsNaN(value) {
+			err := value - model.forecast(1)
+			sse += err * err
+			n++
+		}
+		model.update(values[t])
+	}
+	if n == 0 {
+		return nil, 0, false
+	}
+
+	model.sigma = math.Sqrt(sse / float64(n))
+	return model, sse, true
+}
+
+// Fit chooses the smoothing factors that best predict values one step
+// ahead, then backtests forecasts of up to horizon steps made from each of
+// the last backtestSteps steps. The factors are chosen on the steps before
+// the backtest only, so it scores forecasts of data the model was not tuned
+// on. values holds one entry per step, NaN where there were no readings.
+func Fit(values []float64, period, horizon, backtestSteps int) (*Model, error) {
+	if period < 1 || len(values) < 2*period {
+		return nil, ErrInsufficientHistory
+	}
+
+	// The first two seasons initialize the model and are always trained on
+	training := max(len(values)-backtestSteps, 2*period)
+
+	var best Params
+	bestSSE := math.Inf(1)
+	for _, params := range grid {
+		_, sse, ok := run(values[:training], period, params, nil)
+		if !ok {
+			return nil, ErrInsufficientHistory
+		}
+		if sse < bestSSE {
+			best, bestSSE = params, sse
+		}
+	}
+
+	test := &backtest{from: training, horizon: horizon}
+	model, _, _ := run(values, period, best, test)
+	model.BacktestPoints = test.count
+	if test.count > 0 {
+		mape := 100 * test.sum / float64(test.count)
+		model.MAPE = &mape
+	}
+	return model, nil
+}
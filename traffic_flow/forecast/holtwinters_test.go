@@ -0,0 +1,81 @@
+package forecast
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// daily returns days of a seasonal series with period steps per day
+func daily(days, period int) []float64 {
+	values := make([]float64, days*period)
+	for i := range values {
+		values[i] = 300 + 200*math.Sin(2*math.Pi*float64(i%period)/float64(period))
+	}
+	return values
+}
+
+func TestFit(t *testing.T) {
+	period := 24
+	values := daily(7, period)
+
+	model, err := Fit(values, period, 3, period)
+	require.NoError(t, err)
+	require.NotNil(t, model.MAPE)
+	require.Less(t, *model.MAPE, 1.0)
+	require.Positive(t, model.BacktestPoints)
+
+	// The next step starts a new day
+	require.InDelta(t, 300, model.forecast(1), 5)
+	require.InDelta(t, 500, model.forecast(period/4+1), 5)
+}
+
+func TestFitWithGaps(t *testing.T) {
+	period := 24
+	values := daily(7, period)
+	for i := 30; i < 40; i++ {
+		values[i] = math.NaN()
+	}
+
+	model, err := Fit(values, period, 3, period)
+	require.NoError(t, err)
+	require.InDelta(t, 300, model.forecast(1), 10)
+}
+
+func TestFitInsufficientHistory(t *testing.T) {
+	_, err := Fit(daily(1, 24), 24, 3, 24)
+	require.ErrorIs(t, err, ErrInsufficientHistory)
+
+	empty := make([]float64, 48)
+	for i := range empty {
+		empty[i] = math.NaN()
+	}
+	_, err = Fit(empty, 24, 3, 24)
+	require.ErrorIs(t, err, ErrInsufficientHistory)
+}
+
+func TestStddevGrowsWithHorizon(t *testing.T) {
+	model := &Model{Params: Params{Alpha: 0.3, Beta: 0.05, Gamma: 0.2}, period: 24, sigma: 10}
+	require.Equal(t, 10.0, model.stddev(1))
+	require.Greater(t, model.stddev(4), model.stddev(2))
+}
+
+func TestFitTunesBeforeBacktest(t *testing.T) {
+	period := 24
+	values := daily(7, period)
+	// The last day jumps to a new level the tuning must not see
+	for i := 6 * period; i < len(values); i++ {
+		values[i] += 150 + 10*float64(i%5)
+	}
+
+	model, err := Fit(values, period, 3, period)
+	require.NoError(t, err)
+	tuned, err := Fit(values[:6*period], period, 3, 0)
+	require.NoError(t, err)
+	require.Equal(t, tuned.Params, model.Params)
+
+	whole, err := Fit(values, period, 3, 0)
+	require.NoError(t, err)
+	require.NotEqual(t, whole.Params, model.Params)
+}
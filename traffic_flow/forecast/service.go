@@ -0,0 +1,303 @@
+package forecast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	db "smart_city/traffic_flow/db/sqlc"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrInvalidHorizon = errors.New("invalid forecast horizon")
+	ErrInvalidLevel   = errors.New("invalid prediction interval level")
+)
+
+// zScores are the normal quantiles of the supported prediction interval
+// levels in percent
+var zScores = map[int]float64{
+	80: 1.2816,
+	90: 1.6449,
+	95: 1.9600,
+	99: 2.5758,
+}
+
+// SensorModel holds the volume and speed models of a sensor
+type SensorModel struct {
+	SensorID int32     `json:"sensor_id"`
+	FittedAt time.Time `json:"fitted_at"`
+	// Start is the beginning of the step the models predict first
+	Start  time.Time `json:"-"`
+	Volume *Model    `json:"traffic_volume"`
+	Speed  *Model    `json:"average_speed"`
+}
+
+// Interval is a forecast value with its prediction interval
+type Interval struct {
+	Value float64 `json:"value"`
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+}
+
+type Point struct {
+	Time   time.Time `json:"time"`
+	Volume Interval  `json:"traffic_volume"`
+	Speed  Interval  `json:"average_speed"`
+}
+
+type Forecast struct {
+	*SensorModel
+	Step    string  `json:"step"`
+	Horizon string  `json:"horizon"`
+	Level   int     `json:"level"`
+	Points  []Point `json:"points"`
+}
+
+// Service fits a Holt-Winters model per sensor and metric to the bucketed
+// history in traffic_data and serves forecasts from them. Models are kept
+// until they are older than the refit interval.
+type Service struct {
+	store  *db.Store
+	config Config
+	mu     sync.RWMutex
+	models map[int32]*SensorModel
+}
+
+func NewService(store *db.Store, config Config) *Service {
+	return &Service{
+		store:  store,
+		config: config,
+		models: make(map[int32]*SensorModel),
+	}
+}
+
+func timestamp(t time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{Time: t, Valid: true}
+}
+
+// window is the history models are fitted to, ending with the last
+// complete step
+func (service *Service) window(now time.Time) (time.Time, time.Time) {
+	end := now.UTC().Truncate(service.config.Step)
+	return end.Add(-service.config.History), end
+}
+
+// history loads the bucketed readings of the given sensors, or of all
+// sensors when sensorIDs is nil, as one value per step and metric
+func (service *Service) history(ctx context.Context, start, end time.Time, sensorIDs []int32) (map[int32][2][]float64, error) {
+	rows, err := service.store.GetTrafficSeries(ctx, db.GetTrafficSeriesParams{
+		BucketWidth: pgtype.Interval{Microseconds: service.config.Step.Microseconds(), Valid: true},
+		StartTime:   timestamp(start),
+		EndTime:     timestamp(end),
+		SensorIds:   sensorIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	steps := int(end.Sub(start) / service.config.Step)
+	histories := make(map[int32][2][]float64)
+	for _, row := range rows {
+		history, ok := histories[row.SensorID]
+		if !ok {
+			history = [2][]float64{make([]float64, steps), make([]float64, steps)}
+			for i := range steps {
+				history[0][i], history[1][i] = math.NaN(), math.NaN()
+			}
+			histories[row.SensorID] = history
+		}
+
+		i := int(row.Bucket.Time.Sub(start) / service.config.Step)
+		if i < 0 || i >= steps {
+			continue
+		}
+		history[0][i] = row.AvgVolume
+		history[1][i] = row.AvgSpeed
+	}
+	return histories, nil
+}
+
+func (service *Service) fit(sensorID int32, history [2][]float64, fittedAt, end time.Time) (*SensorModel, error) {
+	period := int(service.config.Season / service.config.Step)
+	horizon := int(math.Ceil(float64(service.config.MaxHorizon) / float64(service.config.Step)))
+	backtest := int(service.config.Backtest / service.config.Step)
+
+	volume, err := Fit(history[0], period, horizon, backtest)
+	if err != nil {
+		return nil, err
+	}
+	speed, err := Fit(history[1], period, horizon, backtest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SensorModel{
+		SensorID: sensorID,
+		FittedAt: fittedAt,
+		Start:    end,
+		Volume:   volume,
+		Speed:    speed,
+	}, nil
+}
+
+// Refit fits new models for a sensor
+func (service *Service) Refit(ctx context.Context, sensorID int32) (*SensorModel, error) {
+	now := time.Now().UTC()
+	start, end := service.window(now)
+
+	histories, err := service.history(ctx, start, end, []int32{sensorID})
+	if err != nil {
+		return nil, err
+	}
+	history, ok := histories[sensorID]
+	if !ok {
+		return nil, ErrInsufficientHistory
+	}
+
+	model, err := service.fit(sensorID, history, now, end)
+	if err != nil {
+		return nil, err
+	}
+
+	service.mu.Lock()
+	service.models[sensorID] = model
+	service.mu.Unlock()
+	return model, nil
+}
+
+// RefitAll fits new models for every sensor with readings in the history
+// window and forgets the models of sensors without enough of them
+func (service *Service) RefitAll(ctx context.Context) error {
+	now := time.Now().UTC()
+	start, end := service.window(now)
+
+	histories, err := service.history(ctx, start, end, nil)
+	if err != nil {
+		return err
+	}
+
+	models := make(map[int32]*SensorModel, len(histories))
+	for sensorID, history := range histories {
+		model, err := service.fit(sensorID, history, now, end)
+		if err != nil {
+			continue
+		}
+		models[sensorID] = model
+	}
+
+	service.mu.Lock()
+	service.models = models
+	service.mu.Unlock()
+	return nil
+}
+
+// Run refits all models right away and then every RefitInterval until ctx
+// is done
+func (service *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(service.config.RefitInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := service.RefitAll(ctx); err != nil {
+			log.Error().Err(err).Msg("cannot refit forecasting models")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// model returns a sensor's models, fitting them first when they are
+// missing or older than the refit interval
+func (service *Service) model(ctx context.Context, sensorID int32) (*SensorModel, error) {
+	service.mu.RLock()
+	model, ok := service.models[sensorID]
+	service.mu.RUnlock()
+
+	if ok && time.Since(model.FittedAt) < service.config.RefitInterval {
+		return model, nil
+	}
+	return service.Refit(ctx, sensorID)
+}
+
+// Forecast predicts a sensor's average volume and speed per step from the
+// current step until horizon from now, with prediction intervals at the
+// given level in percent
+func (service *Service) Forecast(ctx context.Context, sensorID int32, horizon time.Duration, level int) (Forecast, error) {
+	if horizon <= 0 || horizon > service.config.MaxHorizon {
+		return Forecast{}, fmt.Errorf("%w %s, must be positive and at most %s", ErrInvalidHorizon, horizon, service.config.MaxHorizon)
+	}
+	z, ok := zScores[level]
+	if !ok {
+		return Forecast{}, fmt.Errorf("%w %d, must be one of 80, 90, 95 or 99", ErrInvalidLevel, level)
+	}
+
+	model, err := service.model(ctx, sensorID)
+	if err != nil {
+		return Forecast{}, err
+	}
+
+	step := service.config.Step
+	now := time.Now().UTC()
+	until := now.Add(horizon)
+
+	points := []Point{}
+	for h := 1; ; h++ {
+		t := model.Start.Add(time.Duration(h-1) * step)
+		if !t.Before(until) {
+			break
+		}
+		// Steps that are already over are not forecasts anymore
+		if !t.Add(step).After(now) {
+			continue
+		}
+		points = append(points, Point{
+			Time:   t,
+			Volume: predict(model.Volume, h, z),
+			Speed:  predict(model.Speed, h, z),
+		})
+	}
+
+	return Forecast{
+		SensorModel: model,
+		Step:        step.String(),
+		Horizon:     horizon.String(),
+		Level:       level,
+		Points:      points,
+	}, nil
+}
+
+// predict forecasts h steps ahead; neither volume nor speed can be negative
+func predict(model *Model, h int, z float64) Interval {
+	value := model.forecast(h)
+	spread := z * model.stddev(h)
+	return Interval{
+		Value: max(value, 0),
+		Lower: max(value-spread, 0),
+		Upper: max(value+spread, 0),
+	}
+}
+
+// Models returns the current models of all sensors ordered by sensor
+func (service *Service) Models() []*SensorModel {
+	service.mu.RLock()
+	defer service.mu.RUnlock()
+
+	models := make([]*SensorModel, 0, len(service.models))
+	for _, model := range service.models {
+		models = append(models, model)
+	}
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].SensorID < models[j].SensorID
+	})
+	return models
+}
@@ -0,0 +1,168 @@
+package gapi
+
+import (
+	db "smart_city/traffic_flow/db/sqlc"
+	"smart_city/traffic_flow/ingest"
+	"smart_city/traffic_flow/pb"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const dateLayout = "2006-01-02"
+
+func convertReading(reading *pb.TrafficReading) ingest.Reading {
+	// proto3 scalars have no presence, so an unset volume or speed is a 0
+	trafficVolume, averageSpeed := reading.GetTrafficVolume(), reading.GetAverageSpeed()
+	converted := ingest.Reading{
+		SensorID:        reading.GetSensorId(),
+		TrafficVolume:   &trafficVolume,
+		AverageSpeed:    &averageSpeed,
+		CongestionLevel: reading.GetCongestionLevel(),
+	}
+	if reading.GetTimestamp() != nil {
+		ts := reading.GetTimestamp().AsTime()
+		converted.Timestamp = &ts
+	}
+	return converted
+}
+
+func convertTimestamp(ts pgtype.Timestamp) *timestamppb.Timestamp {
+	if !ts.Valid {
+		return nil
+	}
+	return timestamppb.New(ts.Time)
+}
+
+func convertDate(date pgtype.Date) string {
+	if !date.Valid {
+		return ""
+	}
+	return date.Time.Format(dateLayout)
+}
+
+func convertTrafficDatum(data db.TrafficDatum) *pb.TrafficDatum {
+	return &pb.TrafficDatum{
+		SensorId:        data.SensorID,
+		Timestamp:       convertTimestamp(data.Timestamp),
+		TrafficVolume:   data.TrafficVolume,
+		AverageSpeed:    data.AverageSpeed,
+		CongestionLevel: string(data.CongestionLevel),
+		IsLate:          data.IsLate,
+
+		ReportedCongestionLevel: data.ReportedCongestionLevel.String,
+	}
+}
+
+func convertLatestTrafficData(data db.GetLatestTrafficDataRow) *pb.TrafficDatum {
+	return &pb.TrafficDatum{
+		SensorId:        data.SensorID,
+		Timestamp:       convertTimestamp(data.Timestamp),
+		TrafficVolume:   data.TrafficVolume,
+		AverageSpeed:    data.AverageSpeed,
+		CongestionLevel: string(data.CongestionLevel),
+		IsLate:          data.IsLate,
+		Latitude:        data.Latitude,
+		Longitude:       data.Longitude,
+
+		ReportedCongestionLevel: data.ReportedCongestionLevel.String,
+	}
+}
+
+func convertSensorType(sensorType db.SensorType) *pb.SensorType {
+	return &pb.SensorType{
+		TypeId:      sensorType.TypeID,
+		TypeName:    sensorType.TypeName,
+		Description: sensorType.Description.String,
+	}
+}
+
+func convertSensor(sensor db.Sensor) *pb.Sensor {
+	return &pb.Sensor{
+		SensorId:         sensor.SensorID,
+		Latitude:         sensor.Latitude,
+		Longitude:        sensor.Longitude,
+		TypeId:           sensor.TypeID,
+		InstallationDate: convertDate(sensor.InstallationDate),
+		Status:           sensor.Status,
+	}
+}
+
+func convertSensorRow(sensor db.GetSensorRow) *pb.Sensor {
+	return &pb.Sensor{
+		SensorId:         sensor.SensorID,
+		Latitude:         sensor.Latitude,
+		Longitude:        sensor.Longitude,
+		InstallationDate: convertDate(sensor.InstallationDate),
+		Status:           sensor.Status,
+		TypeName:         sensor.TypeName,
+		TypeDescription:  sensor.TypeDescription.String,
+	}
+}
+
+func convertListSensorsRow(sensor db.ListSensorsRow) *pb.Sensor {
+	return convertSensorRow(db.GetSensorRow(sensor))
+}
+
+func convertActiveSensorsRow(sensor db.GetActiveSensorsRow) *pb.Sensor {
+	return &pb.Sensor{
+		SensorId:         sensor.SensorID,
+		Latitude:         sensor.Latitude,
+		Longitude:        sensor.Longitude,
+		InstallationDate: convertDate(sensor.InstallationDate),
+		Status:           sensor.Status,
+		TypeName:         sensor.TypeName,
+	}
+}
+
+func convertSensorsByTypeRow(sensor db.GetSensorsByTypeRow, typeID int32) *pb.Sensor {
+	return &pb.Sensor{
+		SensorId:         sensor.SensorID,
+		Latitude:         sensor.Latitude,
+		Longitude:        sensor.Longitude,
+		TypeId:           typeID,
+		InstallationDate: convertDate(sensor.InstallationDate),
+		Status:           sensor.Status,
+	}
+}
+
+func convertTimestamptz(ts pgtype.Timestamptz) *timestamppb.Timestamp {
+	if !ts.Valid {
+		return nil
+	}
+	return timestamppb.New(ts.Time)
+}
+
+func convertSensorStatusTransition(transition db.SensorStatusTransition) *pb.SensorStatusTransition {
+	return &pb.SensorStatusTransition{
+		SensorId:       transition.SensorID,
+		FromStatus:     transition.FromStatus,
+		ToStatus:       transition.ToStatus,
+		LastSeenAt:     convertTimestamp(transition.LastSeenAt),
+		TransitionedAt: convertTimestamptz(transition.TransitionedAt),
+	}
+}
+
+func convertTrafficAnomaly(trafficAnomaly db.TrafficAnomaly) *pb.TrafficAnomaly {
+	return &pb.TrafficAnomaly{
+		AnomalyId: trafficAnomaly.AnomalyID,
+		SensorId:  trafficAnomaly.SensorID,
+		Timestamp: convertTimestamp(trafficAnomaly.Timestamp),
+		Metric:    trafficAnomaly.Metric,
+		Kind:      trafficAnomaly.Kind,
+		Observed:  trafficAnomaly.Observed,
+		Expected:  trafficAnomaly.Expected,
+		Stddev:    trafficAnomaly.Stddev,
+		ZScore:    trafficAnomaly.ZScore,
+	}
+}
+
+func convertCongestionIndex(index db.CongestionIndex) *pb.CongestionIndex {
+	return &pb.CongestionIndex{
+		Timestamp: convertTimestamp(index.Timestamp),
+		Value:     index.Value,
+		Sensors:   index.Sensors,
+		Volume:    index.Volume,
+		AvgSpeed:  index.AvgSpeed,
+	}
+}
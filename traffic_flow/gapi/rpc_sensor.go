@@ -0,0 +1,137 @@
+package gapi
+
+import (
+	"context"
+	"smart_city/traffic_flow/catalog"
+	"smart_city/traffic_flow/pb"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (server *Server) CreateSensorType(ctx context.Context, req *pb.CreateSensorTypeRequest) (*pb.SensorType, error) {
+	sensorType, err := server.catalog.CreateSensorType(ctx, catalog.CreateSensorTypeRequest{
+		TypeName:    req.GetTypeName(),
+		Description: req.GetDescription(),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return convertSensorType(sensorType), nil
+}
+
+func (server *Server) ListSensorTypes(ctx context.Context, _ *emptypb.Empty) (*pb.ListSensorTypesResponse, error) {
+	sensorTypes, err := server.store.ListSensorTypes(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	rsp := &pb.ListSensorTypesResponse{}
+	for _, sensorType := range sensorTypes {
+		rsp.SensorTypes = append(rsp.SensorTypes, convertSensorType(sensorType))
+	}
+	return rsp, nil
+}
+
+func (server *Server) GetSensorType(ctx context.Context, req *pb.GetSensorTypeRequest) (*pb.SensorType, error) {
+	sensorType, err := server.store.GetSensorType(ctx, req.GetTypeId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return convertSensorType(sensorType), nil
+}
+
+func (server *Server) UpdateSensorType(ctx context.Context, req *pb.UpdateSensorTypeRequest) (*pb.SensorType, error) {
+	sensorType, err := server.catalog.UpdateSensorType(ctx, req.GetTypeId(), catalog.UpdateSensorTypeRequest{
+		TypeName:    req.GetTypeName(),
+		Description: req.GetDescription(),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return convertSensorType(sensorType), nil
+}
+
+func (server *Server) DeleteSensorType(ctx context.Context, req *pb.DeleteSensorTypeRequest) (*emptypb.Empty, error) {
+	if err := server.catalog.DeleteSensorType(ctx, req.GetTypeId()); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (server *Server) CreateSensor(ctx context.Context, req *pb.CreateSensorRequest) (*pb.Sensor, error) {
+	sensor, err := server.catalog.CreateSensor(ctx, catalog.CreateSensorRequest{
+		Latitude:         req.GetLatitude(),
+		Longitude:        req.GetLongitude(),
+		TypeID:           req.GetTypeId(),
+		InstallationDate: req.GetInstallationDate(),
+		Status:           req.GetStatus(),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return convertSensor(sensor), nil
+}
+
+func (server *Server) ListSensors(ctx context.Context, _ *emptypb.Empty) (*pb.ListSensorsResponse, error) {
+	sensors, err := server.store.ListSensors(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	rsp := &pb.ListSensorsResponse{}
+	for _, sensor := range sensors {
+		rsp.Sensors = append(rsp.Sensors, convertListSensorsRow(sensor))
+	}
+	return rsp, nil
+}
+
+func (server *Server) GetSensor(ctx context.Context, req *pb.GetSensorRequest) (*pb.Sensor, error) {
+	sensor, err := server.store.GetSensor(ctx, req.GetSensorId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return convertSensorRow(sensor), nil
+}
+
+func (server *Server) UpdateSensor(ctx context.Context, req *pb.UpdateSensorRequest) (*pb.Sensor, error) {
+	sensor, err := server.catalog.UpdateSensor(ctx, req.GetSensorId(), catalog.UpdateSensorRequest{
+		Status: req.GetStatus(),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return convertSensor(sensor), nil
+}
+
+func (server *Server) DeleteSensor(ctx context.Context, req *pb.DeleteSensorRequest) (*emptypb.Empty, error) {
+	if err := server.catalog.DeleteSensor(ctx, req.GetSensorId()); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (server *Server) GetActiveSensors(ctx context.Context, _ *emptypb.Empty) (*pb.ListSensorsResponse, error) {
+	sensors, err := server.store.GetActiveSensors(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	rsp := &pb.ListSensorsResponse{}
+	for _, sensor := range sensors {
+		rsp.Sensors = append(rsp.Sensors, convertActiveSensorsRow(sensor))
+	}
+	return rsp, nil
+}
+
+func (server *Server) GetSensorsByType(ctx context.Context, req *pb.GetSensorsByTypeRequest) (*pb.ListSensorsResponse, error) {
+	sensors, err := server.store.GetSensorsByType(ctx, req.GetTypeId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	rsp := &pb.ListSensorsResponse{}
+	for _, sensor := range sensors {
+		rsp.Sensors = append(rsp.Sensors, convertSensorsByTypeRow(sensor, req.GetTypeId()))
+	}
+	return rsp, nil
+}
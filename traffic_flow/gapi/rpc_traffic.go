@@ -0,0 +1,165 @@
+package gapi
+
+import (
+	"errors"
+	"io"
+	db "smart_city/traffic_flow/db/sqlc"
+	"smart_city/traffic_flow/pb"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// snapshotInterval and snapshotSize match the WebSocket background updates
+	snapshotInterval = 10 * time.Second
+	snapshotSize     = 20
+	// watchBufferSize is how many updates may queue up for a slow watcher
+	// before further updates are dropped
+	watchBufferSize = 64
+)
+
+func (server *Server) RecordTraffic(stream pb.TrafficService_RecordTrafficServer) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		ack := &pb.RecordTrafficAck{Sequence: req.GetSequence()}
+
+		trafficData, outcome, err := server.ingester.Record(stream.Context(), convertReading(req.GetReading()))
+		switch {
+		case outcome == db.RowQuarantined:
+			ack.Outcome = pb.RecordOutcome_RECORD_OUTCOME_QUARANTINED
+			ack.Error = err.Error()
+			ack.Data = convertTrafficDatum(trafficData)
+		case err != nil:
+			ack.Outcome = pb.RecordOutcome_RECORD_OUTCOME_REJECTED
+			ack.Error = err.Error()
+		case outcome == db.RowSkipped:
+			ack.Outcome = pb.RecordOutcome_RECORD_OUTCOME_SKIPPED
+			ack.Data = convertTrafficDatum(trafficData)
+		case outcome == db.RowOverwritten:
+			ack.Outcome = pb.RecordOutcome_RECORD_OUTCOME_OVERWRITTEN
+			ack.Data = convertTrafficDatum(trafficData)
+		default:
+			ack.Outcome = pb.RecordOutcome_RECORD_OUTCOME_CREATED
+			ack.Data = convertTrafficDatum(trafficData)
+		}
+
+		if err := stream.Send(ack); err != nil {
+			return err
+		}
+	}
+}
+
+func (server *Server) WatchTraffic(req *pb.WatchTrafficRequest, stream pb.TrafficService_WatchTrafficServer) error {
+	ctx := stream.Context()
+
+	filter := make(map[int32]bool, len(req.GetSensorIds()))
+	for _, id := range req.GetSensorIds() {
+		filter[id] = true
+	}
+	matches := func(sensorID int32) bool {
+		return len(filter) == 0 || filter[sensorID]
+	}
+
+	updates := make(chan *pb.TrafficUpdate, watchBufferSize)
+	enqueue := func(update *pb.TrafficUpdate) {
+		select {
+		case updates <- update:
+		default:
+			log.Warn().Msg("dropping traffic update for slow gRPC watcher")
+		}
+	}
+
+	remove := server.ingester.OnRecord(func(data any) {
+		var recorded []db.TrafficDatum
+		switch value := data.(type) {
+		case db.TrafficDatum:
+			recorded = []db.TrafficDatum{value}
+		case []db.TrafficDatum:
+			recorded = value
+		}
+
+		update := &pb.TrafficUpdate{Kind: pb.TrafficUpdate_KIND_RECORDED}
+		for _, trafficData := range recorded {
+			if matches(trafficData.SensorID) {
+				update.Data = append(update.Data, convertTrafficDatum(trafficData))
+			}
+		}
+		if len(update.Data) == 0 {
+			return
+		}
+		enqueue(update)
+	})
+	defer remove()
+
+	// The events the WebSocket broadcasts besides readings
+	removeStatus := server.liveness.OnTransition(func(transition db.SensorStatusTransition) {
+		if matches(transition.SensorID) {
+			enqueue(&pb.TrafficUpdate{
+				Kind:         pb.TrafficUpdate_KIND_SENSOR_STATUS,
+				SensorStatus: convertSensorStatusTransition(transition),
+			})
+		}
+	})
+	defer removeStatus()
+
+	removeAnomaly := server.anomalies.OnAnomaly(func(trafficAnomaly db.TrafficAnomaly) {
+		if matches(trafficAnomaly.SensorID) {
+			enqueue(&pb.TrafficUpdate{
+				Kind:    pb.TrafficUpdate_KIND_ANOMALY,
+				Anomaly: convertTrafficAnomaly(trafficAnomaly),
+			})
+		}
+	})
+	defer removeAnomaly()
+
+	removeIndex := server.congestionIndex.OnIndex(func(index db.CongestionIndex) {
+		enqueue(&pb.TrafficUpdate{
+			Kind:            pb.TrafficUpdate_KIND_CONGESTION_INDEX,
+			CongestionIndex: convertCongestionIndex(index),
+		})
+	})
+	defer removeIndex()
+
+	ticker := time.NewTicker(snapshotInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-server.done:
+			return nil
+		case update := <-updates:
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			latest, err := server.store.GetLatestTrafficData(ctx, snapshotSize)
+			if err != nil {
+				log.Error().Err(err).Msg("Error getting latest traffic data for gRPC watcher")
+				continue
+			}
+
+			update := &pb.TrafficUpdate{Kind: pb.TrafficUpdate_KIND_SNAPSHOT}
+			for _, trafficData := range latest {
+				if matches(trafficData.SensorID) {
+					update.Data = append(update.Data, convertLatestTrafficData(trafficData))
+				}
+			}
+			if len(update.Data) == 0 {
+				continue
+			}
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+		}
+	}
+}
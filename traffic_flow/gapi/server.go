@@ -0,0 +1,75 @@
+package gapi
+
+import (
+	"errors"
+	"smart_city/traffic_flow/anomaly"
+	"smart_city/traffic_flow/catalog"
+	"smart_city/traffic_flow/cityindex"
+	db "smart_city/traffic_flow/db/sqlc"
+	"smart_city/traffic_flow/ingest"
+	"smart_city/traffic_flow/liveness"
+	"smart_city/traffic_flow/pb"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server serves gRPC requests for the traffic flow service. It shares the
+// store, ingest and catalog services with the HTTP server, and streams the
+// same liveness, anomaly and congestion index events.
+type Server struct {
+	pb.UnimplementedTrafficServiceServer
+	pb.UnimplementedSensorServiceServer
+	store           *db.Store
+	ingester        *ingest.Service
+	catalog         *catalog.Service
+	liveness        *liveness.Monitor
+	anomalies       *anomaly.Detector
+	congestionIndex *cityindex.Service
+	// done is closed on Shutdown to end the running WatchTraffic streams
+	done     chan struct{}
+	shutdown sync.Once
+}
+
+func NewServer(store *db.Store, ingester *ingest.Service, catalog *catalog.Service, monitor *liveness.Monitor, detector *anomaly.Detector, congestionIndex *cityindex.Service) (*Server, error) {
+	server := &Server{
+		store:           store,
+		ingester:        ingester,
+		catalog:         catalog,
+		liveness:        monitor,
+		anomalies:       detector,
+		congestionIndex: congestionIndex,
+		done:            make(chan struct{}),
+	}
+	return server, nil
+}
+
+// Shutdown ends the WatchTraffic streams, which would otherwise keep a
+// graceful stop of the gRPC server waiting
+func (server *Server) Shutdown() {
+	server.shutdown.Do(func() {
+		close(server.done)
+	})
+}
+
+// statusError converts a service or store error into a gRPC status error
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, catalog.ErrInvalidRequest),
+		errors.Is(err, ingest.ErrInvalidReading),
+		errors.Is(err, ingest.ErrTimestampTooOld),
+		errors.Is(err, ingest.ErrTimestampInFuture):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, db.ErrDuplicateReading):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, db.ErrUnknownSensor),
+		errors.Is(err, ingest.ErrInactiveSensor):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, pgx.ErrNoRows):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "internal error: %s", err)
+	}
+}
@@ -0,0 +1,105 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidCoordinates = errors.New("invalid coordinates")
+	ErrInvalidBox         = errors.New("invalid bounding box")
+)
+
+// EarthRadius is the mean radius of the earth in meters
+const EarthRadius = 6371008.8
+
+// metersPerDegree is the length of a degree of latitude
+const metersPerDegree = math.Pi * EarthRadius / 180
+
+// CheckCoordinates rejects latitudes outside ±90 and longitudes outside ±180
+func CheckCoordinates(lat, lon float64) error {
+	if math.IsNaN(lat) || math.IsNaN(lon) || lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return fmt.Errorf("%w (%v, %v)", ErrInvalidCoordinates, lat, lon)
+	}
+	return nil
+}
+
+// Distance is the great-circle distance in meters between two points
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	dLat := radians(lat2 - lat1)
+	dLon := radians(lon2 - lon1)
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(radians(lat1))*math.Cos(radians(lat2))*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+// Box is a longitude/latitude rectangle
+type Box struct {
+	MinLon float64 `json:"min_lon"`
+	MinLat float64 `json:"min_lat"`
+	MaxLon float64 `json:"max_lon"`
+	MaxLat float64 `json:"max_lat"`
+}
+
+// World covers every coordinate
+var World = Box{MinLon: -180, MinLat: -90, MaxLon: 180, MaxLat: 90}
+
+// ParseBox reads a box given as "min_lon,min_lat,max_lon,max_lat", the
+// order GeoJSON uses
+func ParseBox(value string) (Box, error) {
+	fields := strings.Split(value, ",")
+	if len(fields) != 4 {
+		return Box{}, fmt.Errorf("%w %q, want min_lon,min_lat,max_lon,max_lat", ErrInvalidBox, value)
+	}
+
+	var numbers [4]float64
+	for i, field := range fields {
+		number, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return Box{}, fmt.Errorf("%w %q, want min_lon,min_lat,max_lon,max_lat", ErrInvalidBox, value)
+		}
+		numbers[i] = number
+	}
+
+	box := Box{MinLon: numbers[0], MinLat: numbers[1], MaxLon: numbers[2], MaxLat: numbers[3]}
+	if err := CheckCoordinates(box.MinLat, box.MinLon); err != nil {
+		return Box{}, fmt.Errorf("%w %q: %v", ErrInvalidBox, value, err)
+	}
+	if err := CheckCoordinates(box.MaxLat, box.MaxLon); err != nil {
+		return Box{}, fmt.Errorf("%w %q: %v", ErrInvalidBox, value, err)
+	}
+	if box.MinLon > box.MaxLon || box.MinLat > box.MaxLat {
+		return Box{}, fmt.Errorf("%w %q, minimum exceeds maximum", ErrInvalidBox, value)
+	}
+	return box, nil
+}
+
+// BoundingBox returns a box containing every point within radius meters of
+// (lat, lon). Near the poles or across the antimeridian it spans all
+// longitudes.
+func BoundingBox(lat, lon, radius float64) Box {
+	dLat := radius / metersPerDegree
+	box := Box{
+		MinLat: math.Max(lat-dLat, -90),
+		MaxLat: math.Min(lat+dLat, 90),
+		MinLon: -180,
+		MaxLon: 180,
+	}
+	if box.MinLat == -90 || box.MaxLat == 90 {
+		return box
+	}
+
+	// Longitude degrees are shortest at the latitude farthest from the equator
+	widest := math.Max(math.Abs(box.MinLat), math.Abs(box.MaxLat))
+	dLon := dLat / math.Cos(radians(widest))
+	if lon-dLon >= -180 && lon+dLon <= 180 {
+		box.MinLon, box.MaxLon = lon-dLon, lon+dLon
+	}
+	return box
+}
@@ -0,0 +1,61 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistance(t *testing.T) {
+	require.Zero(t, Distance(12.97, 77.59, 12.97, 77.59))
+	// One degree of latitude
+	require.InDelta(t, 111195, Distance(0, 0, 1, 0), 1)
+	// Chennai to Bengaluru
+	require.InDelta(t, 290000, Distance(13.0827, 80.2707, 12.9716, 77.5946), 5000)
+}
+
+func TestBoundingBox(t *testing.T) {
+	lat, lon, radius := 48.85, 2.35, 1000.0
+	box := BoundingBox(lat, lon, radius)
+
+	// Points on the circle in every direction lie inside the box
+	for _, point := range [][2]float64{
+		{lat + radius/metersPerDegree, lon},
+		{lat - radius/metersPerDegree, lon},
+		{lat, lon + 0.0136},
+		{lat, lon - 0.0136},
+	} {
+		require.LessOrEqual(t, Distance(lat, lon, point[0], point[1]), radius+1)
+		require.True(t, point[0] >= box.MinLat && point[0] <= box.MaxLat)
+		require.True(t, point[1] >= box.MinLon && point[1] <= box.MaxLon)
+	}
+
+	// Across the antimeridian every longitude is included
+	box = BoundingBox(0, 179.999, 1000)
+	require.Equal(t, -180.0, box.MinLon)
+	require.Equal(t, 180.0, box.MaxLon)
+}
+
+func TestParseBox(t *testing.T) {
+	box, err := ParseBox("77.5, 12.9,77.7,13.1")
+	require.NoError(t, err)
+	require.Equal(t, Box{MinLon: 77.5, MinLat: 12.9, MaxLon: 77.7, MaxLat: 13.1}, box)
+
+	for _, value := range []string{"", "1,2,3", "a,b,c,d", "77.7,12.9,77.5,13.1", "0,-91,1,0", "0,0,181,1"} {
+		_, err := ParseBox(value)
+		require.ErrorIs(t, err, ErrInvalidBox, value)
+	}
+}
+
+func TestGeohash(t *testing.T) {
+	require.Equal(t, "u4pruydqqvj", Geohash(57.64911, 10.40744, 11))
+	require.Equal(t, "tdr1", Geohash(12.9716, 77.5946, 4))
+
+	box, err := GeohashBox("u4pruydqqvj")
+	require.NoError(t, err)
+	require.True(t, box.MinLat <= 57.64911 && 57.64911 <= box.MaxLat)
+	require.True(t, box.MinLon <= 10.40744 && 10.40744 <= box.MaxLon)
+
+	_, err = GeohashBox("abc")
+	require.ErrorIs(t, err, ErrInvalidGeohash)
+}
@@ -0,0 +1,92 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidGeohash = errors.New("invalid geohash")
+
+// Geohash precisions accepted for aggregation; precision 1 cells span
+// thousands of kilometers, precision 9 cells a few meters
+const (
+	MinGeohashPrecision = 1
+	MaxGeohashPrecision = 9
+)
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// Geohash encodes a point as a geohash of the given number of characters
+func Geohash(lat, lon float64, precision int) string {
+	minLat, maxLat := -90.0, 90.0
+	minLon, maxLon := -180.0, 180.0
+
+	var hash strings.Builder
+	bit, index, even := 0, 0, true
+	for hash.Len() < precision {
+		// Bits alternate between longitude and latitude, longitude first
+		if even {
+			mid := (minLon + maxLon) / 2
+			if lon >= mid {
+				index = index<<1 | 1
+				minLon = mid
+			} else {
+				index <<= 1
+				maxLon = mid
+			}
+		} else {
+			mid := (minLat + maxLat) / 2
+			if lat >= mid {
+				index = index<<1 | 1
+				minLat = mid
+			} else {
+				index <<= 1
+				maxLat = mid
+			}
+		}
+		even = !even
+
+		if bit++; bit == 5 {
+			hash.WriteByte(geohashAlphabet[index])
+			bit, index = 0, 0
+		}
+	}
+	return hash.String()
+}
+
+// GeohashBox returns the cell a geohash stands for
+func GeohashBox(hash string) (Box, error) {
+	box := Box{MinLat: -90, MaxLat: 90, MinLon: -180, MaxLon: 180}
+	if hash == "" {
+		return box, fmt.Errorf("%w %q", ErrInvalidGeohash, hash)
+	}
+
+	even := true
+	for _, char := range hash {
+		index := strings.IndexRune(geohashAlphabet, char)
+		if index < 0 {
+			return box, fmt.Errorf("%w %q", ErrInvalidGeohash, hash)
+		}
+		for shift := 4; shift >= 0; shift-- {
+			set := index>>shift&1 == 1
+			if even {
+				mid := (box.MinLon + box.MaxLon) / 2
+				if set {
+					box.MinLon = mid
+				} else {
+					box.MaxLon = mid
+				}
+			} else {
+				mid := (box.MinLat + box.MaxLat) / 2
+				if set {
+					box.MinLat = mid
+				} else {
+					box.MaxLat = mid
+				}
+			}
+			even = !even
+		}
+	}
+	return box, nil
+}
@@ -0,0 +1,103 @@
+package geo
+
+import (
+	db "smart_city/traffic_flow/db/sqlc"
+	"sort"
+)
+
+// Cell aggregates the readings of the sensors inside one geohash cell
+type Cell struct {
+	Geohash     string  `json:"geohash"`
+	Bounds      Box     `json:"bounds"`
+	Sensors     int     `json:"sensors"`
+	Readings    int64   `json:"readings"`
+	TotalVolume int64   `json:"total_volume"`
+	AvgSpeed    float64 `json:"avg_speed"`
+	// HighCongestionShare is the fraction of readings classified high
+	HighCongestionShare float64 `json:"high_congestion_share"`
+}
+
+// Heatmap groups per-sensor totals into geohash cells of the given
+// precision, ordered by geohash. Speeds are averaged over readings, so busy
+// sensors weigh more.
+func Heatmap(rows []db.GetSensorTrafficSummariesRow, precision int) []Cell {
+	byHash := make(map[string]*Cell)
+	sumSpeed := make(map[string]float64)
+	highCount := make(map[string]int64)
+
+	for _, row := range rows {
+		if row.Readings == 0 {
+			continue
+		}
+		hash := Geohash(row.Latitude, row.Longitude, precision)
+		cell, ok := byHash[hash]
+		if !ok {
+			bounds, _ := GeohashBox(hash)
+			cell = &Cell{Geohash: hash, Bounds: bounds}
+			byHash[hash] = cell
+		}
+		cell.Sensors++
+		cell.Readings += row.Readings
+		cell.TotalVolume += row.SumVolume
+		sumSpeed[hash] += row.SumSpeed
+		highCount[hash] += row.HighCount
+	}
+
+	cells := make([]Cell, 0, len(byHash))
+	for hash, cell := range byHash {
+		cell.AvgSpeed = sumSpeed[hash] / float64(cell.Readings)
+		cell.HighCongestionShare = float64(highCount[hash]) / float64(cell.Readings)
+		cells = append(cells, *cell)
+	}
+	sort.Slice(cells, func(i, j int) bool {
+		return cells[i].Geohash < cells[j].Geohash
+	})
+	return cells
+}
+
+// Feature is a GeoJSON feature
+type Feature struct {
+	Type       string   `json:"type"`
+	Geometry   Geometry `json:"geometry"`
+	Properties any      `json:"properties"`
+}
+
+// Geometry is a GeoJSON polygon
+type Geometry struct {
+	Type        string         `json:"type"`
+	Coordinates [][][2]float64 `json:"coordinates"`
+}
+
+// FeatureCollection is a GeoJSON feature collection
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// Polygon returns the box as a GeoJSON polygon, counterclockwise
+func (box Box) Polygon() Geometry {
+	return Geometry{
+		Type: "Polygon",
+		Coordinates: [][][2]float64{{
+			{box.MinLon, box.MinLat},
+			{box.MaxLon, box.MinLat},
+			{box.MaxLon, box.MaxLat},
+			{box.MinLon, box.MaxLat},
+			{box.MinLon, box.MinLat},
+		}},
+	}
+}
+
+// HeatmapFeatures renders cells as GeoJSON polygons with the aggregates as
+// properties
+func HeatmapFeatures(cells []Cell) FeatureCollection {
+	collection := FeatureCollection{Type: "FeatureCollection", Features: make([]Feature, len(cells))}
+	for i, cell := range cells {
+		collection.Features[i] = Feature{
+			Type:       "Feature",
+			Geometry:   cell.Bounds.Polygon(),
+			Properties: cell,
+		}
+	}
+	return collection
+}
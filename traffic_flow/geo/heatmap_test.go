@@ -0,0 +1,39 @@
+package geo
+
+import (
+	db "smart_city/traffic_flow/db/sqlc"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeatmap(t *testing.T) {
+	rows := []db.GetSensorTrafficSummariesRow{
+		{SensorID: 1, Latitude: 12.9716, Longitude: 77.5946, Readings: 10, SumVolume: 1000, SumSpeed: 300, HighCount: 5},
+		{SensorID: 2, Latitude: 12.9717, Longitude: 77.5947, Readings: 30, SumVolume: 2000, SumSpeed: 1500, HighCount: 0},
+		{SensorID: 3, Latitude: 13.0827, Longitude: 80.2707, Readings: 4, SumVolume: 80, SumSpeed: 200, HighCount: 4},
+		{SensorID: 4, Latitude: 13.0827, Longitude: 80.2707},
+	}
+
+	cells := Heatmap(rows, 5)
+	require.Len(t, cells, 2)
+
+	bengaluru := cells[0]
+	require.Equal(t, Geohash(12.9716, 77.5946, 5), bengaluru.Geohash)
+	require.Equal(t, 2, bengaluru.Sensors)
+	require.Equal(t, int64(40), bengaluru.Readings)
+	require.Equal(t, int64(3000), bengaluru.TotalVolume)
+	require.InDelta(t, 45, bengaluru.AvgSpeed, 1e-9)
+	require.InDelta(t, 0.125, bengaluru.HighCongestionShare, 1e-9)
+
+	chennai := cells[1]
+	require.Equal(t, 1, chennai.Sensors)
+	require.Equal(t, 1.0, chennai.HighCongestionShare)
+
+	collection := HeatmapFeatures(cells)
+	require.Equal(t, "FeatureCollection", collection.Type)
+	require.Len(t, collection.Features, 2)
+	ring := collection.Features[0].Geometry.Coordinates[0]
+	require.Len(t, ring, 5)
+	require.Equal(t, ring[0], ring[4])
+}
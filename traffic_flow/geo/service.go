@@ -0,0 +1,71 @@
+package geo
+
+import (
+	"context"
+	"fmt"
+	"smart_city/traffic_flow/catalog"
+	db "smart_city/traffic_flow/db/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// firstNearestRadius is where the search for the nearest sensors starts;
+// each round widens it fourfold
+const firstNearestRadius = 500
+
+// Service looks sensors up by location
+type Service struct {
+	store *db.Store
+}
+
+func NewService(store *db.Store) *Service {
+	return &Service{store: store}
+}
+
+// Within returns the sensors inside a box ordered by id. An empty status
+// matches all.
+func (service *Service) Within(ctx context.Context, box Box, status string, limit int32) ([]db.ListSensorsWithinBoxRow, error) {
+	return service.store.ListSensorsWithinBox(ctx, db.ListSensorsWithinBoxParams{
+		MinLon:   box.MinLon,
+		MinLat:   box.MinLat,
+		MaxLon:   box.MaxLon,
+		MaxLat:   box.MaxLat,
+		Status:   pgtype.Text{String: status, Valid: status != ""},
+		RowLimit: limit,
+	})
+}
+
+// Near returns up to limit sensors within radius meters of (lat, lon),
+// nearest first
+func (service *Service) Near(ctx context.Context, lat, lon, radius float64, status string, limit int32) ([]db.ListSensorsNearRow, error) {
+	if err := CheckCoordinates(lat, lon); err != nil {
+		return nil, fmt.Errorf("%w: %v", catalog.ErrInvalidRequest, err)
+	}
+
+	box := BoundingBox(lat, lon, radius)
+	return service.store.ListSensorsNear(ctx, db.ListSensorsNearParams{
+		Lat:      lat,
+		Lon:      lon,
+		MinLon:   box.MinLon,
+		MinLat:   box.MinLat,
+		MaxLon:   box.MaxLon,
+		MaxLat:   box.MaxLat,
+		Status:   pgtype.Text{String: status, Valid: status != ""},
+		RadiusM:  radius,
+		RowLimit: limit,
+	})
+}
+
+// Nearest returns the k sensors nearest to (lat, lon) but no farther than
+// maxRadius meters. The search radius grows until k sensors are found;
+// every sensor within it is considered, so the result is exact.
+func (service *Service) Nearest(ctx context.Context, lat, lon float64, k int32, maxRadius float64, status string) ([]db.ListSensorsNearRow, error) {
+	radius := min(float64(firstNearestRadius), maxRadius)
+	for {
+		sensors, err := service.Near(ctx, lat, lon, radius, status, k)
+		if err != nil || int32(len(sensors)) >= k || radius >= maxRadius {
+			return sensors, err
+		}
+		radius = min(radius*4, maxRadius)
+	}
+}
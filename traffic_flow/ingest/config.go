@@ -0,0 +1,163 @@
+package ingest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	db "smart_city/traffic_flow/db/sqlc"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrInvalidReading    = errors.New("invalid reading")
+	ErrTimestampTooOld   = errors.New("timestamp is older than the allowed ingestion window")
+	ErrTimestampInFuture = errors.New("timestamp is too far in the future")
+	ErrInactiveSensor    = errors.New("sensor is not active")
+	ErrQuarantined       = errors.New("reading quarantined")
+)
+
+// SensorPolicy decides what happens to readings from sensors that are
+// unknown or not active
+type SensorPolicy string
+
+const (
+	// SensorQuarantine stores such readings in traffic_data_quarantine
+	SensorQuarantine SensorPolicy = "quarantine"
+	// SensorReject refuses them outright
+	SensorReject SensorPolicy = "reject"
+)
+
+// Config controls how client-supplied measurement timestamps are accepted
+// and how primary key collisions are resolved.
+type Config struct {
+	// MaxPastSkew is how far in the past a timestamp may lie; 0 disables the check
+	MaxPastSkew time.Duration
+	// MaxFutureSkew is how far in the future a timestamp may lie
+	MaxFutureSkew time.Duration
+	// LateThreshold is the age after which a reading is flagged as a late arrival
+	LateThreshold time.Duration
+	OnDuplicate   db.DuplicatePolicy
+	// OnInactiveSensor applies to readings from unknown or non-active sensors
+	OnInactiveSensor SensorPolicy
+	// SensorCacheRefresh is how often the sensor cache is fully reloaded
+	SensorCacheRefresh time.Duration
+	Queue              QueueConfig
+}
+
+// LoadConfig reads the ingestion settings from the environment, falling
+// back to defaults for unset variables
+func LoadConfig() (Config, error) {
+	config := Config{
+		MaxPastSkew:   24 * time.Hour,
+		MaxFutureSkew: time.Minute,
+		LateThreshold: 5 * time.Minute,
+		OnDuplicate:   db.DuplicateReject,
+
+		OnInactiveSensor:   SensorQuarantine,
+		SensorCacheRefresh: time.Minute,
+
+		Queue: QueueConfig{
+			Size:          10000,
+			Workers:       4,
+			FlushSize:     500,
+			FlushInterval: time.Second,
+			FlushAttempts: 5,
+			RetryBackoff:  500 * time.Millisecond,
+		},
+	}
+
+	durations := map[string]*time.Duration{
+		"INGEST_MAX_PAST_SKEW":        &config.MaxPastSkew,
+		"INGEST_MAX_FUTURE_SKEW":      &config.MaxFutureSkew,
+		"INGEST_LATE_THRESHOLD":       &config.LateThreshold,
+		"INGEST_SENSOR_CACHE_REFRESH": &config.SensorCacheRefresh,
+		"INGEST_FLUSH_INTERVAL":       &config.Queue.FlushInterval,
+		"INGEST_RETRY_BACKOFF":        &config.Queue.RetryBackoff,
+	}
+	for key, target := range durations {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return config, fmt.Errorf("cannot parse %s: %w", key, err)
+		}
+		*target = duration
+	}
+
+	if value := os.Getenv("INGEST_DUPLICATE_POLICY"); value != "" {
+		policy := db.DuplicatePolicy(value)
+		switch policy {
+		case db.DuplicateReject, db.DuplicateOverwrite, db.DuplicateKeepFirst:
+			config.OnDuplicate = policy
+		default:
+			return config, fmt.Errorf("invalid INGEST_DUPLICATE_POLICY %q", value)
+		}
+	}
+
+	if value := os.Getenv("INGEST_INACTIVE_SENSOR_POLICY"); value != "" {
+		policy := SensorPolicy(value)
+		if policy != SensorQuarantine && policy != SensorReject {
+			return config, fmt.Errorf("invalid INGEST_INACTIVE_SENSOR_POLICY %q", value)
+		}
+		config.OnInactiveSensor = policy
+	}
+
+	if config.SensorCacheRefresh <= 0 {
+		return config, fmt.Errorf("INGEST_SENSOR_CACHE_REFRESH must be positive")
+	}
+
+	if value := os.Getenv("INGEST_ASYNC"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return config, fmt.Errorf("cannot parse INGEST_ASYNC: %w", err)
+		}
+		config.Queue.Enabled = enabled
+	}
+
+	sizes := map[string]*int{
+		"INGEST_QUEUE_SIZE":     &config.Queue.Size,
+		"INGEST_QUEUE_WORKERS":  &config.Queue.Workers,
+		"INGEST_FLUSH_SIZE":     &config.Queue.FlushSize,
+		"INGEST_FLUSH_ATTEMPTS": &config.Queue.FlushAttempts,
+	}
+	for key, target := range sizes {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		size, err := strconv.Atoi(value)
+		if err != nil || size < 1 {
+			return config, fmt.Errorf("invalid %s %q, must be a positive integer", key, value)
+		}
+		*target = size
+	}
+
+	if config.Queue.FlushInterval <= 0 || config.Queue.RetryBackoff <= 0 {
+		return config, fmt.Errorf("INGEST_FLUSH_INTERVAL and INGEST_RETRY_BACKOFF must be positive")
+	}
+
+	return config, nil
+}
+
+// ResolveTimestamp returns the measurement time for a reading received at
+// now, and whether it counts as a late arrival. Readings without a
+// timestamp are stamped with the receive time.
+func (config Config) ResolveTimestamp(ts *time.Time, now time.Time) (time.Time, bool, error) {
+	now = now.UTC().Truncate(time.Microsecond)
+	if ts == nil {
+		return now, false, nil
+	}
+
+	measured := ts.UTC().Truncate(time.Microsecond)
+	if measured.After(now.Add(config.MaxFutureSkew)) {
+		return measured, false, ErrTimestampInFuture
+	}
+	if config.MaxPastSkew > 0 && measured.Before(now.Add(-config.MaxPastSkew)) {
+		return measured, false, ErrTimestampTooOld
+	}
+
+	return measured, now.Sub(measured) > config.LateThreshold, nil
+}
@@ -0,0 +1,170 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"smart_city/traffic_flow/catalog"
+	db "smart_city/traffic_flow/db/sqlc"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Quarantine reasons stored with every quarantined reading
+const (
+	ReasonUnknownSensor  = "unknown_sensor"
+	ReasonInactiveSensor = "inactive_sensor"
+	// ReasonWriteFailed readings were accepted by the queue but could not
+	// be written to traffic_data
+	ReasonWriteFailed = "write_failed"
+)
+
+// sensorCheck is the outcome of looking a sensor up in the cache; an empty
+// reason means its readings may be stored
+type sensorCheck struct {
+	reason string
+	status pgtype.Text
+	typeID int32
+}
+
+func (check sensorCheck) err() error {
+	if check.reason == ReasonUnknownSensor {
+		return db.ErrUnknownSensor
+	}
+	return fmt.Errorf("%w: status is %q", ErrInactiveSensor, check.status.String)
+}
+
+func (service *Service) checkSensor(ctx context.Context, sensorID int32) (sensorCheck, error) {
+	state, ok, err := service.sensors.Lookup(ctx, sensorID)
+	switch {
+	case err != nil:
+		return sensorCheck{}, err
+	case !ok:
+		return sensorCheck{reason: ReasonUnknownSensor}, nil
+	case !catalog.AcceptsReadings(state.Status):
+		return sensorCheck{
+			reason: ReasonInactiveSensor,
+			status: pgtype.Text{String: state.Status, Valid: true},
+			typeID: state.TypeID,
+		}, nil
+	default:
+		return sensorCheck{typeID: state.TypeID}, nil
+	}
+}
+
+// quarantine diverts a reading that failed the sensor check. Depending on
+// the configured policy it is either stored in quarantine or rejected; in
+// both cases the returned error explains why.
+func (service *Service) quarantine(ctx context.Context, arg db.RecordTrafficDataParams, check sensorCheck) (db.TrafficDatum, db.RowOutcome, error) {
+	if service.config.OnInactiveSensor == SensorReject {
+		return db.TrafficDatum{}, db.RowRejected, check.err()
+	}
+
+	_, err := service.store.QuarantineTrafficData(ctx, db.QuarantineTrafficDataParams{
+		SensorID:        arg.SensorID,
+		Timestamp:       arg.Timestamp,
+		TrafficVolume:   arg.TrafficVolume,
+		AverageSpeed:    arg.AverageSpeed,
+		CongestionLevel: arg.CongestionLevel,
+		IsLate:          arg.IsLate,
+		Reason:          check.reason,
+		SensorStatus:    check.status,
+
+		ReportedCongestionLevel: arg.ReportedCongestionLevel,
+	})
+	if err != nil {
+		return db.TrafficDatum{}, db.RowRejected, err
+	}
+	return db.TrafficDatum(arg), db.RowQuarantined, fmt.Errorf("%w: %w", ErrQuarantined, check.err())
+}
+
+// quarantineBatch stores readings in quarantine for reason, as the dead
+// letter of a batch that could not be written
+func (service *Service) quarantineBatch(ctx context.Context, entries []BatchEntry, reason string) error {
+	now := time.Now()
+	rows := make([]db.CopyQuarantinedTrafficDataParams, 0, len(entries))
+	for _, entry := range entries {
+		reading := entry.Reading
+		check, err := service.checkSensor(ctx, reading.SensorID)
+		if err != nil {
+			return err
+		}
+
+		// The reading passed the timestamp checks when it was accepted
+		timestamp, isLate, err := service.config.ResolveTimestamp(reading.Timestamp, now)
+		if err != nil {
+			timestamp, isLate = reading.Timestamp.UTC(), true
+		}
+
+		volume, speed := *reading.TrafficVolume, *reading.AverageSpeed
+		rows = append(rows, db.CopyQuarantinedTrafficDataParams{
+			SensorID:        reading.SensorID,
+			Timestamp:       pgtype.Timestamp{Time: timestamp, Valid: true},
+			TrafficVolume:   volume,
+			AverageSpeed:    speed,
+			CongestionLevel: service.classifier.Classify(reading.SensorID, check.typeID, volume, speed),
+			IsLate:          isLate,
+			Reason:          reason,
+			SensorStatus:    check.status,
+
+			ReportedCongestionLevel: reading.reportedLevel(),
+		})
+	}
+
+	_, err := service.store.CopyQuarantinedTrafficData(ctx, rows)
+	return err
+}
+
+type ReleasedRow struct {
+	QuarantineID int64         `json:"quarantine_id"`
+	SensorID     int32         `json:"sensor_id"`
+	Status       db.RowOutcome `json:"status"`
+	Error        string        `json:"error,omitempty"`
+}
+
+type ReleaseResult struct {
+	Released int           `json:"released"`
+	Skipped  int           `json:"skipped"`
+	Kept     int           `json:"kept"`
+	Results  []ReleasedRow `json:"results"`
+}
+
+// ReleaseQuarantine moves up to limit quarantined readings, selected by id
+// and/or sensor, into traffic_data using the configured duplicate policy.
+// Readings that still cannot be stored are kept in quarantine.
+func (service *Service) ReleaseQuarantine(ctx context.Context, quarantineIDs []int64, sensorID pgtype.Int4, limit int32) (ReleaseResult, error) {
+	result, err := service.store.ReleaseQuarantinedTrafficTx(ctx, db.ReleaseQuarantinedTrafficTxParams{
+		QuarantineIDs: quarantineIDs,
+		SensorID:      sensorID,
+		Limit:         limit,
+		OnDuplicate:   service.config.OnDuplicate,
+	})
+	if err != nil {
+		return ReleaseResult{}, err
+	}
+
+	rsp := ReleaseResult{Results: make([]ReleasedRow, len(result.Rows))}
+	for i, row := range result.Rows {
+		rsp.Results[i] = ReleasedRow{
+			QuarantineID: row.QuarantineID,
+			SensorID:     row.SensorID,
+			Status:       result.Outcomes[i],
+		}
+		switch result.Outcomes[i] {
+		case db.RowCreated, db.RowOverwritten:
+			rsp.Released++
+		case db.RowSkipped:
+			rsp.Skipped++
+		default:
+			rsp.Kept++
+			if result.Errors[i] != nil {
+				rsp.Results[i].Error = result.Errors[i].Error()
+			}
+		}
+	}
+
+	if len(result.Inserted) > 0 {
+		service.notify(result.Inserted)
+	}
+	return rsp, nil
+}
@@ -0,0 +1,254 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	db "smart_city/traffic_flow/db/sqlc"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrQueueFull   = errors.New("ingestion queue is full")
+	ErrQueueClosed = errors.New("ingestion queue is shutting down")
+)
+
+// QueueConfig controls the optional write-behind queue
+type QueueConfig struct {
+	Enabled bool
+	// Size is the capacity of the queue in readings
+	Size    int
+	Workers int
+	// FlushSize and FlushInterval bound how long a reading waits in a
+	// worker's batch before it is written
+	FlushSize     int
+	FlushInterval time.Duration
+	// FlushAttempts bounds how often a batch is written before it is
+	// moved to quarantine; RetryBackoff is the wait after the first failed
+	// attempt, doubling after each further one
+	FlushAttempts int
+	RetryBackoff  time.Duration
+}
+
+// maxRetryBackoff caps the wait between two attempts to write a batch
+const maxRetryBackoff = 30 * time.Second
+
+// QueueStats is a snapshot of the queue's counters
+type QueueStats struct {
+	Depth    int    `json:"depth"`
+	Capacity int    `json:"capacity"`
+	Workers  int    `json:"workers"`
+	Enqueued uint64 `json:"enqueued"`
+	Written  uint64 `json:"written"`
+	// Rejected readings were refused by the store, e.g. duplicates
+	Rejected uint64 `json:"rejected"`
+	// Retried readings belonged to a batch whose write failed and was
+	// attempted again
+	Retried uint64 `json:"retried"`
+	// DeadLettered readings could not be written after all attempts and
+	// were moved to quarantine with reason write_failed
+	DeadLettered uint64 `json:"dead_lettered"`
+	// Dropped readings were refused because the queue was full or lost
+	// because neither their batch nor its dead letter could be written
+	Dropped uint64 `json:"dropped"`
+}
+
+// Queue decouples accepting readings from writing them. Readings are
+// validated on Enqueue, buffered in a bounded channel and written in
+// batches by a pool of workers through the ingest service.
+type Queue struct {
+	service *Service
+	config  QueueConfig
+	entries chan BatchEntry
+
+	mu     sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+
+	enqueued     atomic.Uint64
+	written      atomic.Uint64
+	rejected     atomic.Uint64
+	retried      atomic.Uint64
+	deadLettered atomic.Uint64
+	dropped      atomic.Uint64
+}
+
+// NewQueue creates the queue and starts its workers
+func NewQueue(service *Service, config QueueConfig) *Queue {
+	queue := &Queue{
+		service: service,
+		config:  config,
+		entries: make(chan BatchEntry, config.Size),
+	}
+
+	queue.wg.Add(config.Workers)
+	for range config.Workers {
+		go queue.work()
+	}
+	return queue
+}
+
+// Enqueue validates a reading and hands it to the workers without waiting
+// for the write. It fails with ErrQueueFull when the queue is saturated and
+// with ErrQueueClosed once shutdown has begun.
+func (queue *Queue) Enqueue(reading Reading) error {
+	if err := reading.Validate(); err != nil {
+		return err
+	}
+
+	// Stamp the receive time now rather than when the batch is flushed
+	now := time.Now()
+	if reading.Timestamp == nil {
+		reading.Timestamp = &now
+	}
+	if _, _, err := queue.service.config.ResolveTimestamp(reading.Timestamp, now); err != nil {
+		return err
+	}
+
+	queue.mu.RLock()
+	defer queue.mu.RUnlock()
+	if queue.closed {
+		return ErrQueueClosed
+	}
+
+	select {
+	case queue.entries <- BatchEntry{Reading: reading}:
+		queue.enqueued.Add(1)
+		return nil
+	default:
+		queue.dropped.Add(1)
+		return ErrQueueFull
+	}
+}
+
+// RetryAfter is how long a client should wait before retrying a refused
+// reading: roughly the time the workers need to drain a flush
+func (queue *Queue) RetryAfter() time.Duration {
+	return max(queue.config.FlushInterval, time.Second)
+}
+
+func (queue *Queue) Stats() QueueStats {
+	return QueueStats{
+		Depth:    len(queue.entries),
+		Capacity: cap(queue.entries),
+		Workers:  queue.config.Workers,
+		Enqueued: queue.enqueued.Load(),
+		Written:  queue.written.Load(),
+		Rejected: queue.rejected.Load(),
+		Retried:  queue.retried.Load(),
+		Dropped:  queue.dropped.Load(),
+
+		DeadLettered: queue.deadLettered.Load(),
+	}
+}
+
+// Close stops accepting readings and waits until the workers have written
+// everything still queued, or until ctx is done.
+func (queue *Queue) Close(ctx context.Context) error {
+	queue.mu.Lock()
+	if !queue.closed {
+		queue.closed = true
+		close(queue.entries)
+	}
+	queue.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		queue.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (queue *Queue) work() {
+	defer queue.wg.Done()
+
+	ticker := time.NewTicker(queue.config.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]BatchEntry, 0, queue.config.FlushSize)
+	for {
+		select {
+		case entry, ok := <-queue.entries:
+			if !ok {
+				queue.flush(batch)
+				return
+			}
+			batch = append(batch, entry)
+			if len(batch) >= queue.config.FlushSize {
+				queue.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			queue.flush(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+// flush writes a batch, retrying with backoff when the write fails. A
+// batch that still fails after FlushAttempts is moved to quarantine, since
+// its readings were already acknowledged to the clients.
+func (queue *Queue) flush(batch []BatchEntry) {
+	if len(batch) == 0 {
+		return
+	}
+
+	backoff := queue.config.RetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := queue.write(batch)
+		if err == nil {
+			return
+		}
+		if attempt >= queue.config.FlushAttempts {
+			log.Error().Err(err).Int("readings", len(batch)).Int("attempts", attempt).Msg("cannot write queued readings, moving them to quarantine")
+			queue.deadLetter(batch)
+			return
+		}
+
+		queue.retried.Add(uint64(len(batch)))
+		log.Warn().Err(err).Int("readings", len(batch)).Int("attempt", attempt).Dur("backoff", backoff).Msg("cannot write queued readings, retrying")
+		time.Sleep(backoff)
+		backoff = min(2*backoff, maxRetryBackoff)
+	}
+}
+
+func (queue *Queue) write(batch []BatchEntry) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	rsp, err := queue.service.RecordBatch(ctx, batch)
+	if err != nil {
+		return err
+	}
+
+	queue.written.Add(uint64(rsp.Accepted + rsp.Skipped + rsp.Quarantined))
+	queue.rejected.Add(uint64(rsp.Rejected))
+	for _, result := range rsp.Results {
+		if result.Status == db.RowRejected {
+			log.Warn().Int32("sensor_id", result.SensorID).Str("error", result.Error).Msg("queued reading rejected")
+		}
+	}
+	return nil
+}
+
+func (queue *Queue) deadLetter(batch []BatchEntry) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := queue.service.quarantineBatch(ctx, batch, ReasonWriteFailed); err != nil {
+		queue.dropped.Add(uint64(len(batch)))
+		log.Error().Err(err).Int("readings", len(batch)).Msg("cannot quarantine unwritten readings, dropping them")
+		return
+	}
+	queue.deadLettered.Add(uint64(len(batch)))
+}
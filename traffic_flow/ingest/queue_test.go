@@ -0,0 +1,32 @@
+package ingest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueBackpressure(t *testing.T) {
+	config, err := LoadConfig()
+	require.NoError(t, err)
+
+	// Without workers nothing drains the queue
+	service := NewService(nil, nil, nil, config)
+	queue := NewQueue(service, QueueConfig{Size: 1, FlushSize: 10, FlushInterval: time.Second})
+
+	reading := newReading(1, 120, 42.5)
+	require.NoError(t, queue.Enqueue(reading))
+	require.ErrorIs(t, queue.Enqueue(reading), ErrQueueFull)
+
+	require.ErrorIs(t, queue.Enqueue(newReading(1, -1, 0)), ErrInvalidReading)
+
+	stats := queue.Stats()
+	require.Equal(t, 1, stats.Depth)
+	require.Equal(t, uint64(1), stats.Enqueued)
+	require.Equal(t, uint64(1), stats.Dropped)
+
+	require.NoError(t, queue.Close(context.Background()))
+	require.ErrorIs(t, queue.Enqueue(reading), ErrQueueClosed)
+}
@@ -0,0 +1,278 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"smart_city/traffic_flow/catalog"
+	"smart_city/traffic_flow/congestion"
+	db "smart_city/traffic_flow/db/sqlc"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Reading is a single traffic measurement as submitted by a sensor. The
+// binding rules are shared by every transport (HTTP, MQTT, ...). A volume
+// or speed of 0 is a real measurement, an empty or jammed road, so only
+// negative values are rejected.
+type Reading struct {
+	SensorID int32 `json:"sensor_id" binding:"required"`
+	// TrafficVolume and AverageSpeed are pointers so that a missing value is
+	// told apart from a measured 0
+	TrafficVolume *int32   `json:"traffic_volume" binding:"required,min=0"`
+	AverageSpeed  *float64 `json:"average_speed" binding:"required,min=0"`
+	// CongestionLevel is the client's own assessment; it is stored as the
+	// reported level while the stored level is derived server-side
+	CongestionLevel string     `json:"congestion_level" binding:"omitempty,max=20"`
+	Timestamp       *time.Time `json:"timestamp" binding:"omitempty"`
+}
+
+// Validate checks the reading against its binding rules
+func (reading *Reading) Validate() error {
+	if err := binding.Validator.ValidateStruct(reading); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidReading, err)
+	}
+	return nil
+}
+
+func (reading *Reading) reportedLevel() pgtype.Text {
+	return pgtype.Text{String: reading.CongestionLevel, Valid: reading.CongestionLevel != ""}
+}
+
+// BatchEntry is one element of a batch; Err is set when the transport could
+// not decode the entry, in which case it is rejected without touching the DB.
+type BatchEntry struct {
+	Reading Reading
+	Err     error
+}
+
+type RowResult struct {
+	Index    int           `json:"index"`
+	SensorID int32         `json:"sensor_id,omitempty"`
+	Status   db.RowOutcome `json:"status"`
+	Error    string        `json:"error,omitempty"`
+}
+
+type BatchResult struct {
+	Accepted    int         `json:"accepted"`
+	Skipped     int         `json:"skipped"`
+	Quarantined int         `json:"quarantined"`
+	Rejected    int         `json:"rejected"`
+	Results     []RowResult `json:"results"`
+}
+
+// Listener is notified with the rows written by every successful ingestion
+type Listener func(data any)
+
+// Service validates readings and writes them through the store. It is
+// shared by all ingestion transports so they apply identical rules.
+// Readings from unknown or non-active sensors never reach traffic_data.
+type Service struct {
+	store        *db.Store
+	sensors      *catalog.SensorCache
+	classifier   *congestion.Classifier
+	config       Config
+	mu           sync.RWMutex
+	listeners    map[int]Listener
+	nextListener int
+}
+
+func NewService(store *db.Store, sensors *catalog.SensorCache, classifier *congestion.Classifier, config Config) *Service {
+	return &Service{
+		store:      store,
+		sensors:    sensors,
+		classifier: classifier,
+		config:     config,
+		listeners:  make(map[int]Listener),
+	}
+}
+
+// OnRecord registers a listener for newly written readings and returns a
+// function that removes it again
+func (service *Service) OnRecord(listener Listener) (remove func()) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	id := service.nextListener
+	service.nextListener++
+	service.listeners[id] = listener
+
+	return func() {
+		service.mu.Lock()
+		defer service.mu.Unlock()
+		delete(service.listeners, id)
+	}
+}
+
+func (service *Service) notify(data any) {
+	service.mu.RLock()
+	defer service.mu.RUnlock()
+	for _, listener := range service.listeners {
+		listener(data)
+	}
+}
+
+// Record validates and stores a single reading. With the keep_first
+// duplicate policy an existing reading is returned with db.RowSkipped. A
+// quarantined reading is returned with db.RowQuarantined and an error
+// wrapping ErrQuarantined.
+func (service *Service) Record(ctx context.Context, reading Reading) (db.TrafficDatum, db.RowOutcome, error) {
+	if err := reading.Validate(); err != nil {
+		return db.TrafficDatum{}, db.RowRejected, err
+	}
+
+	timestamp, isLate, err := service.config.ResolveTimestamp(reading.Timestamp, time.Now())
+	if err != nil {
+		return db.TrafficDatum{}, db.RowRejected, err
+	}
+
+	check, err := service.checkSensor(ctx, reading.SensorID)
+	if err != nil {
+		return db.TrafficDatum{}, db.RowRejected, err
+	}
+
+	volume, speed := *reading.TrafficVolume, *reading.AverageSpeed
+	arg := db.RecordTrafficDataParams{
+		SensorID:        reading.SensorID,
+		Timestamp:       pgtype.Timestamp{Time: timestamp, Valid: true},
+		TrafficVolume:   volume,
+		AverageSpeed:    speed,
+		CongestionLevel: service.classifier.Classify(reading.SensorID, check.typeID, volume, speed),
+		IsLate:          isLate,
+
+		ReportedCongestionLevel: reading.reportedLevel(),
+	}
+	if check.reason != "" {
+		return service.quarantine(ctx, arg, check)
+	}
+
+	trafficData, outcome, err := service.store.RecordTrafficDataWithPolicy(ctx, arg, service.config.OnDuplicate)
+	if err != nil {
+		return trafficData, outcome, err
+	}
+
+	if outcome != db.RowSkipped {
+		service.notify(trafficData)
+	}
+	return trafficData, outcome, nil
+}
+
+// RecordBatch validates and stores many readings with a single COPY,
+// reporting the outcome of every entry. Listeners receive one aggregated
+// notification for the whole batch.
+func (service *Service) RecordBatch(ctx context.Context, entries []BatchEntry) (BatchResult, error) {
+	rsp := BatchResult{Results: make([]RowResult, len(entries))}
+
+	// Only entries that decoded and validated are sent to the database;
+	// index maps each of them back to its position in the batch.
+	now := time.Now()
+	params := make([]db.CopyTrafficDataParams, 0, len(entries))
+	index := make([]int, 0, len(entries))
+	quarantine := []db.CopyQuarantinedTrafficDataParams{}
+	checks := make(map[int32]sensorCheck)
+	for i, entry := range entries {
+		reading := entry.Reading
+		rsp.Results[i] = RowResult{Index: i, SensorID: reading.SensorID}
+
+		err := entry.Err
+		if err == nil {
+			err = reading.Validate()
+		}
+
+		var (
+			timestamp time.Time
+			isLate    bool
+		)
+		if err == nil {
+			timestamp, isLate, err = service.config.ResolveTimestamp(reading.Timestamp, now)
+		}
+		if err != nil {
+			rsp.Results[i].Status = db.RowRejected
+			rsp.Results[i].Error = err.Error()
+			continue
+		}
+
+		check, ok := checks[reading.SensorID]
+		if !ok {
+			check, err = service.checkSensor(ctx, reading.SensorID)
+			if err != nil {
+				return rsp, err
+			}
+			checks[reading.SensorID] = check
+		}
+		volume, speed := *reading.TrafficVolume, *reading.AverageSpeed
+		level := service.classifier.Classify(reading.SensorID, check.typeID, volume, speed)
+		if check.reason != "" {
+			if service.config.OnInactiveSensor == SensorReject {
+				rsp.Results[i].Status = db.RowRejected
+				rsp.Results[i].Error = check.err().Error()
+				continue
+			}
+
+			quarantine = append(quarantine, db.CopyQuarantinedTrafficDataParams{
+				SensorID:        reading.SensorID,
+				Timestamp:       pgtype.Timestamp{Time: timestamp, Valid: true},
+				TrafficVolume:   volume,
+				AverageSpeed:    speed,
+				CongestionLevel: level,
+				IsLate:          isLate,
+				Reason:          check.reason,
+				SensorStatus:    check.status,
+
+				ReportedCongestionLevel: reading.reportedLevel(),
+			})
+			rsp.Results[i].Status = db.RowQuarantined
+			rsp.Results[i].Error = check.err().Error()
+			continue
+		}
+
+		params = append(params, db.CopyTrafficDataParams{
+			SensorID:        reading.SensorID,
+			Timestamp:       pgtype.Timestamp{Time: timestamp, Valid: true},
+			TrafficVolume:   volume,
+			AverageSpeed:    speed,
+			CongestionLevel: level,
+			IsLate:          isLate,
+
+			ReportedCongestionLevel: reading.reportedLevel(),
+		})
+		index = append(index, i)
+	}
+
+	result, err := service.store.BulkRecordTrafficDataTx(ctx, db.BulkRecordTrafficDataTxParams{
+		Rows:        params,
+		OnDuplicate: service.config.OnDuplicate,
+		Quarantine:  quarantine,
+	})
+	if err != nil {
+		return rsp, err
+	}
+
+	for j, outcome := range result.Outcomes {
+		i := index[j]
+		rsp.Results[i].Status = outcome
+		if result.Errors[j] != nil {
+			rsp.Results[i].Error = result.Errors[j].Error()
+		}
+	}
+
+	for _, res := range rsp.Results {
+		switch res.Status {
+		case db.RowCreated, db.RowOverwritten:
+			rsp.Accepted++
+		case db.RowSkipped:
+			rsp.Skipped++
+		case db.RowQuarantined:
+			rsp.Quarantined++
+		default:
+			rsp.Rejected++
+		}
+	}
+
+	if len(result.Inserted) > 0 {
+		service.notify(result.Inserted)
+	}
+	return rsp, nil
+}
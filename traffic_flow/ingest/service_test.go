@@ -0,0 +1,43 @@
+package ingest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newReading builds a reading with both measurements set
+func newReading(sensorID, volume int32, speed float64) Reading {
+	return Reading{SensorID: sensorID, TrafficVolume: &volume, AverageSpeed: &speed}
+}
+
+func TestReadingValidate(t *testing.T) {
+	// An empty road at rush hour is a reading, not a missing one
+	empty := newReading(1, 0, 0)
+	require.NoError(t, empty.Validate())
+
+	// Vehicles without a speed and a speed without vehicles are stored, so
+	// the quality checks can count them as inconsistent
+	stopped := newReading(1, 12, 0)
+	require.NoError(t, stopped.Validate())
+	phantom := newReading(1, 0, 45)
+	require.NoError(t, phantom.Validate())
+
+	// A measurement left out is not a 0
+	volumeOnly := newReading(1, 12, 0)
+	volumeOnly.AverageSpeed = nil
+	require.ErrorIs(t, volumeOnly.Validate(), ErrInvalidReading)
+	speedOnly := newReading(1, 0, 45)
+	speedOnly.TrafficVolume = nil
+	require.ErrorIs(t, speedOnly.Validate(), ErrInvalidReading)
+	require.ErrorIs(t, (&Reading{SensorID: 1}).Validate(), ErrInvalidReading)
+
+	negativeVolume := newReading(1, -1, 40)
+	require.ErrorIs(t, negativeVolume.Validate(), ErrInvalidReading)
+
+	negativeSpeed := newReading(1, 10, -0.5)
+	require.ErrorIs(t, negativeSpeed.Validate(), ErrInvalidReading)
+
+	unknownSensor := newReading(0, 10, 40)
+	require.ErrorIs(t, unknownSensor.Validate(), ErrInvalidReading)
+}
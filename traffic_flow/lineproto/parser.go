@@ -0,0 +1,190 @@
+package lineproto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"smart_city/traffic_flow/ingest"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/line-protocol/v2/lineprotocol"
+)
+
+// Measurement is the only measurement name mapped onto traffic readings
+const Measurement = "traffic"
+
+// Line is a parsed line-protocol entry; Entry.Err is set when the line
+// could not be mapped onto a reading.
+type Line struct {
+	Number int
+	Entry  ingest.BatchEntry
+}
+
+// ParsePrecision converts an Influx precision name ("ns", "us", "ms", "s")
+// into a lineprotocol precision. An empty name means nanoseconds.
+func ParsePrecision(name string) (lineprotocol.Precision, error) {
+	switch name {
+	case "", "n", "ns":
+		return lineprotocol.Nanosecond, nil
+	case "u", "us", "µ":
+		return lineprotocol.Microsecond, nil
+	case "ms":
+		return lineprotocol.Millisecond, nil
+	case "s":
+		return lineprotocol.Second, nil
+	default:
+		return lineprotocol.Nanosecond, fmt.Errorf("invalid precision %q", name)
+	}
+}
+
+// Parse splits data into lines and maps every non-empty, non-comment line
+// onto a traffic reading, e.g.
+//
+//	traffic,sensor_id=12 volume=340i,speed=42.1,congestion="moderate" 1710503990000000000
+//
+// Errors are reported per line; one bad line does not affect the others.
+func Parse(data []byte, precision lineprotocol.Precision) []Line {
+	lines := []Line{}
+	for number, raw := range bytes.Split(data, []byte("\n")) {
+		raw = bytes.TrimSpace(raw)
+		if len(raw) == 0 || raw[0] == '#' {
+			continue
+		}
+
+		line := Line{Number: number + 1}
+		line.Entry.Reading, line.Entry.Err = parseLine(raw, precision)
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func parseLine(raw []byte, precision lineprotocol.Precision) (ingest.Reading, error) {
+	var reading ingest.Reading
+
+	dec := lineprotocol.NewDecoderWithBytes(raw)
+	if !dec.Next() {
+		return reading, errors.New("empty line")
+	}
+
+	measurement, err := dec.Measurement()
+	if err != nil {
+		return reading, err
+	}
+	if string(measurement) != Measurement {
+		return reading, fmt.Errorf("unsupported measurement %q, expected %q", measurement, Measurement)
+	}
+
+	for {
+		key, value, err := dec.NextTag()
+		if err != nil {
+			return reading, err
+		}
+		if key == nil {
+			break
+		}
+		if string(key) == "sensor_id" {
+			id, err := strconv.ParseInt(string(value), 10, 32)
+			if err != nil {
+				return reading, fmt.Errorf("invalid sensor_id tag %q", value)
+			}
+			reading.SensorID = int32(id)
+		}
+	}
+
+	for {
+		key, value, err := dec.NextField()
+		if err != nil {
+			return reading, err
+		}
+		if key == nil {
+			break
+		}
+
+		switch string(key) {
+		case "sensor_id":
+			id, err := integer(value)
+			if err != nil {
+				return reading, fmt.Errorf("invalid sensor_id field: %w", err)
+			}
+			reading.SensorID = int32(id)
+		case "volume", "traffic_volume":
+			volume, err := integer(value)
+			if err != nil {
+				return reading, fmt.Errorf("invalid %s field: %w", key, err)
+			}
+			trafficVolume := int32(volume)
+			reading.TrafficVolume = &trafficVolume
+		case "speed", "average_speed":
+			speed, err := float(value)
+			if err != nil {
+				return reading, fmt.Errorf("invalid %s field: %w", key, err)
+			}
+			reading.AverageSpeed = &speed
+		case "congestion", "congestion_level":
+			if value.Kind() != lineprotocol.String {
+				return reading, fmt.Errorf("invalid %s field: expected a string", key)
+			}
+			reading.CongestionLevel = value.StringV()
+		}
+	}
+
+	timestamp, err := dec.TimeBytes()
+	if err != nil {
+		return reading, err
+	}
+	if timestamp != nil {
+		ts, err := strconv.ParseInt(string(timestamp), 10, 64)
+		if err != nil {
+			return reading, fmt.Errorf("invalid timestamp %q", timestamp)
+		}
+		scale := int64(precision.Duration())
+		if ts > math.MaxInt64/scale || ts < math.MinInt64/scale {
+			return reading, fmt.Errorf("timestamp %q out of range", timestamp)
+		}
+		measured := time.Unix(0, ts*scale)
+		reading.Timestamp = &measured
+	}
+
+	return reading, nil
+}
+
+// integer accepts integer fields and floats without a fractional part
+func integer(value lineprotocol.Value) (int64, error) {
+	var n int64
+	switch value.Kind() {
+	case lineprotocol.Int:
+		n = value.IntV()
+	case lineprotocol.Uint:
+		if value.UintV() > math.MaxInt32 {
+			return 0, errors.New("value out of range")
+		}
+		n = int64(value.UintV())
+	case lineprotocol.Float:
+		f := value.FloatV()
+		if f != math.Trunc(f) {
+			return 0, errors.New("expected an integer")
+		}
+		n = int64(f)
+	default:
+		return 0, errors.New("expected an integer")
+	}
+	if n < math.MinInt32 || n > math.MaxInt32 {
+		return 0, errors.New("value out of range")
+	}
+	return n, nil
+}
+
+func float(value lineprotocol.Value) (float64, error) {
+	switch value.Kind() {
+	case lineprotocol.Float:
+		return value.FloatV(), nil
+	case lineprotocol.Int:
+		return float64(value.IntV()), nil
+	case lineprotocol.Uint:
+		return float64(value.UintV()), nil
+	default:
+		return 0, errors.New("expected a number")
+	}
+}
@@ -0,0 +1,60 @@
+package lineproto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/line-protocol/v2/lineprotocol"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	data := []byte(`# comment
+traffic,sensor_id=12 volume=340i,speed=42.5,congestion="moderate" 1710503990
+
+traffic,sensor_id=abc volume=1i
+weather,sensor_id=3 temp=12.5
+traffic traffic_volume=10,average_speed=30i,congestion_level="low",sensor_id=7i
+`)
+
+	lines := Parse(data, lineprotocol.Second)
+	require.Len(t, lines, 4)
+
+	first := lines[0]
+	require.Equal(t, 2, first.Number)
+	require.NoError(t, first.Entry.Err)
+	require.Equal(t, int32(12), first.Entry.Reading.SensorID)
+	require.Equal(t, int32(340), *first.Entry.Reading.TrafficVolume)
+	require.Equal(t, 42.5, *first.Entry.Reading.AverageSpeed)
+	require.Equal(t, "moderate", first.Entry.Reading.CongestionLevel)
+	require.NotNil(t, first.Entry.Reading.Timestamp)
+	require.True(t, first.Entry.Reading.Timestamp.Equal(time.Unix(1710503990, 0)))
+
+	require.Equal(t, 4, lines[1].Number)
+	require.ErrorContains(t, lines[1].Entry.Err, "sensor_id")
+
+	require.Equal(t, 5, lines[2].Number)
+	require.ErrorContains(t, lines[2].Entry.Err, "unsupported measurement")
+
+	last := lines[3]
+	require.NoError(t, last.Entry.Err)
+	require.Equal(t, int32(7), last.Entry.Reading.SensorID)
+	require.Equal(t, int32(10), *last.Entry.Reading.TrafficVolume)
+	require.Equal(t, 30.0, *last.Entry.Reading.AverageSpeed)
+	require.Nil(t, last.Entry.Reading.Timestamp)
+}
+
+func TestParseIntegerField(t *testing.T) {
+	lines := Parse([]byte(`traffic,sensor_id=1 volume=1.5,speed=1,congestion="low"`), lineprotocol.Nanosecond)
+	require.Len(t, lines, 1)
+	require.ErrorContains(t, lines[0].Entry.Err, "expected an integer")
+}
+
+func TestParsePrecision(t *testing.T) {
+	precision, err := ParsePrecision("ms")
+	require.NoError(t, err)
+	require.Equal(t, lineprotocol.Millisecond, precision)
+
+	_, err = ParsePrecision("h")
+	require.Error(t, err)
+}
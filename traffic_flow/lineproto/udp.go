@@ -0,0 +1,214 @@
+package lineproto
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	db "smart_city/traffic_flow/db/sqlc"
+	"smart_city/traffic_flow/ingest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/influxdata/line-protocol/v2/lineprotocol"
+	"github.com/rs/zerolog/log"
+)
+
+// maxDatagramSize is the largest UDP payload we read in one go
+const maxDatagramSize = 64 * 1024
+
+// maxPendingBatches caps the readings held while writes are slow, in
+// multiples of BatchSize; lines arriving beyond it are dropped
+const maxPendingBatches = 10
+
+// UDPConfig holds the settings of the optional UDP line-protocol listener
+type UDPConfig struct {
+	// Addr is the listen address; the listener is disabled when empty
+	Addr          string
+	Precision     lineprotocol.Precision
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+// LoadUDPConfig reads the UDP listener settings from the environment,
+// falling back to defaults for unset variables
+func LoadUDPConfig() (UDPConfig, error) {
+	config := UDPConfig{
+		Addr:          os.Getenv("INFLUX_UDP_ADDR"),
+		Precision:     lineprotocol.Nanosecond,
+		BatchSize:     1000,
+		FlushInterval: time.Second,
+	}
+
+	precision, err := ParsePrecision(os.Getenv("INFLUX_UDP_PRECISION"))
+	if err != nil {
+		return config, fmt.Errorf("cannot parse INFLUX_UDP_PRECISION: %w", err)
+	}
+	config.Precision = precision
+
+	if value := os.Getenv("INFLUX_UDP_BATCH_SIZE"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size < 1 {
+			return config, fmt.Errorf("invalid INFLUX_UDP_BATCH_SIZE %q", value)
+		}
+		config.BatchSize = size
+	}
+
+	if value := os.Getenv("INFLUX_UDP_FLUSH_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			return config, fmt.Errorf("invalid INFLUX_UDP_FLUSH_INTERVAL %q", value)
+		}
+		config.FlushInterval = interval
+	}
+
+	return config, nil
+}
+
+// UDPListener receives line protocol over UDP and writes it in batches,
+// flushing whenever BatchSize readings are pending or FlushInterval passes.
+// UDP has no way to answer the sender, so per-line errors are logged.
+// Batches are written by the flush loop, so that a slow database never
+// keeps the read loop from draining the socket.
+type UDPListener struct {
+	config   UDPConfig
+	ingester *ingest.Service
+	conn     net.PacketConn
+
+	mu      sync.Mutex
+	pending []ingest.BatchEntry
+
+	// full wakes the flush loop once BatchSize readings are pending
+	full chan struct{}
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func NewUDPListener(config UDPConfig, ingester *ingest.Service) *UDPListener {
+	return &UDPListener{
+		config:   config,
+		ingester: ingester,
+		full:     make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start binds the UDP socket and starts the read and flush loops
+func (listener *UDPListener) Start() error {
+	conn, err := net.ListenPacket("udp", listener.config.Addr)
+	if err != nil {
+		return fmt.Errorf("cannot listen on %s: %w", listener.config.Addr, err)
+	}
+	listener.conn = conn
+
+	listener.wg.Add(2)
+	go listener.readLoop()
+	go listener.flushLoop()
+	return nil
+}
+
+// Close stops the listener and flushes any pending readings
+func (listener *UDPListener) Close() error {
+	close(listener.done)
+	err := listener.conn.Close()
+	listener.wg.Wait()
+	listener.flush()
+	return err
+}
+
+func (listener *UDPListener) readLoop() {
+	defer listener.wg.Done()
+
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, _, err := listener.conn.ReadFrom(buf)
+		if err != nil {
+			select {
+			case <-listener.done:
+				return
+			default:
+			}
+			log.Error().Err(err).Msg("cannot read line protocol datagram")
+			continue
+		}
+
+		dropped := 0
+		listener.mu.Lock()
+		for _, line := range Parse(buf[:n], listener.config.Precision) {
+			if line.Entry.Err != nil {
+				log.Warn().Err(line.Entry.Err).Int("line", line.Number).Msg("dropping line protocol entry")
+				continue
+			}
+			if len(listener.pending) >= maxPendingBatches*listener.config.BatchSize {
+				dropped++
+				continue
+			}
+			listener.pending = append(listener.pending, line.Entry)
+		}
+		full := len(listener.pending) >= listener.config.BatchSize
+		listener.mu.Unlock()
+
+		if dropped > 0 {
+			log.Warn().Int("lines", dropped).Msg("dropping line protocol entries while writes catch up")
+		}
+		if full {
+			select {
+			case listener.full <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+func (listener *UDPListener) flushLoop() {
+	defer listener.wg.Done()
+
+	ticker := time.NewTicker(listener.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-listener.done:
+			return
+		case <-ticker.C:
+			listener.flush()
+		case <-listener.full:
+			listener.flush()
+		}
+	}
+}
+
+// flush writes the pending readings in batches of at most BatchSize
+func (listener *UDPListener) flush() {
+	for {
+		listener.mu.Lock()
+		entries := listener.pending[:min(len(listener.pending), listener.config.BatchSize)]
+		listener.pending = listener.pending[len(entries):]
+		listener.mu.Unlock()
+
+		if len(entries) == 0 {
+			return
+		}
+		listener.write(entries)
+	}
+}
+
+func (listener *UDPListener) write(entries []ingest.BatchEntry) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	rsp, err := listener.ingester.RecordBatch(ctx, entries)
+	if err != nil {
+		log.Error().Err(err).Int("readings", len(entries)).Msg("cannot write line protocol batch")
+		return
+	}
+	for _, result := range rsp.Results {
+		switch result.Status {
+		case db.RowRejected:
+			log.Warn().Int32("sensor_id", result.SensorID).Str("error", result.Error).Msg("line protocol reading rejected")
+		case db.RowQuarantined:
+			log.Info().Int32("sensor_id", result.SensorID).Str("reason", result.Error).Msg("line protocol reading quarantined")
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package liveness
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+// Config controls the liveness monitor
+type Config struct {
+	Enabled bool
+	// CheckInterval is how often last-seen times and statuses are updated
+	CheckInterval time.Duration
+	// Defaults apply to sensor types without their own thresholds
+	Defaults Thresholds
+}
+
+// LoadConfig reads the liveness settings from the environment, falling
+// back to defaults for unset variables
+func LoadConfig() (Config, error) {
+	config := Config{
+		Enabled:       true,
+		CheckInterval: 30 * time.Second,
+	}
+	staleAfter, silentAfter := 5*time.Minute, 30*time.Minute
+
+	if value := os.Getenv("LIVENESS_ENABLED"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return config, fmt.Errorf("cannot parse LIVENESS_ENABLED: %w", err)
+		}
+		config.Enabled = enabled
+	}
+
+	durations := map[string]*time.Duration{
+		"LIVENESS_CHECK_INTERVAL": &config.CheckInterval,
+		"LIVENESS_STALE_AFTER":    &staleAfter,
+		"LIVENESS_SILENT_AFTER":   &silentAfter,
+	}
+	for key, target := range durations {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return config, fmt.Errorf("cannot parse %s: %w", key, err)
+		}
+		*target = duration
+	}
+
+	if config.CheckInterval <= 0 {
+		return config, fmt.Errorf("LIVENESS_CHECK_INTERVAL must be positive")
+	}
+
+	config.Defaults = Thresholds{
+		StaleAfterSeconds:  int32(staleAfter / time.Second),
+		SilentAfterSeconds: int32(silentAfter / time.Second),
+	}
+	if err := binding.Validator.ValidateStruct(&config.Defaults); err != nil {
+		return config, fmt.Errorf("invalid LIVENESS_STALE_AFTER or LIVENESS_SILENT_AFTER: %w", err)
+	}
+
+	return config, nil
+}
@@ -0,0 +1,255 @@
+package liveness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"smart_city/traffic_flow/catalog"
+	db "smart_city/traffic_flow/db/sqlc"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrNotFound = errors.New("sensor type not found")
+	// ErrNoThresholds is returned when deleting thresholds that were never set
+	ErrNoThresholds = errors.New("no thresholds configured")
+)
+
+// monitoredStatuses are the statuses the monitor moves sensors between;
+// sensors an operator put into any other status, offline included, are left
+// alone
+var monitoredStatuses = []string{catalog.StatusActive, catalog.StatusStale, catalog.StatusSilent}
+
+// Listener is notified of every status transition made by the monitor
+type Listener func(transition db.SensorStatusTransition)
+
+// Monitor derives sensor statuses from the time of their newest reading in
+// traffic_data. Every check advances each sensor's last_seen_at, marks
+// sensors that stayed quiet for longer than their type's thresholds stale or
+// silent, and makes them active again once they report. Sensors that never
+// reported count as quiet since their installation date.
+type Monitor struct {
+	store        *db.Store
+	sensors      *catalog.SensorCache
+	config       Config
+	mu           sync.RWMutex
+	byType       map[int32]Thresholds
+	listeners    map[int]Listener
+	nextListener int
+	// checkMu serializes checks; scannedAt is when the last one started
+	checkMu   sync.Mutex
+	scannedAt time.Time
+}
+
+func NewMonitor(store *db.Store, sensors *catalog.SensorCache, config Config) *Monitor {
+	return &Monitor{
+		store:     store,
+		sensors:   sensors,
+		config:    config,
+		byType:    make(map[int32]Thresholds),
+		listeners: make(map[int]Listener),
+	}
+}
+
+// OnTransition registers a listener for status transitions and returns a
+// function that removes it again
+func (monitor *Monitor) OnTransition(listener Listener) (remove func()) {
+	monitor.mu.Lock()
+	defer monitor.mu.Unlock()
+
+	id := monitor.nextListener
+	monitor.nextListener++
+	monitor.listeners[id] = listener
+
+	return func() {
+		monitor.mu.Lock()
+		defer monitor.mu.Unlock()
+		delete(monitor.listeners, id)
+	}
+}
+
+func (monitor *Monitor) notify(transition db.SensorStatusTransition) {
+	monitor.mu.RLock()
+	defer monitor.mu.RUnlock()
+	for _, listener := range monitor.listeners {
+		listener(transition)
+	}
+}
+
+// Refresh reloads the per-type thresholds from the database
+func (monitor *Monitor) Refresh(ctx context.Context) error {
+	rows, err := monitor.store.ListLivenessThresholds(ctx)
+	if err != nil {
+		return err
+	}
+
+	byType := make(map[int32]Thresholds, len(rows))
+	for _, row := range rows {
+		byType[row.TypeID] = fromRow(row)
+	}
+
+	monitor.mu.Lock()
+	monitor.byType = byType
+	monitor.mu.Unlock()
+	return nil
+}
+
+// Resolve returns the thresholds that apply to sensors of the given type
+func (monitor *Monitor) Resolve(typeID int32) (Thresholds, Source) {
+	monitor.mu.RLock()
+	defer monitor.mu.RUnlock()
+
+	if thresholds, ok := monitor.byType[typeID]; ok {
+		return thresholds, SourceSensorType
+	}
+	return monitor.config.Defaults, SourceDefault
+}
+
+// maxSilentAfter is the longest silence any sensor may have before it is
+// silent. Readings older than that cannot change a status.
+func (monitor *Monitor) maxSilentAfter() time.Duration {
+	monitor.mu.RLock()
+	defer monitor.mu.RUnlock()
+
+	longest := monitor.config.Defaults.silentAfter()
+	for _, thresholds := range monitor.byType {
+		longest = max(longest, thresholds.silentAfter())
+	}
+	return longest
+}
+
+// Run checks every sensor right away and then every CheckInterval until
+// ctx is done
+func (monitor *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(monitor.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := monitor.Check(ctx); err != nil {
+			log.Error().Err(err).Msg("cannot check sensor liveness")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check updates last-seen times and the status of every monitored sensor
+func (monitor *Monitor) Check(ctx context.Context) error {
+	monitor.checkMu.Lock()
+	defer monitor.checkMu.Unlock()
+
+	if err := monitor.Refresh(ctx); err != nil {
+		return fmt.Errorf("cannot load liveness thresholds: %w", err)
+	}
+
+	// Only readings that can still change a status are scanned, counted
+	// back from the previous check or, on the first one, from now
+	now := time.Now().UTC()
+	scannedAt := monitor.scannedAt
+	if scannedAt.IsZero() {
+		scannedAt = now
+	}
+	since := scannedAt.Add(-monitor.maxSilentAfter())
+	if _, err := monitor.store.UpdateSensorLastSeen(ctx, pgtype.Timestamp{Time: since, Valid: true}); err != nil {
+		return fmt.Errorf("cannot update last seen times: %w", err)
+	}
+	monitor.scannedAt = now
+
+	rows, err := monitor.store.ListSensorLiveness(ctx, monitoredStatuses)
+	if err != nil {
+		return fmt.Errorf("cannot list sensors: %w", err)
+	}
+
+	for _, row := range rows {
+		lastSeen := row.InstallationDate.Time
+		if row.LastSeenAt.Valid {
+			lastSeen = row.LastSeenAt.Time
+		} else if !row.InstallationDate.Valid {
+			continue
+		}
+
+		thresholds, _ := monitor.Resolve(row.TypeID)
+		status := thresholds.Status(now.Sub(lastSeen))
+		if status == row.Status {
+			continue
+		}
+
+		if err := monitor.transition(ctx, row, status); err != nil {
+			log.Error().Err(err).Int32("sensor_id", row.SensorID).Msg("cannot change sensor status")
+		}
+	}
+	return nil
+}
+
+func (monitor *Monitor) transition(ctx context.Context, row db.ListSensorLivenessRow, status string) error {
+	sensor, transition, err := monitor.store.TransitionSensorStatusTx(ctx, db.TransitionSensorStatusTxParams{
+		SensorID:   row.SensorID,
+		FromStatus: row.Status,
+		ToStatus:   status,
+		LastSeenAt: row.LastSeenAt,
+	})
+	// The status was changed by someone else since the sensor was listed
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	monitor.sensors.SetStatus(sensor.SensorID, sensor.Status)
+	log.Info().Int32("sensor_id", sensor.SensorID).Msgf("sensor status changed from %s to %s", transition.FromStatus, transition.ToStatus)
+	monitor.notify(transition)
+	return nil
+}
+
+func (monitor *Monitor) List(ctx context.Context) ([]db.SensorLivenessThreshold, error) {
+	return monitor.store.ListLivenessThresholds(ctx)
+}
+
+// Defaults returns the thresholds of sensor types without their own
+func (monitor *Monitor) Defaults() Thresholds {
+	return monitor.config.Defaults
+}
+
+func (monitor *Monitor) SetForSensorType(ctx context.Context, typeID int32, thresholds Thresholds) (db.SensorLivenessThreshold, error) {
+	if err := binding.Validator.ValidateStruct(&thresholds); err != nil {
+		return db.SensorLivenessThreshold{}, fmt.Errorf("%w: %v", catalog.ErrInvalidRequest, err)
+	}
+
+	row, err := monitor.store.UpsertLivenessThresholds(ctx, db.UpsertLivenessThresholdsParams{
+		TypeID:             typeID,
+		StaleAfterSeconds:  thresholds.StaleAfterSeconds,
+		SilentAfterSeconds: thresholds.SilentAfterSeconds,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == db.ForeignKeyViolation {
+			return row, ErrNotFound
+		}
+		return row, err
+	}
+	return row, monitor.Refresh(ctx)
+}
+
+// DeleteForSensorType makes sensors of the type fall back to the defaults
+func (monitor *Monitor) DeleteForSensorType(ctx context.Context, typeID int32) error {
+	deleted, err := monitor.store.DeleteLivenessThresholds(ctx, typeID)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrNoThresholds
+	}
+	return monitor.Refresh(ctx)
+}
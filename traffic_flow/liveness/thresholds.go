@@ -0,0 +1,45 @@
+package liveness
+
+import (
+	"smart_city/traffic_flow/catalog"
+	db "smart_city/traffic_flow/db/sqlc"
+	"time"
+)
+
+// Thresholds decide how long a sensor may stay silent. Without a reading
+// for StaleAfterSeconds it is stale, for SilentAfterSeconds silent.
+type Thresholds struct {
+	StaleAfterSeconds  int32 `json:"stale_after_seconds" binding:"gt=0"`
+	SilentAfterSeconds int32 `json:"silent_after_seconds" binding:"gt=0,gtefield=StaleAfterSeconds"`
+}
+
+// Source tells which level of configuration thresholds came from
+type Source string
+
+const (
+	SourceSensorType Source = "sensor_type"
+	SourceDefault    Source = "default"
+)
+
+// Status returns the status of a sensor that has been silent for silence
+func (thresholds Thresholds) Status(silence time.Duration) string {
+	switch {
+	case silence >= thresholds.silentAfter():
+		return catalog.StatusSilent
+	case silence >= time.Duration(thresholds.StaleAfterSeconds)*time.Second:
+		return catalog.StatusStale
+	default:
+		return catalog.StatusActive
+	}
+}
+
+func (thresholds Thresholds) silentAfter() time.Duration {
+	return time.Duration(thresholds.SilentAfterSeconds) * time.Second
+}
+
+func fromRow(row db.SensorLivenessThreshold) Thresholds {
+	return Thresholds{
+		StaleAfterSeconds:  row.StaleAfterSeconds,
+		SilentAfterSeconds: row.SilentAfterSeconds,
+	}
+}
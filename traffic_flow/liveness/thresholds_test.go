@@ -0,0 +1,31 @@
+package liveness
+
+import (
+	"smart_city/traffic_flow/catalog"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatus(t *testing.T) {
+	thresholds := Thresholds{StaleAfterSeconds: 300, SilentAfterSeconds: 1800}
+
+	require.Equal(t, catalog.StatusActive, thresholds.Status(0))
+	require.Equal(t, catalog.StatusActive, thresholds.Status(4*time.Minute))
+	require.Equal(t, catalog.StatusStale, thresholds.Status(5*time.Minute))
+	require.Equal(t, catalog.StatusStale, thresholds.Status(29*time.Minute))
+	require.Equal(t, catalog.StatusSilent, thresholds.Status(30*time.Minute))
+}
+
+func TestThresholdsValidation(t *testing.T) {
+	valid := Thresholds{StaleAfterSeconds: 60, SilentAfterSeconds: 60}
+	require.NoError(t, binding.Validator.ValidateStruct(&valid))
+
+	inverted := Thresholds{StaleAfterSeconds: 600, SilentAfterSeconds: 60}
+	require.Error(t, binding.Validator.ValidateStruct(&inverted))
+
+	missing := Thresholds{SilentAfterSeconds: 60}
+	require.Error(t, binding.Validator.ValidateStruct(&missing))
+}
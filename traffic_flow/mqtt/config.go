@@ -0,0 +1,117 @@
+package mqtt
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const sensorIDPlaceholder = "{sensor_id}"
+
+// PayloadFormat selects how message bodies are decoded
+type PayloadFormat string
+
+const (
+	FormatJSON PayloadFormat = "json"
+	FormatCSV  PayloadFormat = "csv"
+)
+
+// Config holds the MQTT subscriber settings
+type Config struct {
+	Enabled   bool
+	BrokerURL string
+	ClientID  string
+	Username  string
+	Password  string
+	// TopicPattern is the topic layout; a {sensor_id} level is subscribed as
+	// a single-level wildcard and supplies the sensor id of the reading
+	TopicPattern string
+	QoS          byte
+	Format       PayloadFormat
+	// CSVColumns is the column order of CSV payloads
+	CSVColumns []string
+}
+
+// LoadConfig reads the MQTT settings from the environment, falling back to
+// defaults for unset variables
+func LoadConfig() (Config, error) {
+	config := Config{
+		BrokerURL:    "tcp://localhost:1883",
+		ClientID:     "traffic-flow",
+		TopicPattern: "city/traffic/" + sensorIDPlaceholder,
+		QoS:          1,
+		Format:       FormatJSON,
+		CSVColumns:   []string{"traffic_volume", "average_speed", "congestion_level", "timestamp"},
+	}
+
+	if value := os.Getenv("MQTT_ENABLED"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return config, fmt.Errorf("cannot parse MQTT_ENABLED: %w", err)
+		}
+		config.Enabled = enabled
+	}
+
+	values := map[string]*string{
+		"MQTT_BROKER_URL":    &config.BrokerURL,
+		"MQTT_CLIENT_ID":     &config.ClientID,
+		"MQTT_USERNAME":      &config.Username,
+		"MQTT_PASSWORD":      &config.Password,
+		"MQTT_TOPIC_PATTERN": &config.TopicPattern,
+	}
+	for key, target := range values {
+		if value := os.Getenv(key); value != "" {
+			*target = value
+		}
+	}
+
+	if value := os.Getenv("MQTT_QOS"); value != "" {
+		qos, err := strconv.ParseUint(value, 10, 8)
+		if err != nil || qos > 2 {
+			return config, fmt.Errorf("invalid MQTT_QOS %q, must be 0, 1 or 2", value)
+		}
+		config.QoS = byte(qos)
+	}
+
+	if value := os.Getenv("MQTT_PAYLOAD_FORMAT"); value != "" {
+		format := PayloadFormat(value)
+		if format != FormatJSON && format != FormatCSV {
+			return config, fmt.Errorf("invalid MQTT_PAYLOAD_FORMAT %q", value)
+		}
+		config.Format = format
+	}
+
+	if value := os.Getenv("MQTT_CSV_COLUMNS"); value != "" {
+		config.CSVColumns = splitColumns(value)
+		for _, column := range config.CSVColumns {
+			if !csvColumns[column] {
+				return config, fmt.Errorf("invalid MQTT_CSV_COLUMNS column %q", column)
+			}
+		}
+	}
+
+	return config, nil
+}
+
+func splitColumns(value string) []string {
+	columns := strings.Split(value, ",")
+	for i, column := range columns {
+		columns[i] = strings.TrimSpace(column)
+	}
+	return columns
+}
+
+// subscription returns the topic filter for the pattern and the level that
+// carries the sensor id, or -1 when the sensor id comes from the payload
+func (config Config) subscription() (string, int) {
+	levels := strings.Split(config.TopicPattern, "/")
+	sensorLevel := -1
+	for i, level := range levels {
+		if level == sensorIDPlaceholder {
+			levels[i] = "+"
+			sensorLevel = i
+		}
+	}
+	return strings.Join(levels, "/"), sensorLevel
+}
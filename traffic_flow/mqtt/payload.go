@@ -0,0 +1,115 @@
+package mqtt
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"smart_city/traffic_flow/ingest"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// csvColumns lists the column names understood in CSV payloads
+var csvColumns = map[string]bool{
+	"sensor_id":        true,
+	"traffic_volume":   true,
+	"average_speed":    true,
+	"congestion_level": true,
+	"timestamp":        true,
+}
+
+// sensorIDFromTopic extracts the sensor id carried by the given topic level
+func sensorIDFromTopic(topic string, level int) (int32, error) {
+	levels := strings.Split(topic, "/")
+	if level < 0 || level >= len(levels) {
+		return 0, fmt.Errorf("topic %q has no sensor id level", topic)
+	}
+
+	id, err := strconv.ParseInt(levels[level], 10, 32)
+	if err != nil || id < 1 {
+		return 0, fmt.Errorf("invalid sensor id %q in topic %q", levels[level], topic)
+	}
+	return int32(id), nil
+}
+
+// decodePayload turns a message body into a reading. topicSensorID is 0
+// when the topic does not carry a sensor id; otherwise it must agree with
+// any sensor id found in the payload.
+func decodePayload(format PayloadFormat, columns []string, payload []byte, topicSensorID int32) (ingest.Reading, error) {
+	var (
+		reading ingest.Reading
+		err     error
+	)
+	switch format {
+	case FormatCSV:
+		reading, err = decodeCSV(columns, payload)
+	default:
+		err = json.Unmarshal(payload, &reading)
+	}
+	if err != nil {
+		return reading, fmt.Errorf("cannot decode payload: %w", err)
+	}
+
+	if topicSensorID != 0 {
+		if reading.SensorID != 0 && reading.SensorID != topicSensorID {
+			return reading, fmt.Errorf("payload sensor_id %d does not match topic sensor id %d", reading.SensorID, topicSensorID)
+		}
+		reading.SensorID = topicSensorID
+	}
+
+	return reading, nil
+}
+
+// decodeCSV decodes a single CSV record; empty fields are left unset
+func decodeCSV(columns []string, payload []byte) (ingest.Reading, error) {
+	var reading ingest.Reading
+
+	record, err := csv.NewReader(bytes.NewReader(payload)).Read()
+	if err != nil {
+		return reading, err
+	}
+	if len(record) != len(columns) {
+		return reading, fmt.Errorf("expected %d fields, got %d", len(columns), len(record))
+	}
+
+	for i, column := range columns {
+		value := strings.TrimSpace(record[i])
+		if value == "" {
+			continue
+		}
+
+		switch column {
+		case "sensor_id":
+			id, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				return reading, fmt.Errorf("invalid sensor_id %q", value)
+			}
+			reading.SensorID = int32(id)
+		case "traffic_volume":
+			volume, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				return reading, fmt.Errorf("invalid traffic_volume %q", value)
+			}
+			trafficVolume := int32(volume)
+			reading.TrafficVolume = &trafficVolume
+		case "average_speed":
+			speed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return reading, fmt.Errorf("invalid average_speed %q", value)
+			}
+			reading.AverageSpeed = &speed
+		case "congestion_level":
+			reading.CongestionLevel = value
+		case "timestamp":
+			ts, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				return reading, fmt.Errorf("invalid timestamp %q, use RFC3339", value)
+			}
+			reading.Timestamp = &ts
+		}
+	}
+
+	return reading, nil
+}
@@ -0,0 +1,71 @@
+package mqtt
+
+import (
+	"smart_city/traffic_flow/ingest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscription(t *testing.T) {
+	config := Config{TopicPattern: "city/traffic/{sensor_id}/readings"}
+	topic, level := config.subscription()
+	require.Equal(t, "city/traffic/+/readings", topic)
+	require.Equal(t, 2, level)
+
+	id, err := sensorIDFromTopic("city/traffic/42/readings", level)
+	require.NoError(t, err)
+	require.Equal(t, int32(42), id)
+
+	_, err = sensorIDFromTopic("city/traffic/abc/readings", level)
+	require.Error(t, err)
+
+	config = Config{TopicPattern: "city/traffic"}
+	topic, level = config.subscription()
+	require.Equal(t, "city/traffic", topic)
+	require.Equal(t, -1, level)
+}
+
+func TestDecodeJSONPayload(t *testing.T) {
+	payload := []byte(`{"traffic_volume": 340, "average_speed": 42.1, "congestion_level": "moderate"}`)
+
+	reading, err := decodePayload(FormatJSON, nil, payload, 12)
+	require.NoError(t, err)
+	require.Equal(t, int32(12), reading.SensorID)
+	require.Equal(t, int32(340), *reading.TrafficVolume)
+	require.Equal(t, 42.1, *reading.AverageSpeed)
+	require.Equal(t, "moderate", reading.CongestionLevel)
+	require.Nil(t, reading.Timestamp)
+
+	_, err = decodePayload(FormatJSON, nil, []byte(`{"sensor_id": 7}`), 12)
+	require.Error(t, err)
+}
+
+func TestDecodeCSVPayload(t *testing.T) {
+	columns := []string{"traffic_volume", "average_speed", "congestion_level", "timestamp"}
+
+	reading, err := decodePayload(FormatCSV, columns, []byte("249,58.8,low,2025-03-15T11:59:50.633831Z"), 40)
+	require.NoError(t, err)
+	require.Equal(t, int32(40), reading.SensorID)
+	require.Equal(t, int32(249), *reading.TrafficVolume)
+	require.Equal(t, "low", reading.CongestionLevel)
+	require.NotNil(t, reading.Timestamp)
+	require.Equal(t, time.Date(2025, 3, 15, 11, 59, 50, 633831000, time.UTC), *reading.Timestamp)
+
+	reading, err = decodePayload(FormatCSV, columns, []byte("249,58.8,low,"), 40)
+	require.NoError(t, err)
+	require.Nil(t, reading.Timestamp)
+
+	// An empty measurement is missing rather than 0, so the reading is refused
+	reading, err = decodePayload(FormatCSV, columns, []byte("249,,low,"), 40)
+	require.NoError(t, err)
+	require.Nil(t, reading.AverageSpeed)
+	require.ErrorIs(t, reading.Validate(), ingest.ErrInvalidReading)
+
+	_, err = decodePayload(FormatCSV, columns, []byte("249,58.8"), 40)
+	require.Error(t, err)
+
+	_, err = decodePayload(FormatCSV, columns, []byte("many,58.8,low,"), 40)
+	require.Error(t, err)
+}
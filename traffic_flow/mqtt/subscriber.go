@@ -0,0 +1,107 @@
+package mqtt
+
+import (
+	"context"
+	"fmt"
+	db "smart_city/traffic_flow/db/sqlc"
+	"smart_city/traffic_flow/ingest"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/rs/zerolog/log"
+)
+
+// storeTimeout bounds how long a single message may spend in the store
+const storeTimeout = 10 * time.Second
+
+// Subscriber consumes traffic readings from an MQTT broker and hands them
+// to the ingest service, so they follow the same validation and storage
+// path as readings posted over HTTP.
+type Subscriber struct {
+	config      Config
+	ingester    *ingest.Service
+	client      paho.Client
+	topic       string
+	sensorLevel int
+}
+
+func NewSubscriber(config Config, ingester *ingest.Service) *Subscriber {
+	subscriber := &Subscriber{
+		config:   config,
+		ingester: ingester,
+	}
+	subscriber.topic, subscriber.sensorLevel = config.subscription()
+
+	opts := paho.NewClientOptions().
+		AddBroker(config.BrokerURL).
+		SetClientID(config.ClientID).
+		SetUsername(config.Username).
+		SetPassword(config.Password).
+		SetAutoReconnect(true).
+		SetConnectRetry(true).
+		SetConnectRetryInterval(5 * time.Second).
+		SetOnConnectHandler(subscriber.onConnect).
+		SetConnectionLostHandler(func(_ paho.Client, err error) {
+			log.Warn().Err(err).Msg("MQTT connection lost")
+		})
+
+	subscriber.client = paho.NewClient(opts)
+	return subscriber
+}
+
+// Start connects to the broker. Subscriptions are (re)established on every
+// successful connection, so a broker that is not up yet is retried in the
+// background.
+func (subscriber *Subscriber) Start() error {
+	token := subscriber.client.Connect()
+	// With connect retry enabled the token only completes once connected
+	if token.WaitTimeout(5*time.Second) && token.Error() != nil {
+		return fmt.Errorf("cannot connect to MQTT broker: %w", token.Error())
+	}
+	return nil
+}
+
+// Close disconnects from the broker, letting in-flight messages finish
+func (subscriber *Subscriber) Close() {
+	subscriber.client.Disconnect(250)
+}
+
+func (subscriber *Subscriber) onConnect(client paho.Client) {
+	token := client.Subscribe(subscriber.topic, subscriber.config.QoS, subscriber.handleMessage)
+	token.Wait()
+	if err := token.Error(); err != nil {
+		log.Error().Err(err).Str("topic", subscriber.topic).Msg("cannot subscribe to MQTT topic")
+		return
+	}
+	log.Info().Str("topic", subscriber.topic).Uint8("qos", subscriber.config.QoS).Msg("subscribed to MQTT traffic topic")
+}
+
+func (subscriber *Subscriber) handleMessage(_ paho.Client, msg paho.Message) {
+	var topicSensorID int32
+	if subscriber.sensorLevel >= 0 {
+		id, err := sensorIDFromTopic(msg.Topic(), subscriber.sensorLevel)
+		if err != nil {
+			log.Warn().Err(err).Msg("dropping MQTT message")
+			return
+		}
+		topicSensorID = id
+	}
+
+	reading, err := decodePayload(subscriber.config.Format, subscriber.config.CSVColumns, msg.Payload(), topicSensorID)
+	if err != nil {
+		log.Warn().Err(err).Str("topic", msg.Topic()).Msg("dropping MQTT message")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	_, outcome, err := subscriber.ingester.Record(ctx, reading)
+	if outcome == db.RowQuarantined {
+		log.Info().Err(err).Int32("sensor_id", reading.SensorID).Msg("MQTT reading quarantined")
+		return
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("topic", msg.Topic()).Int32("sensor_id", reading.SensorID).Msg("cannot record MQTT reading")
+	}
+}
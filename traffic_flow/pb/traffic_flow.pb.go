@@ -0,0 +1,1983 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.4
+// 	protoc        v5.29.3
+// source: traffic_flow.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RecordOutcome int32
+
+const (
+	RecordOutcome_RECORD_OUTCOME_UNSPECIFIED RecordOutcome = 0
+	RecordOutcome_RECORD_OUTCOME_CREATED     RecordOutcome = 1
+	RecordOutcome_RECORD_OUTCOME_SKIPPED     RecordOutcome = 2
+	RecordOutcome_RECORD_OUTCOME_REJECTED    RecordOutcome = 3
+	// The sensor is unknown or not active; the reading was quarantined
+	RecordOutcome_RECORD_OUTCOME_QUARANTINED RecordOutcome = 4
+	// An existing reading with the same timestamp was replaced
+	RecordOutcome_RECORD_OUTCOME_OVERWRITTEN RecordOutcome = 5
+)
+
+// Enum value maps for RecordOutcome.
+var (
+	RecordOutcome_name = map[int32]string{
+		0: "RECORD_OUTCOME_UNSPECIFIED",
+		1: "RECORD_OUTCOME_CREATED",
+		2: "RECORD_OUTCOME_SKIPPED",
+		3: "RECORD_OUTCOME_REJECTED",
+		4: "RECORD_OUTCOME_QUARANTINED",
+		5: "RECORD_OUTCOME_OVERWRITTEN",
+	}
+	RecordOutcome_value = map[string]int32{
+		"RECORD_OUTCOME_UNSPECIFIED": 0,
+		"RECORD_OUTCOME_CREATED":     1,
+		"RECORD_OUTCOME_SKIPPED":     2,
+		"RECORD_OUTCOME_REJECTED":    3,
+		"RECORD_OUTCOME_QUARANTINED": 4,
+		"RECORD_OUTCOME_OVERWRITTEN": 5,
+	}
+)
+
+func (x RecordOutcome) Enum() *RecordOutcome {
+	p := new(RecordOutcome)
+	*p = x
+	return p
+}
+
+func (x RecordOutcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RecordOutcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_traffic_flow_proto_enumTypes[0].Descriptor()
+}
+
+func (RecordOutcome) Type() protoreflect.EnumType {
+	return &file_traffic_flow_proto_enumTypes[0]
+}
+
+func (x RecordOutcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RecordOutcome.Descriptor instead.
+func (RecordOutcome) EnumDescriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{0}
+}
+
+type TrafficUpdate_Kind int32
+
+const (
+	TrafficUpdate_KIND_UNSPECIFIED TrafficUpdate_Kind = 0
+	// Readings that were just recorded
+	TrafficUpdate_KIND_RECORDED TrafficUpdate_Kind = 1
+	// Periodic snapshot of the latest readings
+	TrafficUpdate_KIND_SNAPSHOT TrafficUpdate_Kind = 2
+	// A sensor went stale, silent or active again; sets sensor_status
+	TrafficUpdate_KIND_SENSOR_STATUS TrafficUpdate_Kind = 3
+	// A reading deviated from its sensor's baseline; sets anomaly
+	TrafficUpdate_KIND_ANOMALY TrafficUpdate_Kind = 4
+	// The city-wide congestion index was computed; sets congestion_index.
+	// It is sent whatever sensor_ids the watch was filtered on.
+	TrafficUpdate_KIND_CONGESTION_INDEX TrafficUpdate_Kind = 5
+)
+
+// Enum value maps for TrafficUpdate_Kind.
+var (
+	TrafficUpdate_Kind_name = map[int32]string{
+		0: "KIND_UNSPECIFIED",
+		1: "KIND_RECORDED",
+		2: "KIND_SNAPSHOT",
+		3: "KIND_SENSOR_STATUS",
+		4: "KIND_ANOMALY",
+		5: "KIND_CONGESTION_INDEX",
+	}
+	TrafficUpdate_Kind_value = map[string]int32{
+		"KIND_UNSPECIFIED":      0,
+		"KIND_RECORDED":         1,
+		"KIND_SNAPSHOT":         2,
+		"KIND_SENSOR_STATUS":    3,
+		"KIND_ANOMALY":          4,
+		"KIND_CONGESTION_INDEX": 5,
+	}
+)
+
+func (x TrafficUpdate_Kind) Enum() *TrafficUpdate_Kind {
+	p := new(TrafficUpdate_Kind)
+	*p = x
+	return p
+}
+
+func (x TrafficUpdate_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TrafficUpdate_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_traffic_flow_proto_enumTypes[1].Descriptor()
+}
+
+func (TrafficUpdate_Kind) Type() protoreflect.EnumType {
+	return &file_traffic_flow_proto_enumTypes[1]
+}
+
+func (x TrafficUpdate_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TrafficUpdate_Kind.Descriptor instead.
+func (TrafficUpdate_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{5, 0}
+}
+
+type TrafficReading struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SensorId      int32                  `protobuf:"varint,1,opt,name=sensor_id,json=sensorId,proto3" json:"sensor_id,omitempty"`
+	TrafficVolume int32                  `protobuf:"varint,2,opt,name=traffic_volume,json=trafficVolume,proto3" json:"traffic_volume,omitempty"`
+	AverageSpeed  float64                `protobuf:"fixed64,3,opt,name=average_speed,json=averageSpeed,proto3" json:"average_speed,omitempty"`
+	// The client's own assessment; the stored level is derived server-side
+	CongestionLevel string `protobuf:"bytes,4,opt,name=congestion_level,json=congestionLevel,proto3" json:"congestion_level,omitempty"`
+	// Optional measurement time; the receive time is used when unset
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrafficReading) Reset() {
+	*x = TrafficReading{}
+	mi := &file_traffic_flow_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrafficReading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrafficReading) ProtoMessage() {}
+
+func (x *TrafficReading) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrafficReading.ProtoReflect.Descriptor instead.
+func (*TrafficReading) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TrafficReading) GetSensorId() int32 {
+	if x != nil {
+		return x.SensorId
+	}
+	return 0
+}
+
+func (x *TrafficReading) GetTrafficVolume() int32 {
+	if x != nil {
+		return x.TrafficVolume
+	}
+	return 0
+}
+
+func (x *TrafficReading) GetAverageSpeed() float64 {
+	if x != nil {
+		return x.AverageSpeed
+	}
+	return 0
+}
+
+func (x *TrafficReading) GetCongestionLevel() string {
+	if x != nil {
+		return x.CongestionLevel
+	}
+	return ""
+}
+
+func (x *TrafficReading) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type TrafficDatum struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SensorId        int32                  `protobuf:"varint,1,opt,name=sensor_id,json=sensorId,proto3" json:"sensor_id,omitempty"`
+	Timestamp       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	TrafficVolume   int32                  `protobuf:"varint,3,opt,name=traffic_volume,json=trafficVolume,proto3" json:"traffic_volume,omitempty"`
+	AverageSpeed    float64                `protobuf:"fixed64,4,opt,name=average_speed,json=averageSpeed,proto3" json:"average_speed,omitempty"`
+	CongestionLevel string                 `protobuf:"bytes,5,opt,name=congestion_level,json=congestionLevel,proto3" json:"congestion_level,omitempty"`
+	IsLate          bool                   `protobuf:"varint,6,opt,name=is_late,json=isLate,proto3" json:"is_late,omitempty"`
+	// Only set on snapshots
+	Latitude  float64 `protobuf:"fixed64,7,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64 `protobuf:"fixed64,8,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// Level sent by the client, empty when none was reported
+	ReportedCongestionLevel string `protobuf:"bytes,9,opt,name=reported_congestion_level,json=reportedCongestionLevel,proto3" json:"reported_congestion_level,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *TrafficDatum) Reset() {
+	*x = TrafficDatum{}
+	mi := &file_traffic_flow_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrafficDatum) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrafficDatum) ProtoMessage() {}
+
+func (x *TrafficDatum) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrafficDatum.ProtoReflect.Descriptor instead.
+func (*TrafficDatum) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TrafficDatum) GetSensorId() int32 {
+	if x != nil {
+		return x.SensorId
+	}
+	return 0
+}
+
+func (x *TrafficDatum) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *TrafficDatum) GetTrafficVolume() int32 {
+	if x != nil {
+		return x.TrafficVolume
+	}
+	return 0
+}
+
+func (x *TrafficDatum) GetAverageSpeed() float64 {
+	if x != nil {
+		return x.AverageSpeed
+	}
+	return 0
+}
+
+func (x *TrafficDatum) GetCongestionLevel() string {
+	if x != nil {
+		return x.CongestionLevel
+	}
+	return ""
+}
+
+func (x *TrafficDatum) GetIsLate() bool {
+	if x != nil {
+		return x.IsLate
+	}
+	return false
+}
+
+func (x *TrafficDatum) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *TrafficDatum) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *TrafficDatum) GetReportedCongestionLevel() string {
+	if x != nil {
+		return x.ReportedCongestionLevel
+	}
+	return ""
+}
+
+type RecordTrafficRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Client chosen sequence number echoed in the matching ack
+	Sequence      uint64          `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Reading       *TrafficReading `protobuf:"bytes,2,opt,name=reading,proto3" json:"reading,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordTrafficRequest) Reset() {
+	*x = RecordTrafficRequest{}
+	mi := &file_traffic_flow_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordTrafficRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordTrafficRequest) ProtoMessage() {}
+
+func (x *RecordTrafficRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordTrafficRequest.ProtoReflect.Descriptor instead.
+func (*RecordTrafficRequest) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RecordTrafficRequest) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *RecordTrafficRequest) GetReading() *TrafficReading {
+	if x != nil {
+		return x.Reading
+	}
+	return nil
+}
+
+type RecordTrafficAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sequence      uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Outcome       RecordOutcome          `protobuf:"varint,2,opt,name=outcome,proto3,enum=trafficflow.v1.RecordOutcome" json:"outcome,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Data          *TrafficDatum          `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordTrafficAck) Reset() {
+	*x = RecordTrafficAck{}
+	mi := &file_traffic_flow_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordTrafficAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordTrafficAck) ProtoMessage() {}
+
+func (x *RecordTrafficAck) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordTrafficAck.ProtoReflect.Descriptor instead.
+func (*RecordTrafficAck) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RecordTrafficAck) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *RecordTrafficAck) GetOutcome() RecordOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return RecordOutcome_RECORD_OUTCOME_UNSPECIFIED
+}
+
+func (x *RecordTrafficAck) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RecordTrafficAck) GetData() *TrafficDatum {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type WatchTrafficRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only stream readings of these sensors; empty means all sensors
+	SensorIds     []int32 `protobuf:"varint,1,rep,packed,name=sensor_ids,json=sensorIds,proto3" json:"sensor_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTrafficRequest) Reset() {
+	*x = WatchTrafficRequest{}
+	mi := &file_traffic_flow_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTrafficRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTrafficRequest) ProtoMessage() {}
+
+func (x *WatchTrafficRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTrafficRequest.ProtoReflect.Descriptor instead.
+func (*WatchTrafficRequest) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *WatchTrafficRequest) GetSensorIds() []int32 {
+	if x != nil {
+		return x.SensorIds
+	}
+	return nil
+}
+
+type TrafficUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Kind  TrafficUpdate_Kind     `protobuf:"varint,1,opt,name=kind,proto3,enum=trafficflow.v1.TrafficUpdate_Kind" json:"kind,omitempty"`
+	// Set on recorded and snapshot updates
+	Data            []*TrafficDatum         `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	SensorStatus    *SensorStatusTransition `protobuf:"bytes,3,opt,name=sensor_status,json=sensorStatus,proto3" json:"sensor_status,omitempty"`
+	Anomaly         *TrafficAnomaly         `protobuf:"bytes,4,opt,name=anomaly,proto3" json:"anomaly,omitempty"`
+	CongestionIndex *CongestionIndex        `protobuf:"bytes,5,opt,name=congestion_index,json=congestionIndex,proto3" json:"congestion_index,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TrafficUpdate) Reset() {
+	*x = TrafficUpdate{}
+	mi := &file_traffic_flow_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrafficUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrafficUpdate) ProtoMessage() {}
+
+func (x *TrafficUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrafficUpdate.ProtoReflect.Descriptor instead.
+func (*TrafficUpdate) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TrafficUpdate) GetKind() TrafficUpdate_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return TrafficUpdate_KIND_UNSPECIFIED
+}
+
+func (x *TrafficUpdate) GetData() []*TrafficDatum {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *TrafficUpdate) GetSensorStatus() *SensorStatusTransition {
+	if x != nil {
+		return x.SensorStatus
+	}
+	return nil
+}
+
+func (x *TrafficUpdate) GetAnomaly() *TrafficAnomaly {
+	if x != nil {
+		return x.Anomaly
+	}
+	return nil
+}
+
+func (x *TrafficUpdate) GetCongestionIndex() *CongestionIndex {
+	if x != nil {
+		return x.CongestionIndex
+	}
+	return nil
+}
+
+type SensorStatusTransition struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SensorId       int32                  `protobuf:"varint,1,opt,name=sensor_id,json=sensorId,proto3" json:"sensor_id,omitempty"`
+	FromStatus     string                 `protobuf:"bytes,2,opt,name=from_status,json=fromStatus,proto3" json:"from_status,omitempty"`
+	ToStatus       string                 `protobuf:"bytes,3,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	LastSeenAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	TransitionedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=transitioned_at,json=transitionedAt,proto3" json:"transitioned_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SensorStatusTransition) Reset() {
+	*x = SensorStatusTransition{}
+	mi := &file_traffic_flow_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SensorStatusTransition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SensorStatusTransition) ProtoMessage() {}
+
+func (x *SensorStatusTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SensorStatusTransition.ProtoReflect.Descriptor instead.
+func (*SensorStatusTransition) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SensorStatusTransition) GetSensorId() int32 {
+	if x != nil {
+		return x.SensorId
+	}
+	return 0
+}
+
+func (x *SensorStatusTransition) GetFromStatus() string {
+	if x != nil {
+		return x.FromStatus
+	}
+	return ""
+}
+
+func (x *SensorStatusTransition) GetToStatus() string {
+	if x != nil {
+		return x.ToStatus
+	}
+	return ""
+}
+
+func (x *SensorStatusTransition) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
+func (x *SensorStatusTransition) GetTransitionedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TransitionedAt
+	}
+	return nil
+}
+
+type TrafficAnomaly struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AnomalyId int64                  `protobuf:"varint,1,opt,name=anomaly_id,json=anomalyId,proto3" json:"anomaly_id,omitempty"`
+	SensorId  int32                  `protobuf:"varint,2,opt,name=sensor_id,json=sensorId,proto3" json:"sensor_id,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// traffic_volume or average_speed
+	Metric string `protobuf:"bytes,4,opt,name=metric,proto3" json:"metric,omitempty"`
+	// volume_drop, volume_spike, speed_drop or speed_spike
+	Kind          string  `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`
+	Observed      float64 `protobuf:"fixed64,6,opt,name=observed,proto3" json:"observed,omitempty"`
+	Expected      float64 `protobuf:"fixed64,7,opt,name=expected,proto3" json:"expected,omitempty"`
+	Stddev        float64 `protobuf:"fixed64,8,opt,name=stddev,proto3" json:"stddev,omitempty"`
+	ZScore        float64 `protobuf:"fixed64,9,opt,name=z_score,json=zScore,proto3" json:"z_score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrafficAnomaly) Reset() {
+	*x = TrafficAnomaly{}
+	mi := &file_traffic_flow_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrafficAnomaly) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrafficAnomaly) ProtoMessage() {}
+
+func (x *TrafficAnomaly) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrafficAnomaly.ProtoReflect.Descriptor instead.
+func (*TrafficAnomaly) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TrafficAnomaly) GetAnomalyId() int64 {
+	if x != nil {
+		return x.AnomalyId
+	}
+	return 0
+}
+
+func (x *TrafficAnomaly) GetSensorId() int32 {
+	if x != nil {
+		return x.SensorId
+	}
+	return 0
+}
+
+func (x *TrafficAnomaly) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *TrafficAnomaly) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *TrafficAnomaly) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *TrafficAnomaly) GetObserved() float64 {
+	if x != nil {
+		return x.Observed
+	}
+	return 0
+}
+
+func (x *TrafficAnomaly) GetExpected() float64 {
+	if x != nil {
+		return x.Expected
+	}
+	return 0
+}
+
+func (x *TrafficAnomaly) GetStddev() float64 {
+	if x != nil {
+		return x.Stddev
+	}
+	return 0
+}
+
+func (x *TrafficAnomaly) GetZScore() float64 {
+	if x != nil {
+		return x.ZScore
+	}
+	return 0
+}
+
+type CongestionIndex struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// 0 is free flow across the city, 100 is standstill
+	Value         float64 `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	Sensors       int32   `protobuf:"varint,3,opt,name=sensors,proto3" json:"sensors,omitempty"`
+	Volume        int64   `protobuf:"varint,4,opt,name=volume,proto3" json:"volume,omitempty"`
+	AvgSpeed      float64 `protobuf:"fixed64,5,opt,name=avg_speed,json=avgSpeed,proto3" json:"avg_speed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CongestionIndex) Reset() {
+	*x = CongestionIndex{}
+	mi := &file_traffic_flow_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CongestionIndex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CongestionIndex) ProtoMessage() {}
+
+func (x *CongestionIndex) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CongestionIndex.ProtoReflect.Descriptor instead.
+func (*CongestionIndex) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CongestionIndex) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *CongestionIndex) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *CongestionIndex) GetSensors() int32 {
+	if x != nil {
+		return x.Sensors
+	}
+	return 0
+}
+
+func (x *CongestionIndex) GetVolume() int64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *CongestionIndex) GetAvgSpeed() float64 {
+	if x != nil {
+		return x.AvgSpeed
+	}
+	return 0
+}
+
+type SensorType struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TypeId        int32                  `protobuf:"varint,1,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`
+	TypeName      string                 `protobuf:"bytes,2,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SensorType) Reset() {
+	*x = SensorType{}
+	mi := &file_traffic_flow_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SensorType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SensorType) ProtoMessage() {}
+
+func (x *SensorType) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SensorType.ProtoReflect.Descriptor instead.
+func (*SensorType) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SensorType) GetTypeId() int32 {
+	if x != nil {
+		return x.TypeId
+	}
+	return 0
+}
+
+func (x *SensorType) GetTypeName() string {
+	if x != nil {
+		return x.TypeName
+	}
+	return ""
+}
+
+func (x *SensorType) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type Sensor struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SensorId  int32                  `protobuf:"varint,1,opt,name=sensor_id,json=sensorId,proto3" json:"sensor_id,omitempty"`
+	Latitude  float64                `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64                `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	TypeId    int32                  `protobuf:"varint,4,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`
+	// Date formatted as YYYY-MM-DD
+	InstallationDate string `protobuf:"bytes,5,opt,name=installation_date,json=installationDate,proto3" json:"installation_date,omitempty"`
+	Status           string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	TypeName         string `protobuf:"bytes,7,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
+	TypeDescription  string `protobuf:"bytes,8,opt,name=type_description,json=typeDescription,proto3" json:"type_description,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Sensor) Reset() {
+	*x = Sensor{}
+	mi := &file_traffic_flow_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sensor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sensor) ProtoMessage() {}
+
+func (x *Sensor) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sensor.ProtoReflect.Descriptor instead.
+func (*Sensor) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Sensor) GetSensorId() int32 {
+	if x != nil {
+		return x.SensorId
+	}
+	return 0
+}
+
+func (x *Sensor) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Sensor) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Sensor) GetTypeId() int32 {
+	if x != nil {
+		return x.TypeId
+	}
+	return 0
+}
+
+func (x *Sensor) GetInstallationDate() string {
+	if x != nil {
+		return x.InstallationDate
+	}
+	return ""
+}
+
+func (x *Sensor) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Sensor) GetTypeName() string {
+	if x != nil {
+		return x.TypeName
+	}
+	return ""
+}
+
+func (x *Sensor) GetTypeDescription() string {
+	if x != nil {
+		return x.TypeDescription
+	}
+	return ""
+}
+
+type CreateSensorTypeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TypeName      string                 `protobuf:"bytes,1,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSensorTypeRequest) Reset() {
+	*x = CreateSensorTypeRequest{}
+	mi := &file_traffic_flow_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSensorTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSensorTypeRequest) ProtoMessage() {}
+
+func (x *CreateSensorTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSensorTypeRequest.ProtoReflect.Descriptor instead.
+func (*CreateSensorTypeRequest) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CreateSensorTypeRequest) GetTypeName() string {
+	if x != nil {
+		return x.TypeName
+	}
+	return ""
+}
+
+func (x *CreateSensorTypeRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type GetSensorTypeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TypeId        int32                  `protobuf:"varint,1,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSensorTypeRequest) Reset() {
+	*x = GetSensorTypeRequest{}
+	mi := &file_traffic_flow_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSensorTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSensorTypeRequest) ProtoMessage() {}
+
+func (x *GetSensorTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSensorTypeRequest.ProtoReflect.Descriptor instead.
+func (*GetSensorTypeRequest) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetSensorTypeRequest) GetTypeId() int32 {
+	if x != nil {
+		return x.TypeId
+	}
+	return 0
+}
+
+type UpdateSensorTypeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TypeId        int32                  `protobuf:"varint,1,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`
+	TypeName      string                 `protobuf:"bytes,2,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSensorTypeRequest) Reset() {
+	*x = UpdateSensorTypeRequest{}
+	mi := &file_traffic_flow_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSensorTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSensorTypeRequest) ProtoMessage() {}
+
+func (x *UpdateSensorTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSensorTypeRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSensorTypeRequest) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateSensorTypeRequest) GetTypeId() int32 {
+	if x != nil {
+		return x.TypeId
+	}
+	return 0
+}
+
+func (x *UpdateSensorTypeRequest) GetTypeName() string {
+	if x != nil {
+		return x.TypeName
+	}
+	return ""
+}
+
+func (x *UpdateSensorTypeRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type DeleteSensorTypeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TypeId        int32                  `protobuf:"varint,1,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSensorTypeRequest) Reset() {
+	*x = DeleteSensorTypeRequest{}
+	mi := &file_traffic_flow_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSensorTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSensorTypeRequest) ProtoMessage() {}
+
+func (x *DeleteSensorTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSensorTypeRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSensorTypeRequest) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteSensorTypeRequest) GetTypeId() int32 {
+	if x != nil {
+		return x.TypeId
+	}
+	return 0
+}
+
+type ListSensorTypesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SensorTypes   []*SensorType          `protobuf:"bytes,1,rep,name=sensor_types,json=sensorTypes,proto3" json:"sensor_types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSensorTypesResponse) Reset() {
+	*x = ListSensorTypesResponse{}
+	mi := &file_traffic_flow_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSensorTypesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSensorTypesResponse) ProtoMessage() {}
+
+func (x *ListSensorTypesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSensorTypesResponse.ProtoReflect.Descriptor instead.
+func (*ListSensorTypesResponse) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListSensorTypesResponse) GetSensorTypes() []*SensorType {
+	if x != nil {
+		return x.SensorTypes
+	}
+	return nil
+}
+
+type CreateSensorRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Latitude  float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	TypeId    int32                  `protobuf:"varint,3,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`
+	// Date formatted as YYYY-MM-DD
+	InstallationDate string `protobuf:"bytes,4,opt,name=installation_date,json=installationDate,proto3" json:"installation_date,omitempty"`
+	Status           string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateSensorRequest) Reset() {
+	*x = CreateSensorRequest{}
+	mi := &file_traffic_flow_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSensorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSensorRequest) ProtoMessage() {}
+
+func (x *CreateSensorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSensorRequest.ProtoReflect.Descriptor instead.
+func (*CreateSensorRequest) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateSensorRequest) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *CreateSensorRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *CreateSensorRequest) GetTypeId() int32 {
+	if x != nil {
+		return x.TypeId
+	}
+	return 0
+}
+
+func (x *CreateSensorRequest) GetInstallationDate() string {
+	if x != nil {
+		return x.InstallationDate
+	}
+	return ""
+}
+
+func (x *CreateSensorRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type GetSensorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SensorId      int32                  `protobuf:"varint,1,opt,name=sensor_id,json=sensorId,proto3" json:"sensor_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSensorRequest) Reset() {
+	*x = GetSensorRequest{}
+	mi := &file_traffic_flow_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSensorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSensorRequest) ProtoMessage() {}
+
+func (x *GetSensorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSensorRequest.ProtoReflect.Descriptor instead.
+func (*GetSensorRequest) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetSensorRequest) GetSensorId() int32 {
+	if x != nil {
+		return x.SensorId
+	}
+	return 0
+}
+
+type UpdateSensorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SensorId      int32                  `protobuf:"varint,1,opt,name=sensor_id,json=sensorId,proto3" json:"sensor_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSensorRequest) Reset() {
+	*x = UpdateSensorRequest{}
+	mi := &file_traffic_flow_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSensorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSensorRequest) ProtoMessage() {}
+
+func (x *UpdateSensorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSensorRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSensorRequest) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdateSensorRequest) GetSensorId() int32 {
+	if x != nil {
+		return x.SensorId
+	}
+	return 0
+}
+
+func (x *UpdateSensorRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type DeleteSensorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SensorId      int32                  `protobuf:"varint,1,opt,name=sensor_id,json=sensorId,proto3" json:"sensor_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSensorRequest) Reset() {
+	*x = DeleteSensorRequest{}
+	mi := &file_traffic_flow_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSensorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSensorRequest) ProtoMessage() {}
+
+func (x *DeleteSensorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSensorRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSensorRequest) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeleteSensorRequest) GetSensorId() int32 {
+	if x != nil {
+		return x.SensorId
+	}
+	return 0
+}
+
+type GetSensorsByTypeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TypeId        int32                  `protobuf:"varint,1,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSensorsByTypeRequest) Reset() {
+	*x = GetSensorsByTypeRequest{}
+	mi := &file_traffic_flow_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSensorsByTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSensorsByTypeRequest) ProtoMessage() {}
+
+func (x *GetSensorsByTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSensorsByTypeRequest.ProtoReflect.Descriptor instead.
+func (*GetSensorsByTypeRequest) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetSensorsByTypeRequest) GetTypeId() int32 {
+	if x != nil {
+		return x.TypeId
+	}
+	return 0
+}
+
+type ListSensorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sensors       []*Sensor              `protobuf:"bytes,1,rep,name=sensors,proto3" json:"sensors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSensorsResponse) Reset() {
+	*x = ListSensorsResponse{}
+	mi := &file_traffic_flow_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSensorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSensorsResponse) ProtoMessage() {}
+
+func (x *ListSensorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_traffic_flow_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSensorsResponse.ProtoReflect.Descriptor instead.
+func (*ListSensorsResponse) Descriptor() ([]byte, []int) {
+	return file_traffic_flow_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListSensorsResponse) GetSensors() []*Sensor {
+	if x != nil {
+		return x.Sensors
+	}
+	return nil
+}
+
+var File_traffic_flow_proto protoreflect.FileDescriptor
+
+var file_traffic_flow_proto_rawDesc = string([]byte{
+	0x0a, 0x12, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x5f, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c, 0x6f,
+	0x77, 0x2e, 0x76, 0x31, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0xde, 0x01, 0x0a, 0x0e, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x52, 0x65,
+	0x61, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72,
+	0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x5f, 0x76, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x66,
+	0x66, 0x69, 0x63, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x76, 0x65,
+	0x72, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0c, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x53, 0x70, 0x65, 0x65, 0x64, 0x12, 0x29,
+	0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x67, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x22, 0xeb, 0x02, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x44,
+	0x61, 0x74, 0x75, 0x6d, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x49,
+	0x64, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x25, 0x0a, 0x0e, 0x74,
+	0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x56, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x70,
+	0x65, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x61, 0x76, 0x65, 0x72, 0x61,
+	0x67, 0x65, 0x53, 0x70, 0x65, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x67, 0x65,
+	0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76,
+	0x65, 0x6c, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x73, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x73, 0x4c, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c,
+	0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c,
+	0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67,
+	0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x3a, 0x0a, 0x19, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65,
+	0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x17, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x65, 0x64, 0x43, 0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x22, 0x6c, 0x0a, 0x14, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x54, 0x72, 0x61, 0x66, 0x66,
+	0x69, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x72, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63,
+	0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x52,
+	0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x72, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x22,
+	0xaf, 0x01, 0x0a, 0x10, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69,
+	0x63, 0x41, 0x63, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x12, 0x37, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1d, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c, 0x6f, 0x77, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65,
+	0x52, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x30, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x54,
+	0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x44, 0x61, 0x74, 0x75, 0x6d, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x22, 0x34, 0x0a, 0x13, 0x57, 0x61, 0x74, 0x63, 0x68, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69,
+	0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x09, 0x73, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x49, 0x64, 0x73, 0x22, 0xd6, 0x03, 0x0a, 0x0d, 0x54, 0x72, 0x61, 0x66,
+	0x66, 0x69, 0x63, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x36, 0x0a, 0x04, 0x6b, 0x69, 0x6e,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69,
+	0x63, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e,
+	0x64, 0x12, 0x30, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x44, 0x61, 0x74, 0x75, 0x6d, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x4b, 0x0a, 0x0d, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x5f, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x74, 0x72, 0x61,
+	0x66, 0x66, 0x69, 0x63, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0c, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x38, 0x0a, 0x07, 0x61, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c, 0x6f, 0x77, 0x2e,
+	0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x41, 0x6e, 0x6f, 0x6d, 0x61, 0x6c,
+	0x79, 0x52, 0x07, 0x61, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x79, 0x12, 0x4a, 0x0a, 0x10, 0x63, 0x6f,
+	0x6e, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c,
+	0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x87, 0x01, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12,
+	0x14, 0x0a, 0x10, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x52, 0x45,
+	0x43, 0x4f, 0x52, 0x44, 0x45, 0x44, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x4b, 0x49, 0x4e, 0x44,
+	0x5f, 0x53, 0x4e, 0x41, 0x50, 0x53, 0x48, 0x4f, 0x54, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x4b,
+	0x49, 0x4e, 0x44, 0x5f, 0x53, 0x45, 0x4e, 0x53, 0x4f, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x10, 0x03, 0x12, 0x10, 0x0a, 0x0c, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x41, 0x4e, 0x4f, 0x4d,
+	0x41, 0x4c, 0x59, 0x10, 0x04, 0x12, 0x19, 0x0a, 0x15, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x43, 0x4f,
+	0x4e, 0x47, 0x45, 0x53, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x49, 0x4e, 0x44, 0x45, 0x58, 0x10, 0x05,
+	0x22, 0xf6, 0x01, 0x0a, 0x16, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x73,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x72, 0x6f, 0x6d,
+	0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66,
+	0x72, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x6f, 0x5f,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x6f,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x3c, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73,
+	0x65, 0x65, 0x6e, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65,
+	0x65, 0x6e, 0x41, 0x74, 0x12, 0x43, 0x0a, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x65, 0x64, 0x41, 0x74, 0x22, 0x9b, 0x02, 0x0a, 0x0e, 0x54, 0x72,
+	0x61, 0x66, 0x66, 0x69, 0x63, 0x41, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x79, 0x12, 0x1d, 0x0a, 0x0a,
+	0x61, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x61, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x79, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x08, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x65, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x64, 0x65, 0x76,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x73, 0x74, 0x64, 0x64, 0x65, 0x76, 0x12, 0x17,
+	0x0a, 0x07, 0x7a, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x06, 0x7a, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x22, 0xb0, 0x01, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x67,
+	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x38, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x73, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x61, 0x76, 0x67, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x08, 0x61, 0x76, 0x67, 0x53, 0x70, 0x65, 0x65, 0x64, 0x22, 0x64, 0x0a, 0x0a, 0x53, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x79, 0x70, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x74, 0x79, 0x70, 0x65, 0x49,
+	0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x79, 0x70, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20,
+	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0x85, 0x02, 0x0a, 0x06, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x73,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75,
+	0x64, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x74, 0x79, 0x70, 0x65, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x11, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x61, 0x74, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x1b, 0x0a, 0x09, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x79, 0x70, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a,
+	0x10, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x74, 0x79, 0x70, 0x65, 0x44, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x58, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x79, 0x70, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x2f, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x79,
+	0x70, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x74, 0x79, 0x70,
+	0x65, 0x49, 0x64, 0x22, 0x71, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x74, 0x79, 0x70, 0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x79, 0x70, 0x65, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x79, 0x70, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x32, 0x0a, 0x17, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x74, 0x79, 0x70, 0x65, 0x49, 0x64, 0x22, 0x58, 0x0a, 0x17, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x0c, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x72,
+	0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0b, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x54,
+	0x79, 0x70, 0x65, 0x73, 0x22, 0xad, 0x01, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08,
+	0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67,
+	0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e,
+	0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x74, 0x79, 0x70, 0x65, 0x49, 0x64, 0x12,
+	0x2b, 0x0a, 0x11, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x22, 0x2f, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x49, 0x64, 0x22, 0x4a, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x22, 0x32, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x49, 0x64, 0x22, 0x32, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x53, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x73, 0x42, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x17, 0x0a, 0x07, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x74, 0x79, 0x70, 0x65, 0x49, 0x64, 0x22, 0x47, 0x0a, 0x13, 0x4c, 0x69, 0x73,
+	0x74, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x30, 0x0a, 0x07, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c, 0x6f, 0x77, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x52, 0x07, 0x73, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x73, 0x2a, 0xc4, 0x01, 0x0a, 0x0d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4f, 0x75, 0x74,
+	0x63, 0x6f, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x1a, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x4f,
+	0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x4f,
+	0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x01,
+	0x12, 0x1a, 0x0a, 0x16, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x4f, 0x55, 0x54, 0x43, 0x4f,
+	0x4d, 0x45, 0x5f, 0x53, 0x4b, 0x49, 0x50, 0x50, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17,
+	0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x52,
+	0x45, 0x4a, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x03, 0x12, 0x1e, 0x0a, 0x1a, 0x52, 0x45, 0x43,
+	0x4f, 0x52, 0x44, 0x5f, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x51, 0x55, 0x41, 0x52,
+	0x41, 0x4e, 0x54, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x04, 0x12, 0x1e, 0x0a, 0x1a, 0x52, 0x45, 0x43,
+	0x4f, 0x52, 0x44, 0x5f, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x4f, 0x56, 0x45, 0x52,
+	0x57, 0x52, 0x49, 0x54, 0x54, 0x45, 0x4e, 0x10, 0x05, 0x32, 0xc3, 0x01, 0x0a, 0x0e, 0x54, 0x72,
+	0x61, 0x66, 0x66, 0x69, 0x63, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5b, 0x0a, 0x0d,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x12, 0x24, 0x2e,
+	0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c, 0x6f,
+	0x77, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x54, 0x72, 0x61, 0x66, 0x66,
+	0x69, 0x63, 0x41, 0x63, 0x6b, 0x28, 0x01, 0x30, 0x01, 0x12, 0x54, 0x0a, 0x0c, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x12, 0x23, 0x2e, 0x74, 0x72, 0x61, 0x66,
+	0x66, 0x69, 0x63, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
+	0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e,
+	0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x30, 0x01, 0x32,
+	0xea, 0x07, 0x0a, 0x0d, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x57, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x27, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66,
+	0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a,
+	0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x52, 0x0a, 0x0f, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x27, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66,
+	0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51,
+	0x0a, 0x0d, 0x47, 0x65, 0x74, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x24, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66,
+	0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x57, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x27, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66,
+	0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a,
+	0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x53, 0x0a, 0x10, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x27,
+	0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
+	0x4b, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x12,
+	0x23, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c,
+	0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x12, 0x4a, 0x0a, 0x0b,
+	0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x23, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c, 0x6f,
+	0x77, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x12, 0x20, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66,
+	0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69,
+	0x63, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x12,
+	0x4b, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x12,
+	0x23, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c,
+	0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x12, 0x4b, 0x0a, 0x0c,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x12, 0x23, 0x2e, 0x74,
+	0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x4f, 0x0a, 0x10, 0x47, 0x65, 0x74,
+	0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x73, 0x12, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x23, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66,
+	0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x10, 0x47, 0x65,
+	0x74, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x73, 0x42, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x27,
+	0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x73, 0x42, 0x79, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69,
+	0x63, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x1c, 0x5a, 0x1a,
+	0x73, 0x6d, 0x61, 0x72, 0x74, 0x5f, 0x63, 0x69, 0x74, 0x79, 0x2f, 0x74, 0x72, 0x61, 0x66, 0x66,
+	0x69, 0x63, 0x5f, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+})
+
+var (
+	file_traffic_flow_proto_rawDescOnce sync.Once
+	file_traffic_flow_proto_rawDescData []byte
+)
+
+func file_traffic_flow_proto_rawDescGZIP() []byte {
+	file_traffic_flow_proto_rawDescOnce.Do(func() {
+		file_traffic_flow_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_traffic_flow_proto_rawDesc), len(file_traffic_flow_proto_rawDesc)))
+	})
+	return file_traffic_flow_proto_rawDescData
+}
+
+var file_traffic_flow_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_traffic_flow_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_traffic_flow_proto_goTypes = []any{
+	(RecordOutcome)(0),              // 0: trafficflow.v1.RecordOutcome
+	(TrafficUpdate_Kind)(0),         // 1: trafficflow.v1.TrafficUpdate.Kind
+	(*TrafficReading)(nil),          // 2: trafficflow.v1.TrafficReading
+	(*TrafficDatum)(nil),            // 3: trafficflow.v1.TrafficDatum
+	(*RecordTrafficRequest)(nil),    // 4: trafficflow.v1.RecordTrafficRequest
+	(*RecordTrafficAck)(nil),        // 5: trafficflow.v1.RecordTrafficAck
+	(*WatchTrafficRequest)(nil),     // 6: trafficflow.v1.WatchTrafficRequest
+	(*TrafficUpdate)(nil),           // 7: trafficflow.v1.TrafficUpdate
+	(*SensorStatusTransition)(nil),  // 8: trafficflow.v1.SensorStatusTransition
+	(*TrafficAnomaly)(nil),          // 9: trafficflow.v1.TrafficAnomaly
+	(*CongestionIndex)(nil),         // 10: trafficflow.v1.CongestionIndex
+	(*SensorType)(nil),              // 11: trafficflow.v1.SensorType
+	(*Sensor)(nil),                  // 12: trafficflow.v1.Sensor
+	(*CreateSensorTypeRequest)(nil), // 13: trafficflow.v1.CreateSensorTypeRequest
+	(*GetSensorTypeRequest)(nil),    // 14: trafficflow.v1.GetSensorTypeRequest
+	(*UpdateSensorTypeRequest)(nil), // 15: trafficflow.v1.UpdateSensorTypeRequest
+	(*DeleteSensorTypeRequest)(nil), // 16: trafficflow.v1.DeleteSensorTypeRequest
+	(*ListSensorTypesResponse)(nil), // 17: trafficflow.v1.ListSensorTypesResponse
+	(*CreateSensorRequest)(nil),     // 18: trafficflow.v1.CreateSensorRequest
+	(*GetSensorRequest)(nil),        // 19: trafficflow.v1.GetSensorRequest
+	(*UpdateSensorRequest)(nil),     // 20: trafficflow.v1.UpdateSensorRequest
+	(*DeleteSensorRequest)(nil),     // 21: trafficflow.v1.DeleteSensorRequest
+	(*GetSensorsByTypeRequest)(nil), // 22: trafficflow.v1.GetSensorsByTypeRequest
+	(*ListSensorsResponse)(nil),     // 23: trafficflow.v1.ListSensorsResponse
+	(*timestamppb.Timestamp)(nil),   // 24: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),           // 25: google.protobuf.Empty
+}
+var file_traffic_flow_proto_depIdxs = []int32{
+	24, // 0: trafficflow.v1.TrafficReading.timestamp:type_name -> google.protobuf.Timestamp
+	24, // 1: trafficflow.v1.TrafficDatum.timestamp:type_name -> google.protobuf.Timestamp
+	2,  // 2: trafficflow.v1.RecordTrafficRequest.reading:type_name -> trafficflow.v1.TrafficReading
+	0,  // 3: trafficflow.v1.RecordTrafficAck.outcome:type_name -> trafficflow.v1.RecordOutcome
+	3,  // 4: trafficflow.v1.RecordTrafficAck.data:type_name -> trafficflow.v1.TrafficDatum
+	1,  // 5: trafficflow.v1.TrafficUpdate.kind:type_name -> trafficflow.v1.TrafficUpdate.Kind
+	3,  // 6: trafficflow.v1.TrafficUpdate.data:type_name -> trafficflow.v1.TrafficDatum
+	8,  // 7: trafficflow.v1.TrafficUpdate.sensor_status:type_name -> trafficflow.v1.SensorStatusTransition
+	9,  // 8: trafficflow.v1.TrafficUpdate.anomaly:type_name -> trafficflow.v1.TrafficAnomaly
+	10, // 9: trafficflow.v1.TrafficUpdate.congestion_index:type_name -> trafficflow.v1.CongestionIndex
+	24, // 10: trafficflow.v1.SensorStatusTransition.last_seen_at:type_name -> google.protobuf.Timestamp
+	24, // 11: trafficflow.v1.SensorStatusTransition.transitioned_at:type_name -> google.protobuf.Timestamp
+	24, // 12: trafficflow.v1.TrafficAnomaly.timestamp:type_name -> google.protobuf.Timestamp
+	24, // 13: trafficflow.v1.CongestionIndex.timestamp:type_name -> google.protobuf.Timestamp
+	11, // 14: trafficflow.v1.ListSensorTypesResponse.sensor_types:type_name -> trafficflow.v1.SensorType
+	12, // 15: trafficflow.v1.ListSensorsResponse.sensors:type_name -> trafficflow.v1.Sensor
+	4,  // 16: trafficflow.v1.TrafficService.RecordTraffic:input_type -> trafficflow.v1.RecordTrafficRequest
+	6,  // 17: trafficflow.v1.TrafficService.WatchTraffic:input_type -> trafficflow.v1.WatchTrafficRequest
+	13, // 18: trafficflow.v1.SensorService.CreateSensorType:input_type -> trafficflow.v1.CreateSensorTypeRequest
+	25, // 19: trafficflow.v1.SensorService.ListSensorTypes:input_type -> google.protobuf.Empty
+	14, // 20: trafficflow.v1.SensorService.GetSensorType:input_type -> trafficflow.v1.GetSensorTypeRequest
+	15, // 21: trafficflow.v1.SensorService.UpdateSensorType:input_type -> trafficflow.v1.UpdateSensorTypeRequest
+	16, // 22: trafficflow.v1.SensorService.DeleteSensorType:input_type -> trafficflow.v1.DeleteSensorTypeRequest
+	18, // 23: trafficflow.v1.SensorService.CreateSensor:input_type -> trafficflow.v1.CreateSensorRequest
+	25, // 24: trafficflow.v1.SensorService.ListSensors:input_type -> google.protobuf.Empty
+	19, // 25: trafficflow.v1.SensorService.GetSensor:input_type -> trafficflow.v1.GetSensorRequest
+	20, // 26: trafficflow.v1.SensorService.UpdateSensor:input_type -> trafficflow.v1.UpdateSensorRequest
+	21, // 27: trafficflow.v1.SensorService.DeleteSensor:input_type -> trafficflow.v1.DeleteSensorRequest
+	25, // 28: trafficflow.v1.SensorService.GetActiveSensors:input_type -> google.protobuf.Empty
+	22, // 29: trafficflow.v1.SensorService.GetSensorsByType:input_type -> trafficflow.v1.GetSensorsByTypeRequest
+	5,  // 30: trafficflow.v1.TrafficService.RecordTraffic:output_type -> trafficflow.v1.RecordTrafficAck
+	7,  // 31: trafficflow.v1.TrafficService.WatchTraffic:output_type -> trafficflow.v1.TrafficUpdate
+	11, // 32: trafficflow.v1.SensorService.CreateSensorType:output_type -> trafficflow.v1.SensorType
+	17, // 33: trafficflow.v1.SensorService.ListSensorTypes:output_type -> trafficflow.v1.ListSensorTypesResponse
+	11, // 34: trafficflow.v1.SensorService.GetSensorType:output_type -> trafficflow.v1.SensorType
+	11, // 35: trafficflow.v1.SensorService.UpdateSensorType:output_type -> trafficflow.v1.SensorType
+	25, // 36: trafficflow.v1.SensorService.DeleteSensorType:output_type -> google.protobuf.Empty
+	12, // 37: trafficflow.v1.SensorService.CreateSensor:output_type -> trafficflow.v1.Sensor
+	23, // 38: trafficflow.v1.SensorService.ListSensors:output_type -> trafficflow.v1.ListSensorsResponse
+	12, // 39: trafficflow.v1.SensorService.GetSensor:output_type -> trafficflow.v1.Sensor
+	12, // 40: trafficflow.v1.SensorService.UpdateSensor:output_type -> trafficflow.v1.Sensor
+	25, // 41: trafficflow.v1.SensorService.DeleteSensor:output_type -> google.protobuf.Empty
+	23, // 42: trafficflow.v1.SensorService.GetActiveSensors:output_type -> trafficflow.v1.ListSensorsResponse
+	23, // 43: trafficflow.v1.SensorService.GetSensorsByType:output_type -> trafficflow.v1.ListSensorsResponse
+	30, // [30:44] is the sub-list for method output_type
+	16, // [16:30] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_traffic_flow_proto_init() }
+func file_traffic_flow_proto_init() {
+	if File_traffic_flow_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_traffic_flow_proto_rawDesc), len(file_traffic_flow_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_traffic_flow_proto_goTypes,
+		DependencyIndexes: file_traffic_flow_proto_depIdxs,
+		EnumInfos:         file_traffic_flow_proto_enumTypes,
+		MessageInfos:      file_traffic_flow_proto_msgTypes,
+	}.Build()
+	File_traffic_flow_proto = out.File
+	file_traffic_flow_proto_goTypes = nil
+	file_traffic_flow_proto_depIdxs = nil
+}
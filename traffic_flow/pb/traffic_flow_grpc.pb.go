@@ -0,0 +1,695 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: traffic_flow.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TrafficService_RecordTraffic_FullMethodName = "/trafficflow.v1.TrafficService/RecordTraffic"
+	TrafficService_WatchTraffic_FullMethodName  = "/trafficflow.v1.TrafficService/WatchTraffic"
+)
+
+// TrafficServiceClient is the client API for TrafficService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TrafficService ingests and streams traffic readings
+type TrafficServiceClient interface {
+	// RecordTraffic receives a stream of readings and acknowledges every
+	// message as soon as it has been stored or rejected.
+	RecordTraffic(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[RecordTrafficRequest, RecordTrafficAck], error)
+	// WatchTraffic streams newly recorded readings, periodic snapshots of the
+	// latest data, sensor status transitions, anomalies and congestion index
+	// updates, like the /ws/traffic WebSocket.
+	WatchTraffic(ctx context.Context, in *WatchTrafficRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TrafficUpdate], error)
+}
+
+type trafficServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTrafficServiceClient(cc grpc.ClientConnInterface) TrafficServiceClient {
+	return &trafficServiceClient{cc}
+}
+
+func (c *trafficServiceClient) RecordTraffic(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[RecordTrafficRequest, RecordTrafficAck], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TrafficService_ServiceDesc.Streams[0], TrafficService_RecordTraffic_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RecordTrafficRequest, RecordTrafficAck]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TrafficService_RecordTrafficClient = grpc.BidiStreamingClient[RecordTrafficRequest, RecordTrafficAck]
+
+func (c *trafficServiceClient) WatchTraffic(ctx context.Context, in *WatchTrafficRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TrafficUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TrafficService_ServiceDesc.Streams[1], TrafficService_WatchTraffic_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchTrafficRequest, TrafficUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TrafficService_WatchTrafficClient = grpc.ServerStreamingClient[TrafficUpdate]
+
+// TrafficServiceServer is the server API for TrafficService service.
+// All implementations must embed UnimplementedTrafficServiceServer
+// for forward compatibility.
+//
+// TrafficService ingests and streams traffic readings
+type TrafficServiceServer interface {
+	// RecordTraffic receives a stream of readings and acknowledges every
+	// message as soon as it has been stored or rejected.
+	RecordTraffic(grpc.BidiStreamingServer[RecordTrafficRequest, RecordTrafficAck]) error
+	// WatchTraffic streams newly recorded readings, periodic snapshots of the
+	// latest data, sensor status transitions, anomalies and congestion index
+	// updates, like the /ws/traffic WebSocket.
+	WatchTraffic(*WatchTrafficRequest, grpc.ServerStreamingServer[TrafficUpdate]) error
+	mustEmbedUnimplementedTrafficServiceServer()
+}
+
+// UnimplementedTrafficServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTrafficServiceServer struct{}
+
+func (UnimplementedTrafficServiceServer) RecordTraffic(grpc.BidiStreamingServer[RecordTrafficRequest, RecordTrafficAck]) error {
+	return status.Errorf(codes.Unimplemented, "method RecordTraffic not implemented")
+}
+func (UnimplementedTrafficServiceServer) WatchTraffic(*WatchTrafficRequest, grpc.ServerStreamingServer[TrafficUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchTraffic not implemented")
+}
+func (UnimplementedTrafficServiceServer) mustEmbedUnimplementedTrafficServiceServer() {}
+func (UnimplementedTrafficServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeTrafficServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TrafficServiceServer will
+// result in compilation errors.
+type UnsafeTrafficServiceServer interface {
+	mustEmbedUnimplementedTrafficServiceServer()
+}
+
+func RegisterTrafficServiceServer(s grpc.ServiceRegistrar, srv TrafficServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTrafficServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TrafficService_ServiceDesc, srv)
+}
+
+func _TrafficService_RecordTraffic_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TrafficServiceServer).RecordTraffic(&grpc.GenericServerStream[RecordTrafficRequest, RecordTrafficAck]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TrafficService_RecordTrafficServer = grpc.BidiStreamingServer[RecordTrafficRequest, RecordTrafficAck]
+
+func _TrafficService_WatchTraffic_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTrafficRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TrafficServiceServer).WatchTraffic(m, &grpc.GenericServerStream[WatchTrafficRequest, TrafficUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TrafficService_WatchTrafficServer = grpc.ServerStreamingServer[TrafficUpdate]
+
+// TrafficService_ServiceDesc is the grpc.ServiceDesc for TrafficService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TrafficService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "trafficflow.v1.TrafficService",
+	HandlerType: (*TrafficServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RecordTraffic",
+			Handler:       _TrafficService_RecordTraffic_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchTraffic",
+			Handler:       _TrafficService_WatchTraffic_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "traffic_flow.proto",
+}
+
+const (
+	SensorService_CreateSensorType_FullMethodName = "/trafficflow.v1.SensorService/CreateSensorType"
+	SensorService_ListSensorTypes_FullMethodName  = "/trafficflow.v1.SensorService/ListSensorTypes"
+	SensorService_GetSensorType_FullMethodName    = "/trafficflow.v1.SensorService/GetSensorType"
+	SensorService_UpdateSensorType_FullMethodName = "/trafficflow.v1.SensorService/UpdateSensorType"
+	SensorService_DeleteSensorType_FullMethodName = "/trafficflow.v1.SensorService/DeleteSensorType"
+	SensorService_CreateSensor_FullMethodName     = "/trafficflow.v1.SensorService/CreateSensor"
+	SensorService_ListSensors_FullMethodName      = "/trafficflow.v1.SensorService/ListSensors"
+	SensorService_GetSensor_FullMethodName        = "/trafficflow.v1.SensorService/GetSensor"
+	SensorService_UpdateSensor_FullMethodName     = "/trafficflow.v1.SensorService/UpdateSensor"
+	SensorService_DeleteSensor_FullMethodName     = "/trafficflow.v1.SensorService/DeleteSensor"
+	SensorService_GetActiveSensors_FullMethodName = "/trafficflow.v1.SensorService/GetActiveSensors"
+	SensorService_GetSensorsByType_FullMethodName = "/trafficflow.v1.SensorService/GetSensorsByType"
+)
+
+// SensorServiceClient is the client API for SensorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SensorService mirrors the sensor and sensor type CRUD of the HTTP API
+type SensorServiceClient interface {
+	CreateSensorType(ctx context.Context, in *CreateSensorTypeRequest, opts ...grpc.CallOption) (*SensorType, error)
+	ListSensorTypes(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSensorTypesResponse, error)
+	GetSensorType(ctx context.Context, in *GetSensorTypeRequest, opts ...grpc.CallOption) (*SensorType, error)
+	UpdateSensorType(ctx context.Context, in *UpdateSensorTypeRequest, opts ...grpc.CallOption) (*SensorType, error)
+	DeleteSensorType(ctx context.Context, in *DeleteSensorTypeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateSensor(ctx context.Context, in *CreateSensorRequest, opts ...grpc.CallOption) (*Sensor, error)
+	ListSensors(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSensorsResponse, error)
+	GetSensor(ctx context.Context, in *GetSensorRequest, opts ...grpc.CallOption) (*Sensor, error)
+	UpdateSensor(ctx context.Context, in *UpdateSensorRequest, opts ...grpc.CallOption) (*Sensor, error)
+	DeleteSensor(ctx context.Context, in *DeleteSensorRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetActiveSensors(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSensorsResponse, error)
+	GetSensorsByType(ctx context.Context, in *GetSensorsByTypeRequest, opts ...grpc.CallOption) (*ListSensorsResponse, error)
+}
+
+type sensorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSensorServiceClient(cc grpc.ClientConnInterface) SensorServiceClient {
+	return &sensorServiceClient{cc}
+}
+
+func (c *sensorServiceClient) CreateSensorType(ctx context.Context, in *CreateSensorTypeRequest, opts ...grpc.CallOption) (*SensorType, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SensorType)
+	err := c.cc.Invoke(ctx, SensorService_CreateSensorType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorServiceClient) ListSensorTypes(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSensorTypesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSensorTypesResponse)
+	err := c.cc.Invoke(ctx, SensorService_ListSensorTypes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorServiceClient) GetSensorType(ctx context.Context, in *GetSensorTypeRequest, opts ...grpc.CallOption) (*SensorType, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SensorType)
+	err := c.cc.Invoke(ctx, SensorService_GetSensorType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorServiceClient) UpdateSensorType(ctx context.Context, in *UpdateSensorTypeRequest, opts ...grpc.CallOption) (*SensorType, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SensorType)
+	err := c.cc.Invoke(ctx, SensorService_UpdateSensorType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorServiceClient) DeleteSensorType(ctx context.Context, in *DeleteSensorTypeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SensorService_DeleteSensorType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorServiceClient) CreateSensor(ctx context.Context, in *CreateSensorRequest, opts ...grpc.CallOption) (*Sensor, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Sensor)
+	err := c.cc.Invoke(ctx, SensorService_CreateSensor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorServiceClient) ListSensors(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSensorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSensorsResponse)
+	err := c.cc.Invoke(ctx, SensorService_ListSensors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorServiceClient) GetSensor(ctx context.Context, in *GetSensorRequest, opts ...grpc.CallOption) (*Sensor, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Sensor)
+	err := c.cc.Invoke(ctx, SensorService_GetSensor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorServiceClient) UpdateSensor(ctx context.Context, in *UpdateSensorRequest, opts ...grpc.CallOption) (*Sensor, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Sensor)
+	err := c.cc.Invoke(ctx, SensorService_UpdateSensor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorServiceClient) DeleteSensor(ctx context.Context, in *DeleteSensorRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SensorService_DeleteSensor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorServiceClient) GetActiveSensors(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSensorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSensorsResponse)
+	err := c.cc.Invoke(ctx, SensorService_GetActiveSensors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorServiceClient) GetSensorsByType(ctx context.Context, in *GetSensorsByTypeRequest, opts ...grpc.CallOption) (*ListSensorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSensorsResponse)
+	err := c.cc.Invoke(ctx, SensorService_GetSensorsByType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SensorServiceServer is the server API for SensorService service.
+// All implementations must embed UnimplementedSensorServiceServer
+// for forward compatibility.
+//
+// SensorService mirrors the sensor and sensor type CRUD of the HTTP API
+type SensorServiceServer interface {
+	CreateSensorType(context.Context, *CreateSensorTypeRequest) (*SensorType, error)
+	ListSensorTypes(context.Context, *emptypb.Empty) (*ListSensorTypesResponse, error)
+	GetSensorType(context.Context, *GetSensorTypeRequest) (*SensorType, error)
+	UpdateSensorType(context.Context, *UpdateSensorTypeRequest) (*SensorType, error)
+	DeleteSensorType(context.Context, *DeleteSensorTypeRequest) (*emptypb.Empty, error)
+	CreateSensor(context.Context, *CreateSensorRequest) (*Sensor, error)
+	ListSensors(context.Context, *emptypb.Empty) (*ListSensorsResponse, error)
+	GetSensor(context.Context, *GetSensorRequest) (*Sensor, error)
+	UpdateSensor(context.Context, *UpdateSensorRequest) (*Sensor, error)
+	DeleteSensor(context.Context, *DeleteSensorRequest) (*emptypb.Empty, error)
+	GetActiveSensors(context.Context, *emptypb.Empty) (*ListSensorsResponse, error)
+	GetSensorsByType(context.Context, *GetSensorsByTypeRequest) (*ListSensorsResponse, error)
+	mustEmbedUnimplementedSensorServiceServer()
+}
+
+// UnimplementedSensorServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSensorServiceServer struct{}
+
+func (UnimplementedSensorServiceServer) CreateSensorType(context.Context, *CreateSensorTypeRequest) (*SensorType, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSensorType not implemented")
+}
+func (UnimplementedSensorServiceServer) ListSensorTypes(context.Context, *emptypb.Empty) (*ListSensorTypesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSensorTypes not implemented")
+}
+func (UnimplementedSensorServiceServer) GetSensorType(context.Context, *GetSensorTypeRequest) (*SensorType, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSensorType not implemented")
+}
+func (UnimplementedSensorServiceServer) UpdateSensorType(context.Context, *UpdateSensorTypeRequest) (*SensorType, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSensorType not implemented")
+}
+func (UnimplementedSensorServiceServer) DeleteSensorType(context.Context, *DeleteSensorTypeRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSensorType not implemented")
+}
+func (UnimplementedSensorServiceServer) CreateSensor(context.Context, *CreateSensorRequest) (*Sensor, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSensor not implemented")
+}
+func (UnimplementedSensorServiceServer) ListSensors(context.Context, *emptypb.Empty) (*ListSensorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSensors not implemented")
+}
+func (UnimplementedSensorServiceServer) GetSensor(context.Context, *GetSensorRequest) (*Sensor, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSensor not implemented")
+}
+func (UnimplementedSensorServiceServer) UpdateSensor(context.Context, *UpdateSensorRequest) (*Sensor, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSensor not implemented")
+}
+func (UnimplementedSensorServiceServer) DeleteSensor(context.Context, *DeleteSensorRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSensor not implemented")
+}
+func (UnimplementedSensorServiceServer) GetActiveSensors(context.Context, *emptypb.Empty) (*ListSensorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActiveSensors not implemented")
+}
+func (UnimplementedSensorServiceServer) GetSensorsByType(context.Context, *GetSensorsByTypeRequest) (*ListSensorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSensorsByType not implemented")
+}
+func (UnimplementedSensorServiceServer) mustEmbedUnimplementedSensorServiceServer() {}
+func (UnimplementedSensorServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeSensorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SensorServiceServer will
+// result in compilation errors.
+type UnsafeSensorServiceServer interface {
+	mustEmbedUnimplementedSensorServiceServer()
+}
+
+func RegisterSensorServiceServer(s grpc.ServiceRegistrar, srv SensorServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSensorServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SensorService_ServiceDesc, srv)
+}
+
+func _SensorService_CreateSensorType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSensorTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServiceServer).CreateSensorType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorService_CreateSensorType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServiceServer).CreateSensorType(ctx, req.(*CreateSensorTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorService_ListSensorTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServiceServer).ListSensorTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorService_ListSensorTypes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServiceServer).ListSensorTypes(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorService_GetSensorType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSensorTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServiceServer).GetSensorType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorService_GetSensorType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServiceServer).GetSensorType(ctx, req.(*GetSensorTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorService_UpdateSensorType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSensorTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServiceServer).UpdateSensorType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorService_UpdateSensorType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServiceServer).UpdateSensorType(ctx, req.(*UpdateSensorTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorService_DeleteSensorType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSensorTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServiceServer).DeleteSensorType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorService_DeleteSensorType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServiceServer).DeleteSensorType(ctx, req.(*DeleteSensorTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorService_CreateSensor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSensorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServiceServer).CreateSensor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorService_CreateSensor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServiceServer).CreateSensor(ctx, req.(*CreateSensorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorService_ListSensors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServiceServer).ListSensors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorService_ListSensors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServiceServer).ListSensors(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorService_GetSensor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSensorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServiceServer).GetSensor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorService_GetSensor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServiceServer).GetSensor(ctx, req.(*GetSensorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorService_UpdateSensor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSensorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServiceServer).UpdateSensor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorService_UpdateSensor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServiceServer).UpdateSensor(ctx, req.(*UpdateSensorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorService_DeleteSensor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSensorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServiceServer).DeleteSensor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorService_DeleteSensor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServiceServer).DeleteSensor(ctx, req.(*DeleteSensorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorService_GetActiveSensors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServiceServer).GetActiveSensors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorService_GetActiveSensors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServiceServer).GetActiveSensors(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensorService_GetSensorsByType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSensorsByTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServiceServer).GetSensorsByType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensorService_GetSensorsByType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServiceServer).GetSensorsByType(ctx, req.(*GetSensorsByTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SensorService_ServiceDesc is the grpc.ServiceDesc for SensorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SensorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "trafficflow.v1.SensorService",
+	HandlerType: (*SensorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSensorType",
+			Handler:    _SensorService_CreateSensorType_Handler,
+		},
+		{
+			MethodName: "ListSensorTypes",
+			Handler:    _SensorService_ListSensorTypes_Handler,
+		},
+		{
+			MethodName: "GetSensorType",
+			Handler:    _SensorService_GetSensorType_Handler,
+		},
+		{
+			MethodName: "UpdateSensorType",
+			Handler:    _SensorService_UpdateSensorType_Handler,
+		},
+		{
+			MethodName: "DeleteSensorType",
+			Handler:    _SensorService_DeleteSensorType_Handler,
+		},
+		{
+			MethodName: "CreateSensor",
+			Handler:    _SensorService_CreateSensor_Handler,
+		},
+		{
+			MethodName: "ListSensors",
+			Handler:    _SensorService_ListSensors_Handler,
+		},
+		{
+			MethodName: "GetSensor",
+			Handler:    _SensorService_GetSensor_Handler,
+		},
+		{
+			MethodName: "UpdateSensor",
+			Handler:    _SensorService_UpdateSensor_Handler,
+		},
+		{
+			MethodName: "DeleteSensor",
+			Handler:    _SensorService_DeleteSensor_Handler,
+		},
+		{
+			MethodName: "GetActiveSensors",
+			Handler:    _SensorService_GetActiveSensors_Handler,
+		},
+		{
+			MethodName: "GetSensorsByType",
+			Handler:    _SensorService_GetSensorsByType_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "traffic_flow.proto",
+}
@@ -0,0 +1,106 @@
+package peak
+
+import (
+	"fmt"
+	db "smart_city/traffic_flow/db/sqlc"
+	"sort"
+	"time"
+)
+
+const (
+	// Interval is the resolution of the peak-hour factor
+	Interval     = 15 * time.Minute
+	slotsPerHour = int(time.Hour / Interval)
+	slotsPerDay  = 24 * slotsPerHour
+	noon         = slotsPerDay / 2
+)
+
+// Hour is the busiest hour of a half day
+type Hour struct {
+	// Start and End are local times of day such as "07:30"
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// Volume is the number of vehicles in the hour on the average day
+	Volume float64 `json:"volume"`
+	// PeakQuarterVolume is the busiest 15 minutes within the hour
+	PeakQuarterVolume float64 `json:"peak_quarter_volume"`
+	// Factor is the peak-hour factor Volume / (4 × PeakQuarterVolume),
+	// 1 when the hour's flow is even and lower the more it peaks
+	Factor float64 `json:"factor"`
+}
+
+// SensorPeaks are the morning and evening peak hours of a sensor's
+// average day
+type SensorPeaks struct {
+	SensorID int32 `json:"sensor_id"`
+	// Days is the number of local days with readings
+	Days int `json:"days"`
+	// AM and PM are nil without traffic in that half of the day
+	AM *Hour `json:"am"`
+	PM *Hour `json:"pm"`
+}
+
+type profile struct {
+	volumes [slotsPerDay]float64
+	days    map[string]bool
+}
+
+func clock(slot int) string {
+	minutes := slot * int(Interval/time.Minute)
+	return fmt.Sprintf("%02d:%02d", minutes/60%24, minutes%60)
+}
+
+// findPeak returns the busiest run of four slots lying within from to to
+func findPeak(volumes []float64, from, to int) *Hour {
+	var best *Hour
+	for start := from; start+slotsPerHour <= to; start++ {
+		hour := Hour{Start: clock(start), End: clock(start + slotsPerHour)}
+		for _, volume := range volumes[start : start+slotsPerHour] {
+			hour.Volume += volume
+			hour.PeakQuarterVolume = max(hour.PeakQuarterVolume, volume)
+		}
+		if hour.Volume > 0 && (best == nil || hour.Volume > best.Volume) {
+			best = &hour
+		}
+	}
+	if best != nil {
+		best.Factor = best.Volume / (float64(slotsPerHour) * best.PeakQuarterVolume)
+	}
+	return best
+}
+
+// Peaks averages the 15-minute volumes of each sensor over the local days
+// of the range and finds the busiest hour before and after noon. Rows must
+// be bucketed by Interval.
+func Peaks(rows []db.GetTrafficSeriesRow, loc *time.Location) []SensorPeaks {
+	profiles := make(map[int32]*profile)
+	for _, row := range rows {
+		p, ok := profiles[row.SensorID]
+		if !ok {
+			p = &profile{days: make(map[string]bool)}
+			profiles[row.SensorID] = p
+		}
+		local := row.Bucket.Time.In(loc)
+		slot := local.Hour()*slotsPerHour + local.Minute()/int(Interval/time.Minute)
+		p.volumes[slot] += float64(row.SumVolume)
+		p.days[local.Format(time.DateOnly)] = true
+	}
+
+	peaks := make([]SensorPeaks, 0, len(profiles))
+	for sensorID, p := range profiles {
+		days := len(p.days)
+		for slot := range p.volumes {
+			p.volumes[slot] /= float64(days)
+		}
+		peaks = append(peaks, SensorPeaks{
+			SensorID: sensorID,
+			Days:     days,
+			AM:       findPeak(p.volumes[:], 0, noon),
+			PM:       findPeak(p.volumes[:], noon, slotsPerDay),
+		})
+	}
+	sort.Slice(peaks, func(i, j int) bool {
+		return peaks[i].SensorID < peaks[j].SensorID
+	})
+	return peaks
+}
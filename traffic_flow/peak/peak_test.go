@@ -0,0 +1,71 @@
+package peak
+
+import (
+	db "smart_city/traffic_flow/db/sqlc"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func quarter(sensorID int32, at time.Time, volume int64) db.GetTrafficSeriesRow {
+	return db.GetTrafficSeriesRow{
+		Bucket:    pgtype.Timestamp{Time: at.UTC(), Valid: true},
+		SensorID:  sensorID,
+		SumVolume: volume,
+	}
+}
+
+func TestPeaks(t *testing.T) {
+	day := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	rows := []db.GetTrafficSeriesRow{}
+	for d := range 2 {
+		date := day.AddDate(0, 0, d)
+		// Morning peak 07:30-08:30 with a busy 08:00 quarter
+		for i, volume := range []int64{100, 100, 200, 100} {
+			rows = append(rows, quarter(1, date.Add(7*time.Hour+30*time.Minute+time.Duration(i)*Interval), volume))
+		}
+		rows = append(rows, quarter(1, date.Add(6*time.Hour), 50))
+		// Even evening flow on the first day only
+		if d == 0 {
+			for i := range 4 {
+				rows = append(rows, quarter(1, date.Add(17*time.Hour+time.Duration(i)*Interval), 80))
+			}
+		}
+	}
+
+	peaks := Peaks(rows, time.UTC)
+	require.Len(t, peaks, 1)
+	require.Equal(t, 2, peaks[0].Days)
+
+	am := peaks[0].AM
+	require.NotNil(t, am)
+	require.Equal(t, "07:30", am.Start)
+	require.Equal(t, "08:30", am.End)
+	require.Equal(t, 500.0, am.Volume)
+	require.Equal(t, 200.0, am.PeakQuarterVolume)
+	require.InDelta(t, 0.625, am.Factor, 1e-9)
+
+	// Averaged over both days
+	pm := peaks[0].PM
+	require.NotNil(t, pm)
+	require.Equal(t, "17:00", pm.Start)
+	require.Equal(t, 160.0, pm.Volume)
+	require.Equal(t, 1.0, pm.Factor)
+}
+
+func TestPeaksLocalTime(t *testing.T) {
+	loc := time.FixedZone("IST", 5*3600+1800)
+	// 02:30 UTC is 08:00 in India
+	at := time.Date(2026, 10, 12, 2, 30, 0, 0, time.UTC)
+	rows := []db.GetTrafficSeriesRow{}
+	for i := range 4 {
+		rows = append(rows, quarter(3, at.Add(time.Duration(i)*Interval), 40))
+	}
+	peaks := Peaks(rows, loc)
+
+	require.Equal(t, "08:00", peaks[0].AM.Start)
+	require.Equal(t, "09:00", peaks[0].AM.End)
+	require.Nil(t, peaks[0].PM)
+}
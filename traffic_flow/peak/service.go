@@ -0,0 +1,37 @@
+package peak
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"smart_city/traffic_flow/rollup"
+	"time"
+)
+
+var ErrRangeTooLong = errors.New("time range too long")
+
+// MaxRange bounds the raw 15-minute buckets a request reads
+const MaxRange = 92 * 24 * time.Hour
+
+// Service finds the peak hours of sensors
+type Service struct {
+	rollups *rollup.Service
+}
+
+func NewService(rollups *rollup.Service) *Service {
+	return &Service{rollups: rollups}
+}
+
+// Peaks returns the peak hours from start to end of the given sensors, or
+// of all sensors when none are given, with times of day in loc
+func (service *Service) Peaks(ctx context.Context, start, end time.Time, sensorIDs []int32, loc *time.Location) ([]SensorPeaks, rollup.Plan, error) {
+	if end.Sub(start) > MaxRange {
+		return nil, rollup.Plan{}, fmt.Errorf("%w, at most %s", ErrRangeTooLong, MaxRange)
+	}
+
+	rows, plan, err := service.rollups.Series(ctx, Interval, start, end, sensorIDs)
+	if err != nil {
+		return nil, plan, err
+	}
+	return Peaks(rows, loc), plan, nil
+}
@@ -0,0 +1,116 @@
+package quality
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config controls the data quality checks
+type Config struct {
+	// Enabled evaluates all sensors in the background; when false sensors
+	// are evaluated on request only
+	Enabled bool
+	// Window is the span of readings each evaluation covers, ending now
+	Window time.Duration
+	// Interval is how often all sensors are evaluated again
+	Interval time.Duration
+	// MaxSpeed in km/h and MaxVolume bound plausible readings
+	MaxSpeed  float64
+	MaxVolume int32
+	// FlatlineReadings is the number of identical consecutive readings
+	// from which a sensor counts as stuck
+	FlatlineReadings int32
+	// MaxGap is the longest silence tolerated between readings
+	MaxGap time.Duration
+	// MinScore is the score below which a sensor is of low quality
+	MinScore float64
+}
+
+// LoadConfig reads the data quality settings from the environment, falling
+// back to defaults for unset variables
+func LoadConfig() (Config, error) {
+	config := Config{
+		Enabled:          true,
+		Window:           24 * time.Hour,
+		Interval:         15 * time.Minute,
+		MaxSpeed:         200,
+		MaxVolume:        10000,
+		FlatlineReadings: 12,
+		MaxGap:           30 * time.Minute,
+		MinScore:         70,
+	}
+
+	if value := os.Getenv("QUALITY_ENABLED"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return config, fmt.Errorf("cannot parse QUALITY_ENABLED: %w", err)
+		}
+		config.Enabled = enabled
+	}
+
+	floats := map[string]*float64{
+		"QUALITY_MAX_SPEED": &config.MaxSpeed,
+		"QUALITY_MIN_SCORE": &config.MinScore,
+	}
+	for key, target := range floats {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return config, fmt.Errorf("cannot parse %s: %w", key, err)
+		}
+		*target = number
+	}
+
+	ints := map[string]*int32{
+		"QUALITY_MAX_VOLUME":        &config.MaxVolume,
+		"QUALITY_FLATLINE_READINGS": &config.FlatlineReadings,
+	}
+	for key, target := range ints {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		number, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return config, fmt.Errorf("cannot parse %s: %w", key, err)
+		}
+		*target = int32(number)
+	}
+
+	durations := map[string]*time.Duration{
+		"QUALITY_WINDOW":   &config.Window,
+		"QUALITY_INTERVAL": &config.Interval,
+		"QUALITY_MAX_GAP":  &config.MaxGap,
+	}
+	for key, target := range durations {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return config, fmt.Errorf("cannot parse %s: %w", key, err)
+		}
+		*target = duration
+	}
+
+	if config.Window <= 0 || config.Interval <= 0 || config.MaxGap <= 0 {
+		return config, fmt.Errorf("QUALITY_WINDOW, QUALITY_INTERVAL and QUALITY_MAX_GAP must be positive")
+	}
+	if config.MaxSpeed <= 0 || config.MaxVolume <= 0 {
+		return config, fmt.Errorf("QUALITY_MAX_SPEED and QUALITY_MAX_VOLUME must be positive")
+	}
+	if config.FlatlineReadings < 2 {
+		return config, fmt.Errorf("QUALITY_FLATLINE_READINGS must be at least 2")
+	}
+	if config.MinScore < 0 || config.MinScore > 100 {
+		return config, fmt.Errorf("QUALITY_MIN_SCORE must be between 0 and 100")
+	}
+
+	return config, nil
+}
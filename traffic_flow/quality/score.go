@@ -0,0 +1,124 @@
+package quality
+
+import (
+	"fmt"
+	db "smart_city/traffic_flow/db/sqlc"
+	"time"
+)
+
+// Checks a sensor can fail, as reported in reasons
+const (
+	CheckNoReadings   = "no_readings"
+	CheckCompleteness = "completeness"
+	CheckGap          = "gap"
+	CheckFlatline     = "flatline"
+	CheckRange        = "range"
+	CheckConsistency  = "consistency"
+)
+
+// Weights of the checks in the score, summing to 1
+const (
+	completenessWeight = 0.4
+	flatlineWeight     = 0.2
+	rangeWeight        = 0.25
+	consistencyWeight  = 0.15
+)
+
+const (
+	// minCompleteness is the share of expected readings below which a
+	// sensor is reported as incomplete
+	minCompleteness = 0.9
+	// maxInconsistent is the share of inconsistent readings tolerated as
+	// noise, e.g. a single vehicle with no speed measured
+	maxInconsistent = 0.05
+)
+
+// Reason explains a failed check
+type Reason struct {
+	Check  string `json:"check"`
+	Detail string `json:"detail"`
+}
+
+// Report is the data quality of one sensor over a window
+type Report struct {
+	SensorID    int32     `json:"sensor_id"`
+	WindowStart time.Time `json:"window_start"`
+	WindowEnd   time.Time `json:"window_end"`
+	// Score runs from 0 for unusable to 100 for flawless data
+	Score float64 `json:"score"`
+	// Low is set when the score is below the configured minimum
+	Low      bool  `json:"low"`
+	Readings int64 `json:"readings"`
+	// Expected is the readings the window holds at the sensor's usual
+	// reporting interval, its median gap between readings
+	Expected         int64    `json:"expected"`
+	Completeness     float64  `json:"completeness"`
+	MaxGapSeconds    float64  `json:"max_gap_seconds"`
+	LongestFlatline  int64    `json:"longest_flatline"`
+	FlatlineShare    float64  `json:"flatline_share"`
+	RangeViolations  int64    `json:"range_violations"`
+	InconsistentRate float64  `json:"inconsistent_rate"`
+	Reasons          []Reason `json:"reasons"`
+}
+
+// Evaluate scores a sensor's readings from start to end. stats is nil for
+// a sensor without readings in the window.
+func Evaluate(sensorID int32, stats *db.GetSensorQualityStatsRow, start, end time.Time, config Config) Report {
+	report := Report{SensorID: sensorID, WindowStart: start, WindowEnd: end, Reasons: []Reason{}}
+	window := end.Sub(start)
+	if stats == nil || stats.Readings == 0 {
+		report.Low = true
+		report.MaxGapSeconds = window.Seconds()
+		report.Reasons = append(report.Reasons, Reason{CheckNoReadings, fmt.Sprintf("no readings in the last %s", window)})
+		return report
+	}
+	report.Readings = stats.Readings
+	readings := float64(stats.Readings)
+
+	// The silences before the first and after the last reading count as
+	// gaps too
+	maxGap := max(stats.MaxGapSeconds,
+		stats.FirstReading.Time.Sub(start).Seconds(),
+		end.Sub(stats.LastReading.Time).Seconds())
+	report.MaxGapSeconds = maxGap
+	if maxGap > config.MaxGap.Seconds() {
+		report.Reasons = append(report.Reasons, Reason{CheckGap,
+			fmt.Sprintf("no readings for %s, at most %s tolerated", time.Duration(maxGap*float64(time.Second)).Round(time.Second), config.MaxGap)})
+	}
+
+	report.Expected = stats.Readings
+	if stats.MedianGapSeconds > 0 {
+		report.Expected = max(int64(window.Seconds()/stats.MedianGapSeconds), 1)
+	}
+	report.Completeness = min(readings/float64(report.Expected), 1)
+	if report.Completeness < minCompleteness {
+		report.Reasons = append(report.Reasons, Reason{CheckCompleteness,
+			fmt.Sprintf("%d of %d expected readings", report.Readings, report.Expected)})
+	}
+
+	report.LongestFlatline = stats.LongestFlatline
+	report.FlatlineShare = float64(stats.Flatlined) / readings
+	if stats.LongestFlatline >= int64(config.FlatlineReadings) {
+		report.Reasons = append(report.Reasons, Reason{CheckFlatline,
+			fmt.Sprintf("%d identical readings in a row", stats.LongestFlatline)})
+	}
+
+	report.RangeViolations = stats.RangeViolations
+	if stats.RangeViolations > 0 {
+		report.Reasons = append(report.Reasons, Reason{CheckRange,
+			fmt.Sprintf("%d readings with a speed outside 0-%g km/h or a volume outside 0-%d", stats.RangeViolations, config.MaxSpeed, config.MaxVolume)})
+	}
+
+	report.InconsistentRate = float64(stats.Inconsistent) / readings
+	if report.InconsistentRate > maxInconsistent {
+		report.Reasons = append(report.Reasons, Reason{CheckConsistency,
+			fmt.Sprintf("%d readings with a speed but no vehicles or vehicles but no speed", stats.Inconsistent)})
+	}
+
+	report.Score = 100 * (completenessWeight*report.Completeness +
+		flatlineWeight*(1-report.FlatlineShare) +
+		rangeWeight*(1-float64(stats.RangeViolations)/readings) +
+		consistencyWeight*(1-report.InconsistentRate))
+	report.Low = report.Score < config.MinScore
+	return report
+}
@@ -0,0 +1,89 @@
+package quality
+
+import (
+	db "smart_city/traffic_flow/db/sqlc"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = Config{
+	Window:           time.Hour,
+	MaxSpeed:         200,
+	MaxVolume:        10000,
+	FlatlineReadings: 12,
+	MaxGap:           10 * time.Minute,
+	MinScore:         70,
+}
+
+func stats(start, end time.Time, readings int64) db.GetSensorQualityStatsRow {
+	return db.GetSensorQualityStatsRow{
+		SensorID:         1,
+		Readings:         readings,
+		FirstReading:     pgtype.Timestamp{Time: start, Valid: true},
+		LastReading:      pgtype.Timestamp{Time: end.Add(-time.Minute), Valid: true},
+		MedianGapSeconds: 60,
+		MaxGapSeconds:    60,
+		LongestFlatline:  1,
+	}
+}
+
+func checks(report Report) []string {
+	names := []string{}
+	for _, reason := range report.Reasons {
+		names = append(names, reason.Check)
+	}
+	return names
+}
+
+func TestEvaluateClean(t *testing.T) {
+	end := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	start := end.Add(-time.Hour)
+	row := stats(start, end, 60)
+
+	report := Evaluate(1, &row, start, end, testConfig)
+	require.Equal(t, int64(60), report.Expected)
+	require.Equal(t, 1.0, report.Completeness)
+	require.InDelta(t, 100, report.Score, 1e-9)
+	require.False(t, report.Low)
+	require.Empty(t, report.Reasons)
+}
+
+func TestEvaluateFaults(t *testing.T) {
+	end := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	start := end.Add(-time.Hour)
+
+	// Half the readings, a 20 minute gap, stuck for 20 readings, 5 speeds
+	// of 300 km/h and 6 readings with vehicles but no speed
+	row := stats(start, end, 30)
+	row.MaxGapSeconds = 1200
+	row.LongestFlatline = 20
+	row.Flatlined = 20
+	row.RangeViolations = 5
+	row.Inconsistent = 6
+
+	report := Evaluate(1, &row, start, end, testConfig)
+	require.Equal(t, []string{CheckGap, CheckCompleteness, CheckFlatline, CheckRange, CheckConsistency}, checks(report))
+	require.Equal(t, 0.5, report.Completeness)
+	require.InDelta(t, 100*(0.4*0.5+0.2*(1-20.0/30)+0.25*(1-5.0/30)+0.15*(1-6.0/30)), report.Score, 1e-9)
+	require.True(t, report.Low)
+}
+
+func TestEvaluateSilent(t *testing.T) {
+	end := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	start := end.Add(-time.Hour)
+
+	report := Evaluate(2, nil, start, end, testConfig)
+	require.Zero(t, report.Score)
+	require.True(t, report.Low)
+	require.Equal(t, []string{CheckNoReadings}, checks(report))
+
+	// Going silent before the end of the window is a gap
+	row := stats(start, end, 30)
+	row.LastReading = pgtype.Timestamp{Time: start.Add(30 * time.Minute), Valid: true}
+	report = Evaluate(1, &row, start, end, testConfig)
+	require.Contains(t, checks(report), CheckGap)
+	require.Equal(t, 1800.0, report.MaxGapSeconds)
+}
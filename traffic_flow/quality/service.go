@@ -0,0 +1,214 @@
+package quality
+
+import (
+	"context"
+	"errors"
+	"smart_city/traffic_flow/catalog"
+	db "smart_city/traffic_flow/db/sqlc"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+var ErrSensorNotFound = errors.New("sensor not found")
+
+// Service scores the data quality of sensors over a rolling window. With
+// Run all sensors are evaluated every Interval and served from memory;
+// otherwise evaluations happen on request and are kept for an Interval.
+type Service struct {
+	store  *db.Store
+	config Config
+
+	mu          sync.RWMutex
+	reports     map[int32]Report
+	evaluatedAt time.Time
+}
+
+func NewService(store *db.Store, config Config) *Service {
+	return &Service{store: store, config: config}
+}
+
+// evaluate scores the given sensors, or with nil every sensor with
+// readings and every sensor expected to report, over the window ending now
+func (service *Service) evaluate(ctx context.Context, sensorIDs []int32) (map[int32]Report, error) {
+	end := time.Now().UTC()
+	start := end.Add(-service.config.Window)
+	rows, err := service.store.GetSensorQualityStats(ctx, db.GetSensorQualityStatsParams{
+		StartTime:   pgtype.Timestamp{Time: start, Valid: true},
+		EndTime:     pgtype.Timestamp{Time: end, Valid: true},
+		SensorIds:   sensorIDs,
+		MinFlatline: service.config.FlatlineReadings,
+		MaxSpeed:    service.config.MaxSpeed,
+		MaxVolume:   service.config.MaxVolume,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make(map[int32]Report, len(rows))
+	for i := range rows {
+		reports[rows[i].SensorID] = Evaluate(rows[i].SensorID, &rows[i], start, end, service.config)
+	}
+
+	// Sensors that should report but stayed silent score 0. That includes
+	// those the liveness monitor already marked stale or silent; only
+	// sensors an operator took out of service are not expected to report.
+	candidates := sensorIDs
+	if candidates == nil {
+		states, err := service.store.ListSensorStates(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, state := range states {
+			if catalog.AcceptsReadings(state.Status) {
+				candidates = append(candidates, state.SensorID)
+			}
+		}
+	}
+	for _, sensorID := range candidates {
+		if _, ok := reports[sensorID]; !ok {
+			reports[sensorID] = Evaluate(sensorID, nil, start, end, service.config)
+		}
+	}
+	return reports, nil
+}
+
+// EvaluateAll scores every sensor and keeps the results
+func (service *Service) EvaluateAll(ctx context.Context) (map[int32]Report, error) {
+	reports, err := service.evaluate(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	service.mu.Lock()
+	service.reports, service.evaluatedAt = reports, time.Now()
+	service.mu.Unlock()
+	return reports, nil
+}
+
+// Run evaluates all sensors right away and then every Interval until ctx
+// is done
+func (service *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(service.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := service.EvaluateAll(ctx); err != nil {
+			log.Error().Err(err).Msg("cannot evaluate sensor data quality")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// all returns the kept reports, evaluating every sensor again when they
+// are older than Interval
+func (service *Service) all(ctx context.Context) (map[int32]Report, error) {
+	service.mu.RLock()
+	reports, evaluatedAt := service.reports, service.evaluatedAt
+	service.mu.RUnlock()
+
+	if reports != nil && time.Since(evaluatedAt) < service.config.Interval {
+		return reports, nil
+	}
+	return service.EvaluateAll(ctx)
+}
+
+// Sensor returns the data quality of one sensor
+func (service *Service) Sensor(ctx context.Context, sensorID int32) (Report, error) {
+	if _, err := service.store.GetSensorState(ctx, sensorID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Report{}, ErrSensorNotFound
+		}
+		return Report{}, err
+	}
+
+	service.mu.RLock()
+	report, ok := service.reports[sensorID]
+	fresh := time.Since(service.evaluatedAt) < service.config.Interval
+	service.mu.RUnlock()
+	if ok && fresh {
+		return report, nil
+	}
+
+	reports, err := service.evaluate(ctx, []int32{sensorID})
+	if err != nil {
+		return Report{}, err
+	}
+	return reports[sensorID], nil
+}
+
+// Ranking returns the sensors worst first, only those of low quality when
+// lowOnly is set, at most limit of them
+func (service *Service) Ranking(ctx context.Context, lowOnly bool, limit int) ([]Report, error) {
+	reports, err := service.all(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ranking := make([]Report, 0, len(reports))
+	for _, report := range reports {
+		if lowOnly && !report.Low {
+			continue
+		}
+		ranking = append(ranking, report)
+	}
+	sort.Slice(ranking, func(i, j int) bool {
+		if ranking[i].Score != ranking[j].Score {
+			return ranking[i].Score < ranking[j].Score
+		}
+		return ranking[i].SensorID < ranking[j].SensorID
+	})
+	return ranking[:min(limit, len(ranking))], nil
+}
+
+// LowQuality returns the ids of the sensors of low quality
+func (service *Service) LowQuality(ctx context.Context) (map[int32]bool, error) {
+	reports, err := service.all(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	low := make(map[int32]bool)
+	for sensorID, report := range reports {
+		if report.Low {
+			low[sensorID] = true
+		}
+	}
+	return low, nil
+}
+
+// Exclude drops the sensors of low quality from sensorIDs. An empty list
+// stands for all sensors, so it becomes every sensor but those.
+func (service *Service) Exclude(ctx context.Context, sensorIDs []int32) ([]int32, error) {
+	low, err := service.LowQuality(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sensorIDs) == 0 {
+		states, err := service.store.ListSensorStates(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, state := range states {
+			sensorIDs = append(sensorIDs, state.SensorID)
+		}
+	}
+
+	kept := []int32{}
+	for _, sensorID := range sensorIDs {
+		if !low[sensorID] {
+			kept = append(kept, sensorID)
+		}
+	}
+	return kept, nil
+}
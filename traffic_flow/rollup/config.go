@@ -0,0 +1,55 @@
+package rollup
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config decides when analytics queries are answered from a rollup
+type Config struct {
+	// Routing sends queries to the rollups at all; when false every query
+	// scans traffic_data
+	Routing bool
+	// HourlyMinRange and DailyMinRange are the shortest time ranges served
+	// from the hourly and daily rollups
+	HourlyMinRange time.Duration
+	DailyMinRange  time.Duration
+}
+
+// LoadConfig reads the rollup settings from the environment, falling back
+// to defaults for unset variables
+func LoadConfig() (Config, error) {
+	config := Config{
+		Routing:        true,
+		HourlyMinRange: 48 * time.Hour,
+		DailyMinRange:  30 * 24 * time.Hour,
+	}
+
+	if value := os.Getenv("ROLLUP_ROUTING"); value != "" {
+		routing, err := strconv.ParseBool(value)
+		if err != nil {
+			return config, fmt.Errorf("cannot parse ROLLUP_ROUTING: %w", err)
+		}
+		config.Routing = routing
+	}
+
+	durations := map[string]*time.Duration{
+		"ROLLUP_HOURLY_MIN_RANGE": &config.HourlyMinRange,
+		"ROLLUP_DAILY_MIN_RANGE":  &config.DailyMinRange,
+	}
+	for key, target := range durations {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return config, fmt.Errorf("cannot parse %s: %w", key, err)
+		}
+		*target = duration
+	}
+
+	return config, nil
+}
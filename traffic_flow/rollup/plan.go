@@ -0,0 +1,86 @@
+package rollup
+
+import (
+	"errors"
+	"fmt"
+	db "smart_city/traffic_flow/db/sqlc"
+	"time"
+)
+
+var ErrInvalidSource = errors.New("invalid rollup")
+
+// Source is where an analytics query reads its data from
+type Source string
+
+const (
+	SourceRaw    Source = "raw"
+	SourceHourly Source = "hourly"
+	SourceDaily  Source = "daily"
+)
+
+// resolutions lists the rollups from coarsest to finest
+var resolutions = []struct {
+	source     Source
+	view       string
+	resolution time.Duration
+}{
+	{SourceDaily, db.RollupDaily, 24 * time.Hour},
+	{SourceHourly, db.RollupHourly, time.Hour},
+}
+
+// Plan is the source a query reads from and the time range it covers
+type Plan struct {
+	Source    Source    `json:"source"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+// Choose picks the coarsest rollup whose resolution divides width and
+// whose minimum range the query spans. A width of 0 asks for totals over
+// the whole range, which any rollup can answer. Rollups only hold whole
+// buckets, so a range that does not start and end on a bucket boundary is
+// read from a finer rollup or the raw readings rather than widened.
+func (config Config) Choose(start, end time.Time, width time.Duration) Plan {
+	start, end = start.UTC(), end.UTC()
+	if config.Routing {
+		span := end.Sub(start)
+		for _, rollup := range resolutions {
+			if span >= config.minRange(rollup.source) && width%rollup.resolution == 0 &&
+				aligned(start, rollup.resolution) && aligned(end, rollup.resolution) {
+				return Plan{Source: rollup.source, StartTime: start, EndTime: end}
+			}
+		}
+	}
+	return Plan{Source: SourceRaw, StartTime: start, EndTime: end}
+}
+
+func (config Config) minRange(source Source) time.Duration {
+	if source == SourceDaily {
+		return config.DailyMinRange
+	}
+	return config.HourlyMinRange
+}
+
+// ceil rounds t up to a multiple of resolution
+func ceil(t time.Time, resolution time.Duration) time.Time {
+	truncated := t.Truncate(resolution)
+	if truncated.Equal(t) {
+		return t
+	}
+	return truncated.Add(resolution)
+}
+
+// aligned reports whether t is a bucket boundary of the given resolution
+func aligned(t time.Time, resolution time.Duration) bool {
+	return t.Truncate(resolution).Equal(t)
+}
+
+// ParseSource accepts "hourly" or "daily"
+func ParseSource(value string) (Source, error) {
+	for _, rollup := range resolutions {
+		if string(rollup.source) == value {
+			return rollup.source, nil
+		}
+	}
+	return "", fmt.Errorf("%w %q, must be hourly or daily", ErrInvalidSource, value)
+}
@@ -0,0 +1,54 @@
+package rollup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChoose(t *testing.T) {
+	config := Config{Routing: true, HourlyMinRange: 48 * time.Hour, DailyMinRange: 30 * 24 * time.Hour}
+	start := time.Date(2026, 8, 1, 8, 30, 0, 0, time.UTC)
+
+	plan := config.Choose(start, start.Add(6*time.Hour), 0)
+	require.Equal(t, Plan{Source: SourceRaw, StartTime: start, EndTime: start.Add(6 * time.Hour)}, plan)
+
+	// A range between hour boundaries is answered from the raw readings,
+	// since the rollup would count the rest of its first and last hours
+	plan = config.Choose(start, start.Add(72*time.Hour), time.Hour)
+	require.Equal(t, Plan{Source: SourceRaw, StartTime: start, EndTime: start.Add(72 * time.Hour)}, plan)
+
+	hour := time.Date(2026, 8, 1, 8, 0, 0, 0, time.UTC)
+	plan = config.Choose(hour, hour.Add(72*time.Hour), time.Hour)
+	require.Equal(t, Plan{Source: SourceHourly, StartTime: hour, EndTime: hour.Add(72 * time.Hour)}, plan)
+
+	// Buckets that do not line up with a rollup need the raw readings
+	plan = config.Choose(hour, hour.Add(72*time.Hour), 15*time.Minute)
+	require.Equal(t, SourceRaw, plan.Source)
+
+	day := time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC)
+	plan = config.Choose(day, day.Add(60*24*time.Hour), 0)
+	require.Equal(t, Plan{Source: SourceDaily, StartTime: day, EndTime: day.Add(60 * 24 * time.Hour)}, plan)
+
+	// Hour boundaries that are not day boundaries fall back to the hourly
+	// rollup
+	plan = config.Choose(hour, hour.Add(60*24*time.Hour), 0)
+	require.Equal(t, SourceHourly, plan.Source)
+
+	plan = config.Choose(day, day.Add(60*24*time.Hour), 6*time.Hour)
+	require.Equal(t, SourceHourly, plan.Source)
+
+	config.Routing = false
+	plan = config.Choose(day, day.Add(60*24*time.Hour), 0)
+	require.Equal(t, SourceRaw, plan.Source)
+}
+
+func TestParseSource(t *testing.T) {
+	source, err := ParseSource("daily")
+	require.NoError(t, err)
+	require.Equal(t, SourceDaily, source)
+
+	_, err = ParseSource("raw")
+	require.ErrorIs(t, err, ErrInvalidSource)
+}
@@ -0,0 +1,270 @@
+package rollup
+
+import (
+	"context"
+	db "smart_city/traffic_flow/db/sqlc"
+	"smart_city/traffic_flow/geo"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Service answers analytics queries from the raw readings or from the
+// hourly or daily rollup, whichever the requested range and resolution
+// allow. Every method returns the plan it followed.
+type Service struct {
+	store  *db.Store
+	config Config
+}
+
+func NewService(store *db.Store, config Config) *Service {
+	return &Service{store: store, config: config}
+}
+
+func timestamp(t time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{Time: t, Valid: true}
+}
+
+// Series aggregates readings into buckets of the given width
+func (service *Service) Series(ctx context.Context, width time.Duration, start, end time.Time, sensorIDs []int32) ([]db.GetTrafficSeriesRow, Plan, error) {
+	plan := service.config.Choose(start, end, width)
+	interval := pgtype.Interval{Microseconds: width.Microseconds(), Valid: true}
+
+	switch plan.Source {
+	case SourceDaily:
+		rows, err := service.store.GetTrafficSeriesDaily(ctx, db.GetTrafficSeriesDailyParams{
+			BucketWidth: interval,
+			StartTime:   timestamp(plan.StartTime),
+			EndTime:     timestamp(plan.EndTime),
+			SensorIds:   sensorIDs,
+		})
+		series := make([]db.GetTrafficSeriesRow, len(rows))
+		for i, row := range rows {
+			series[i] = db.GetTrafficSeriesRow(row)
+		}
+		return series, plan, err
+	case SourceHourly:
+		rows, err := service.store.GetTrafficSeriesHourly(ctx, db.GetTrafficSeriesHourlyParams{
+			BucketWidth: interval,
+			StartTime:   timestamp(plan.StartTime),
+			EndTime:     timestamp(plan.EndTime),
+			SensorIds:   sensorIDs,
+		})
+		series := make([]db.GetTrafficSeriesRow, len(rows))
+		for i, row := range rows {
+			series[i] = db.GetTrafficSeriesRow(row)
+		}
+		return series, plan, err
+	default:
+		rows, err := service.store.GetTrafficSeries(ctx, db.GetTrafficSeriesParams{
+			BucketWidth: interval,
+			StartTime:   timestamp(plan.StartTime),
+			EndTime:     timestamp(plan.EndTime),
+			SensorIds:   sensorIDs,
+		})
+		return rows, plan, err
+	}
+}
+
+// GapfilledSeries is Series with a row for every bucket of the range and
+// sensor, those without readings having 0 readings and null aggregates
+// next to their locf() and interpolate() fills
+func (service *Service) GapfilledSeries(ctx context.Context, width time.Duration, start, end time.Time, sensorIDs []int32) ([]db.GetTrafficSeriesGapfillRow, Plan, error) {
+	plan := service.config.Choose(start, end, width)
+	interval := pgtype.Interval{Microseconds: width.Microseconds(), Valid: true}
+
+	switch plan.Source {
+	case SourceDaily:
+		rows, err := service.store.GetTrafficSeriesGapfillDaily(ctx, db.GetTrafficSeriesGapfillDailyParams{
+			BucketWidth: interval,
+			StartTime:   timestamp(plan.StartTime),
+			EndTime:     timestamp(plan.EndTime),
+			SensorIds:   sensorIDs,
+		})
+		series := make([]db.GetTrafficSeriesGapfillRow, len(rows))
+		for i, row := range rows {
+			series[i] = db.GetTrafficSeriesGapfillRow(row)
+		}
+		return series, plan, err
+	case SourceHourly:
+		rows, err := service.store.GetTrafficSeriesGapfillHourly(ctx, db.GetTrafficSeriesGapfillHourlyParams{
+			BucketWidth: interval,
+			StartTime:   timestamp(plan.StartTime),
+			EndTime:     timestamp(plan.EndTime),
+			SensorIds:   sensorIDs,
+		})
+		series := make([]db.GetTrafficSeriesGapfillRow, len(rows))
+		for i, row := range rows {
+			series[i] = db.GetTrafficSeriesGapfillRow(row)
+		}
+		return series, plan, err
+	default:
+		rows, err := service.store.GetTrafficSeriesGapfill(ctx, db.GetTrafficSeriesGapfillParams{
+			BucketWidth: interval,
+			StartTime:   timestamp(plan.StartTime),
+			EndTime:     timestamp(plan.EndTime),
+			SensorIds:   sensorIDs,
+		})
+		return rows, plan, err
+	}
+}
+
+// Averages returns a sensor's average volume and speed over a range
+func (service *Service) Averages(ctx context.Context, sensorID int32, start, end time.Time) (db.GetTrafficAveragesRow, Plan, error) {
+	plan := service.config.Choose(start, end, 0)
+
+	switch plan.Source {
+	case SourceDaily:
+		row, err := service.store.GetTrafficAveragesDaily(ctx, db.GetTrafficAveragesDailyParams{
+			SensorID:  sensorID,
+			StartTime: timestamp(plan.StartTime),
+			EndTime:   timestamp(plan.EndTime),
+		})
+		return db.GetTrafficAveragesRow(row), plan, err
+	case SourceHourly:
+		row, err := service.store.GetTrafficAveragesHourly(ctx, db.GetTrafficAveragesHourlyParams{
+			SensorID:  sensorID,
+			StartTime: timestamp(plan.StartTime),
+			EndTime:   timestamp(plan.EndTime),
+		})
+		return db.GetTrafficAveragesRow(row), plan, err
+	default:
+		row, err := service.store.GetTrafficAverages(ctx, db.GetTrafficAveragesParams{
+			SensorID:    sensorID,
+			Timestamp:   timestamp(plan.StartTime),
+			Timestamp_2: timestamp(plan.EndTime),
+		})
+		return row, plan, err
+	}
+}
+
+// HighCongestionAreas returns the sensors with the most high congestion
+// readings in a range, among sensorIDs or all sensors when nil
+func (service *Service) HighCongestionAreas(ctx context.Context, start, end time.Time, limit int32, sensorIDs []int32) ([]db.GetHighCongestionAreasRow, Plan, error) {
+	plan := service.config.Choose(start, end, 0)
+	arg := db.GetHighCongestionAreasParams{
+		StartTime: timestamp(plan.StartTime),
+		EndTime:   timestamp(plan.EndTime),
+		SensorIds: sensorIDs,
+		RowLimit:  limit,
+	}
+
+	switch plan.Source {
+	case SourceDaily:
+		rows, err := service.store.GetHighCongestionAreasDaily(ctx, db.GetHighCongestionAreasDailyParams(arg))
+		areas := make([]db.GetHighCongestionAreasRow, len(rows))
+		for i, row := range rows {
+			areas[i] = db.GetHighCongestionAreasRow(row)
+		}
+		return areas, plan, err
+	case SourceHourly:
+		rows, err := service.store.GetHighCongestionAreasHourly(ctx, db.GetHighCongestionAreasHourlyParams(arg))
+		areas := make([]db.GetHighCongestionAreasRow, len(rows))
+		for i, row := range rows {
+			areas[i] = db.GetHighCongestionAreasRow(row)
+		}
+		return areas, plan, err
+	default:
+		rows, err := service.store.GetHighCongestionAreas(ctx, arg)
+		return rows, plan, err
+	}
+}
+
+// CongestionDistribution counts each sensor's readings per congestion
+// level, for sensorIDs or all sensors when nil
+func (service *Service) CongestionDistribution(ctx context.Context, start, end time.Time, sensorIDs []int32) ([]db.GetSensorCongestionDistributionRow, Plan, error) {
+	plan := service.config.Choose(start, end, 0)
+	arg := db.GetSensorCongestionDistributionParams{
+		StartTime: timestamp(plan.StartTime),
+		EndTime:   timestamp(plan.EndTime),
+		SensorIds: sensorIDs,
+	}
+
+	switch plan.Source {
+	case SourceDaily:
+		rows, err := service.store.GetSensorCongestionDistributionDaily(ctx, db.GetSensorCongestionDistributionDailyParams(arg))
+		distribution := make([]db.GetSensorCongestionDistributionRow, len(rows))
+		for i, row := range rows {
+			distribution[i] = db.GetSensorCongestionDistributionRow(row)
+		}
+		return distribution, plan, err
+	case SourceHourly:
+		rows, err := service.store.GetSensorCongestionDistributionHourly(ctx, db.GetSensorCongestionDistributionHourlyParams(arg))
+		distribution := make([]db.GetSensorCongestionDistributionRow, len(rows))
+		for i, row := range rows {
+			distribution[i] = db.GetSensorCongestionDistributionRow(row)
+		}
+		return distribution, plan, err
+	default:
+		rows, err := service.store.GetSensorCongestionDistribution(ctx, arg)
+		return rows, plan, err
+	}
+}
+
+// SensorSummaries totals the readings of every sensor inside a box
+func (service *Service) SensorSummaries(ctx context.Context, start, end time.Time, box geo.Box) ([]db.GetSensorTrafficSummariesRow, Plan, error) {
+	plan := service.config.Choose(start, end, 0)
+	arg := db.GetSensorTrafficSummariesParams{
+		StartTime: timestamp(plan.StartTime),
+		EndTime:   timestamp(plan.EndTime),
+		MinLon:    box.MinLon,
+		MinLat:    box.MinLat,
+		MaxLon:    box.MaxLon,
+		MaxLat:    box.MaxLat,
+	}
+
+	switch plan.Source {
+	case SourceDaily:
+		rows, err := service.store.GetSensorTrafficSummariesDaily(ctx, db.GetSensorTrafficSummariesDailyParams(arg))
+		summaries := make([]db.GetSensorTrafficSummariesRow, len(rows))
+		for i, row := range rows {
+			summaries[i] = db.GetSensorTrafficSummariesRow(row)
+		}
+		return summaries, plan, err
+	case SourceHourly:
+		rows, err := service.store.GetSensorTrafficSummariesHourly(ctx, db.GetSensorTrafficSummariesHourlyParams(arg))
+		summaries := make([]db.GetSensorTrafficSummariesRow, len(rows))
+		for i, row := range rows {
+			summaries[i] = db.GetSensorTrafficSummariesRow(row)
+		}
+		return summaries, plan, err
+	default:
+		rows, err := service.store.GetSensorTrafficSummaries(ctx, arg)
+		return rows, plan, err
+	}
+}
+
+// Refresh rematerializes the given rollups, or both when sources is empty,
+// for the window from start to end widened to whole buckets. The hourly
+// rollup is refreshed first since the daily one is built on it.
+func (service *Service) Refresh(ctx context.Context, sources []Source, start, end time.Time) ([]Plan, error) {
+	refresh := make(map[Source]bool)
+	for _, source := range sources {
+		refresh[source] = true
+	}
+
+	plans := []Plan{}
+	for i := len(resolutions) - 1; i >= 0; i-- {
+		rollup := resolutions[i]
+		if len(refresh) > 0 && !refresh[rollup.source] {
+			continue
+		}
+
+		plan := Plan{
+			Source:    rollup.source,
+			StartTime: start.UTC().Truncate(rollup.resolution),
+			EndTime:   ceil(end.UTC(), rollup.resolution),
+		}
+		// A window must hold at least one whole bucket
+		if !plan.EndTime.After(plan.StartTime) {
+			plan.EndTime = plan.StartTime.Add(rollup.resolution)
+		}
+
+		err := service.store.RefreshRollup(ctx, rollup.view, timestamp(plan.StartTime), timestamp(plan.EndTime))
+		if err != nil {
+			return plans, err
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
@@ -0,0 +1,81 @@
+package series
+
+import (
+	"errors"
+	"fmt"
+	db "smart_city/traffic_flow/db/sqlc"
+	"strings"
+)
+
+var ErrInvalidAggregate = errors.New("invalid aggregate")
+
+// Aggregate names a function applied to the readings of a bucket
+type Aggregate string
+
+const (
+	AvgVolume   Aggregate = "avg_volume"
+	MinVolume   Aggregate = "min_volume"
+	MaxVolume   Aggregate = "max_volume"
+	SumVolume   Aggregate = "sum_volume"
+	CountVolume Aggregate = "count_volume"
+	AvgSpeed    Aggregate = "avg_speed"
+	MinSpeed    Aggregate = "min_speed"
+	MaxSpeed    Aggregate = "max_speed"
+	SumSpeed    Aggregate = "sum_speed"
+	CountSpeed  Aggregate = "count_speed"
+)
+
+// DefaultAggregates are used when a request names none
+var DefaultAggregates = []Aggregate{AvgVolume, AvgSpeed}
+
+// value extracts the aggregate from a bucket. Both counts are the number of
+// readings since neither column can be NULL.
+func (aggregate Aggregate) value(row db.GetTrafficSeriesRow) (any, bool) {
+	switch aggregate {
+	case AvgVolume:
+		return row.AvgVolume, true
+	case MinVolume:
+		return row.MinVolume, true
+	case MaxVolume:
+		return row.MaxVolume, true
+	case SumVolume:
+		return row.SumVolume, true
+	case AvgSpeed:
+		return row.AvgSpeed, true
+	case MinSpeed:
+		return row.MinSpeed, true
+	case MaxSpeed:
+		return row.MaxSpeed, true
+	case SumSpeed:
+		return row.SumSpeed, true
+	case CountVolume, CountSpeed:
+		return row.Readings, true
+	default:
+		return nil, false
+	}
+}
+
+// ParseAggregates reads aggregate names given as repeated and/or comma
+// separated values, dropping duplicates
+func ParseAggregates(values []string) ([]Aggregate, error) {
+	aggregates := []Aggregate{}
+	seen := make(map[Aggregate]bool)
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			aggregate := Aggregate(strings.TrimSpace(name))
+			if aggregate == "" || seen[aggregate] {
+				continue
+			}
+			if _, ok := aggregate.value(db.GetTrafficSeriesRow{}); !ok {
+				return nil, fmt.Errorf("%w %q", ErrInvalidAggregate, aggregate)
+			}
+			seen[aggregate] = true
+			aggregates = append(aggregates, aggregate)
+		}
+	}
+
+	if len(aggregates) == 0 {
+		return DefaultAggregates, nil
+	}
+	return aggregates, nil
+}
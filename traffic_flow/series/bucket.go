@@ -0,0 +1,69 @@
+package series
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidBucket  = errors.New("invalid bucket width")
+	ErrTooManyBuckets = errors.New("time range holds too many buckets")
+)
+
+const (
+	// MinBucketWidth keeps series from degenerating into raw readings
+	MinBucketWidth = time.Second
+	// MaxBuckets caps the number of points per series
+	MaxBuckets = 10000
+)
+
+// Bucket is the width of the time buckets a series is aggregated into
+type Bucket struct {
+	Width time.Duration
+	label string
+}
+
+// ParseBucket accepts Go durations such as "1m", "15m" or "1h30m", and
+// whole days or weeks such as "1d" or "2w"
+func ParseBucket(value string) (Bucket, error) {
+	value = strings.TrimSpace(value)
+
+	var width time.Duration
+	switch {
+	case strings.HasSuffix(value, "d"), strings.HasSuffix(value, "w"):
+		count, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil {
+			return Bucket{}, fmt.Errorf("%w %q", ErrInvalidBucket, value)
+		}
+		width = time.Duration(count) * 24 * time.Hour
+		if strings.HasSuffix(value, "w") {
+			width *= 7
+		}
+	default:
+		var err error
+		width, err = time.ParseDuration(value)
+		if err != nil {
+			return Bucket{}, fmt.Errorf("%w %q", ErrInvalidBucket, value)
+		}
+	}
+
+	if width < MinBucketWidth {
+		return Bucket{}, fmt.Errorf("%w %q, must be at least %s", ErrInvalidBucket, value, MinBucketWidth)
+	}
+	return Bucket{Width: width, label: value}, nil
+}
+
+func (bucket Bucket) String() string {
+	return bucket.label
+}
+
+// Check makes sure a range from start to end fits into MaxBuckets buckets
+func (bucket Bucket) Check(start, end time.Time) error {
+	if buckets := end.Sub(start) / bucket.Width; buckets > MaxBuckets {
+		return fmt.Errorf("%w: %d buckets of %s, at most %d allowed", ErrTooManyBuckets, buckets, bucket, MaxBuckets)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package series
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	db "smart_city/traffic_flow/db/sqlc"
+	"time"
+)
+
+var ErrInvalidFill = errors.New("invalid fill")
+
+// Fill is how the buckets without readings of a gapfilled series are filled
+type Fill string
+
+const (
+	// FillNull leaves the aggregates of empty buckets null
+	FillNull Fill = "null"
+	// FillLOCF carries the last bucket with readings forward
+	FillLOCF Fill = "locf"
+	// FillLinear interpolates between the buckets with readings around
+	FillLinear Fill = "linear"
+)
+
+// ParseFill reads a fill strategy
+func ParseFill(value string) (Fill, error) {
+	switch fill := Fill(value); fill {
+	case FillNull, FillLOCF, FillLinear:
+		return fill, nil
+	default:
+		return "", fmt.Errorf("%w %q, must be %s, %s or %s", ErrInvalidFill, value, FillNull, FillLOCF, FillLinear)
+	}
+}
+
+// column picks the column of a gapfilled aggregate that fill asks for,
+// nil where it is null
+func (fill Fill) column(null, locf, interpolated driver.Valuer) any {
+	column := null
+	switch fill {
+	case FillLOCF:
+		column = locf
+	case FillLinear:
+		column = interpolated
+	}
+	value, _ := column.Value()
+	return value
+}
+
+// filledValue extracts the aggregate from a gapfilled bucket as filled by
+// the database. Counts are 0 for buckets without readings.
+func (aggregate Aggregate) filledValue(row db.GetTrafficSeriesGapfillRow, fill Fill) any {
+	switch aggregate {
+	case AvgVolume:
+		return fill.column(row.AvgVolume, row.AvgVolumeLocf, row.AvgVolumeInterpolated)
+	case MinVolume:
+		return fill.column(row.MinVolume, row.MinVolumeLocf, row.MinVolumeInterpolated)
+	case MaxVolume:
+		return fill.column(row.MaxVolume, row.MaxVolumeLocf, row.MaxVolumeInterpolated)
+	case SumVolume:
+		return fill.column(row.SumVolume, row.SumVolumeLocf, row.SumVolumeInterpolated)
+	case AvgSpeed:
+		return fill.column(row.AvgSpeed, row.AvgSpeedLocf, row.AvgSpeedInterpolated)
+	case MinSpeed:
+		return fill.column(row.MinSpeed, row.MinSpeedLocf, row.MinSpeedInterpolated)
+	case MaxSpeed:
+		return fill.column(row.MaxSpeed, row.MaxSpeedLocf, row.MaxSpeedInterpolated)
+	case SumSpeed:
+		return fill.column(row.SumSpeed, row.SumSpeedLocf, row.SumSpeedInterpolated)
+	case CountVolume, CountSpeed:
+		return row.Readings
+	default:
+		return nil
+	}
+}
+
+// Speed is the average speed of a gapfilled bucket as fill says, false
+// when it stays null
+func (fill Fill) Speed(row db.GetTrafficSeriesGapfillRow) (float64, bool) {
+	speed, ok := AvgSpeed.filledValue(row, fill).(float64)
+	return speed, ok
+}
+
+// BuildFilled is Build for gapfilled rows, which have a row for every
+// bucket and sensor. Every point is flagged under "filled", true for the
+// buckets without readings, whose aggregates the database filled as fill
+// says.
+func BuildFilled(rows []db.GetTrafficSeriesGapfillRow, aggregates []Aggregate, fill Fill) []Series {
+	series := []Series{}
+	for _, row := range rows {
+		if len(series) == 0 || series[len(series)-1].SensorID != row.SensorID {
+			series = append(series, Series{SensorID: row.SensorID, Points: []Point{}})
+		}
+
+		point := Point{"time": row.Bucket.Time.Format(time.RFC3339), "filled": row.Readings == 0}
+		for _, aggregate := range aggregates {
+			point[string(aggregate)] = aggregate.filledValue(row, fill)
+		}
+
+		current := &series[len(series)-1]
+		current.Points = append(current.Points, point)
+	}
+	return series
+}
@@ -0,0 +1,35 @@
+package series
+
+import (
+	db "smart_city/traffic_flow/db/sqlc"
+	"time"
+)
+
+// Point is one bucket of a series: its start time under "time" and the
+// requested aggregates under their names, ready to be plotted
+type Point map[string]any
+
+// Series holds the buckets of one sensor in chronological order
+type Series struct {
+	SensorID int32   `json:"sensor_id"`
+	Points   []Point `json:"points"`
+}
+
+// Build groups rows ordered by sensor and bucket into one series per sensor
+func Build(rows []db.GetTrafficSeriesRow, aggregates []Aggregate) []Series {
+	series := []Series{}
+	for _, row := range rows {
+		if len(series) == 0 || series[len(series)-1].SensorID != row.SensorID {
+			series = append(series, Series{SensorID: row.SensorID, Points: []Point{}})
+		}
+
+		point := Point{"time": row.Bucket.Time.Format(time.RFC3339)}
+		for _, aggregate := range aggregates {
+			point[string(aggregate)], _ = aggregate.value(row)
+		}
+
+		current := &series[len(series)-1]
+		current.Points = append(current.Points, point)
+	}
+	return series
+}
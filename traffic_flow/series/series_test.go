@@ -0,0 +1,143 @@
+package series
+
+import (
+	db "smart_city/traffic_flow/db/sqlc"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBucket(t *testing.T) {
+	for value, width := range map[string]time.Duration{
+		"1m":    time.Minute,
+		"15m":   15 * time.Minute,
+		"1h30m": 90 * time.Minute,
+		"1d":    24 * time.Hour,
+		"2w":    14 * 24 * time.Hour,
+	} {
+		bucket, err := ParseBucket(value)
+		require.NoError(t, err, value)
+		require.Equal(t, width, bucket.Width, value)
+		require.Equal(t, value, bucket.String())
+	}
+
+	for _, value := range []string{"", "d", "1y", "-1h", "500ms"} {
+		_, err := ParseBucket(value)
+		require.ErrorIs(t, err, ErrInvalidBucket, value)
+	}
+
+	bucket, err := ParseBucket("1m")
+	require.NoError(t, err)
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, bucket.Check(start, start.Add(24*time.Hour)))
+	require.ErrorIs(t, bucket.Check(start, start.Add(30*24*time.Hour)), ErrTooManyBuckets)
+}
+
+func TestParseAggregates(t *testing.T) {
+	aggregates, err := ParseAggregates(nil)
+	require.NoError(t, err)
+	require.Equal(t, DefaultAggregates, aggregates)
+
+	aggregates, err = ParseAggregates([]string{"max_speed, count_volume", "max_speed"})
+	require.NoError(t, err)
+	require.Equal(t, []Aggregate{MaxSpeed, CountVolume}, aggregates)
+
+	_, err = ParseAggregates([]string{"median_speed"})
+	require.ErrorIs(t, err, ErrInvalidAggregate)
+}
+
+func TestBuild(t *testing.T) {
+	start := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	rows := []db.GetTrafficSeriesRow{
+		{SensorID: 1, Bucket: pgtype.Timestamp{Time: start, Valid: true}, Readings: 3, AvgSpeed: 40},
+		{SensorID: 1, Bucket: pgtype.Timestamp{Time: start.Add(time.Hour), Valid: true}, Readings: 2, AvgSpeed: 30},
+		{SensorID: 2, Bucket: pgtype.Timestamp{Time: start, Valid: true}, Readings: 1, AvgSpeed: 50},
+	}
+
+	series := Build(rows, []Aggregate{AvgSpeed, CountSpeed})
+	require.Len(t, series, 2)
+	require.Equal(t, int32(1), series[0].SensorID)
+	require.Equal(t, []Point{
+		{"time": "2026-10-01T08:00:00Z", "avg_speed": 40.0, "count_speed": int64(3)},
+		{"time": "2026-10-01T09:00:00Z", "avg_speed": 30.0, "count_speed": int64(2)},
+	}, series[0].Points)
+	require.Len(t, series[1].Points, 1)
+
+	require.Empty(t, Build(nil, DefaultAggregates))
+}
+
+func TestParseFill(t *testing.T) {
+	for _, value := range []string{"null", "locf", "linear"} {
+		fill, err := ParseFill(value)
+		require.NoError(t, err)
+		require.Equal(t, Fill(value), fill)
+	}
+
+	_, err := ParseFill("previous")
+	require.ErrorIs(t, err, ErrInvalidFill)
+}
+
+func TestBuildFilled(t *testing.T) {
+	start := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	bucket := func(i int) pgtype.Timestamp {
+		return pgtype.Timestamp{Time: start.Add(time.Duration(i) * time.Hour), Valid: true}
+	}
+	speed := func(value float64) pgtype.Float8 {
+		return pgtype.Float8{Float64: value, Valid: true}
+	}
+	volume := func(value int32) pgtype.Int4 {
+		return pgtype.Int4{Int32: value, Valid: true}
+	}
+	// Empty, 40, empty, 10, empty, as the database fills them
+	rows := []db.GetTrafficSeriesGapfillRow{
+		{SensorID: 1, Bucket: bucket(0)},
+		{SensorID: 1, Bucket: bucket(1), Readings: 3,
+			AvgSpeed: speed(40), AvgSpeedLocf: speed(40), AvgSpeedInterpolated: speed(40),
+			MaxVolume: volume(12), MaxVolumeLocf: volume(12), MaxVolumeInterpolated: volume(12)},
+		{SensorID: 1, Bucket: bucket(2),
+			AvgSpeedLocf: speed(40), AvgSpeedInterpolated: speed(25),
+			MaxVolumeLocf: volume(12), MaxVolumeInterpolated: volume(8)},
+		{SensorID: 1, Bucket: bucket(3), Readings: 1,
+			AvgSpeed: speed(10), AvgSpeedLocf: speed(10), AvgSpeedInterpolated: speed(10),
+			MaxVolume: volume(3), MaxVolumeLocf: volume(3), MaxVolumeInterpolated: volume(3)},
+		{SensorID: 1, Bucket: bucket(4), AvgSpeedLocf: speed(10), MaxVolumeLocf: volume(3)},
+		// A sensor without readings still gets its series
+		{SensorID: 2, Bucket: bucket(0)},
+		{SensorID: 2, Bucket: bucket(1)},
+	}
+	aggregates := []Aggregate{AvgSpeed, MaxVolume, CountSpeed}
+
+	values := func(points []Point, key string) []any {
+		column := []any{}
+		for _, point := range points {
+			column = append(column, point[key])
+		}
+		return column
+	}
+
+	series := BuildFilled(rows, aggregates, FillNull)
+	require.Len(t, series, 2)
+	points := series[0].Points
+	require.Equal(t, []any{true, false, true, false, true}, values(points, "filled"))
+	require.Equal(t, []any{nil, 40.0, nil, 10.0, nil}, values(points, "avg_speed"))
+	require.Equal(t, []any{int64(0), int64(3), int64(0), int64(1), int64(0)}, values(points, "count_speed"))
+	require.Equal(t, []any{true, true}, values(series[1].Points, "filled"))
+	require.Equal(t, []any{nil, nil}, values(series[1].Points, "avg_speed"))
+
+	points = BuildFilled(rows, aggregates, FillLOCF)[0].Points
+	require.Equal(t, []any{nil, 40.0, 40.0, 10.0, 10.0}, values(points, "avg_speed"))
+	require.Equal(t, []any{nil, int64(12), int64(12), int64(3), int64(3)}, values(points, "max_volume"))
+
+	points = BuildFilled(rows, aggregates, FillLinear)[0].Points
+	require.Equal(t, []any{nil, 40.0, 25.0, 10.0, nil}, values(points, "avg_speed"))
+	require.Equal(t, []any{nil, int64(12), int64(8), int64(3), nil}, values(points, "max_volume"))
+	require.Equal(t, []any{int64(0), int64(3), int64(0), int64(1), int64(0)}, values(points, "count_speed"))
+
+	speedValue, ok := FillLOCF.Speed(rows[2])
+	require.True(t, ok)
+	require.Equal(t, 40.0, speedValue)
+	_, ok = FillNull.Speed(rows[2])
+	require.False(t, ok)
+}
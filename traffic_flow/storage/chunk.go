@@ -0,0 +1,51 @@
+package storage
+
+import (
+	db "smart_city/traffic_flow/db/sqlc"
+)
+
+// Chunk is a traffic_data chunk with its size before and after compression
+type Chunk struct {
+	db.ListTrafficChunksRow
+	// CompressionRatio is the uncompressed size divided by the compressed
+	// size, or nil for chunks that are not compressed
+	CompressionRatio *float64 `json:"compression_ratio"`
+}
+
+func NewChunk(row db.ListTrafficChunksRow) Chunk {
+	chunk := Chunk{ListTrafficChunksRow: row}
+	if row.IsCompressed && row.BeforeCompressionBytes.Valid && row.AfterCompressionBytes.Valid && row.AfterCompressionBytes.Int64 > 0 {
+		ratio := float64(row.BeforeCompressionBytes.Int64) / float64(row.AfterCompressionBytes.Int64)
+		chunk.CompressionRatio = &ratio
+	}
+	return chunk
+}
+
+// Summary totals the sizes of all chunks of traffic_data
+type Summary struct {
+	Chunks           int   `json:"chunks"`
+	CompressedChunks int   `json:"compressed_chunks"`
+	TotalBytes       int64 `json:"total_bytes"`
+	// UncompressedBytes is what the chunks would take without compression
+	UncompressedBytes int64 `json:"uncompressed_bytes"`
+	// CompressionRatio is UncompressedBytes divided by TotalBytes
+	CompressionRatio *float64 `json:"compression_ratio"`
+}
+
+func Summarize(chunks []Chunk) Summary {
+	summary := Summary{Chunks: len(chunks)}
+	for _, chunk := range chunks {
+		summary.TotalBytes += chunk.TotalBytes.Int64
+		if chunk.IsCompressed && chunk.BeforeCompressionBytes.Valid {
+			summary.CompressedChunks++
+			summary.UncompressedBytes += chunk.BeforeCompressionBytes.Int64
+		} else {
+			summary.UncompressedBytes += chunk.TotalBytes.Int64
+		}
+	}
+	if summary.TotalBytes > 0 {
+		ratio := float64(summary.UncompressedBytes) / float64(summary.TotalBytes)
+		summary.CompressionRatio = &ratio
+	}
+	return summary
+}
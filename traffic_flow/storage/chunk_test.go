@@ -0,0 +1,46 @@
+package storage
+
+import (
+	db "smart_city/traffic_flow/db/sqlc"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func bytes(n int64) pgtype.Int8 {
+	return pgtype.Int8{Int64: n, Valid: true}
+}
+
+func TestNewChunk(t *testing.T) {
+	uncompressed := NewChunk(db.ListTrafficChunksRow{TotalBytes: bytes(4096)})
+	require.Nil(t, uncompressed.CompressionRatio)
+
+	compressed := NewChunk(db.ListTrafficChunksRow{
+		IsCompressed:           true,
+		TotalBytes:             bytes(1024),
+		BeforeCompressionBytes: bytes(8192),
+		AfterCompressionBytes:  bytes(1024),
+	})
+	require.NotNil(t, compressed.CompressionRatio)
+	require.InDelta(t, 8, *compressed.CompressionRatio, 1e-9)
+}
+
+func TestSummarize(t *testing.T) {
+	require.Nil(t, Summarize(nil).CompressionRatio)
+
+	summary := Summarize([]Chunk{
+		NewChunk(db.ListTrafficChunksRow{
+			IsCompressed:           true,
+			TotalBytes:             bytes(1000),
+			BeforeCompressionBytes: bytes(9000),
+			AfterCompressionBytes:  bytes(1000),
+		}),
+		NewChunk(db.ListTrafficChunksRow{TotalBytes: bytes(3000)}),
+	})
+	require.Equal(t, 2, summary.Chunks)
+	require.Equal(t, 1, summary.CompressedChunks)
+	require.Equal(t, int64(4000), summary.TotalBytes)
+	require.Equal(t, int64(12000), summary.UncompressedBytes)
+	require.InDelta(t, 3, *summary.CompressionRatio, 1e-9)
+}
@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"smart_city/traffic_flow/catalog"
+	db "smart_city/traffic_flow/db/sqlc"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Names of the TimescaleDB policy jobs
+const (
+	PolicyCompression = "policy_compression"
+	PolicyRetention   = "policy_retention"
+)
+
+// RollupRefreshDays is how far back the daily rollup refresh policy
+// rematerializes. Raw chunks dropped inside that window would be erased
+// from the rollups as well, so retention must keep at least that much.
+const RollupRefreshDays = 10
+
+// Policies are the compression and retention policies of traffic_data;
+// a nil policy is disabled
+type Policies struct {
+	Compression *db.ListStoragePoliciesRow `json:"compression"`
+	Retention   *db.ListStoragePoliciesRow `json:"retention"`
+}
+
+// Service manages compression and retention of the traffic_data
+// hypertable. Ages are whole days, matching the default chunk interval.
+type Service struct {
+	store *db.Store
+	// keepDays is the youngest age at which chunks may be dropped
+	keepDays int32
+}
+
+// NewService creates a service that refuses to drop readings younger than
+// history, the longest span of traffic_data any other service reads, such
+// as the anomaly baselines or the free-flow speeds of the congestion index.
+// The rollup refresh window is always kept.
+func NewService(store *db.Store, history time.Duration) *Service {
+	keepDays := int32((history + 24*time.Hour - 1) / (24 * time.Hour))
+	return &Service{store: store, keepDays: max(keepDays, RollupRefreshDays)}
+}
+
+func days(n int32) pgtype.Interval {
+	return pgtype.Interval{Days: n, Valid: true}
+}
+
+func checkCompressAfter(compressAfterDays int32) error {
+	if compressAfterDays < 1 {
+		return fmt.Errorf("%w: chunks can only be compressed after at least 1 day", catalog.ErrInvalidRequest)
+	}
+	return nil
+}
+
+func (service *Service) checkDropAfter(dropAfterDays int32) error {
+	if dropAfterDays <= service.keepDays {
+		return fmt.Errorf("%w: chunks can only be dropped after more than %d days, the history the rollups, baselines and forecasts are computed from", catalog.ErrInvalidRequest, service.keepDays)
+	}
+	return nil
+}
+
+func (service *Service) Policies(ctx context.Context) (Policies, error) {
+	var policies Policies
+
+	rows, err := service.store.ListStoragePolicies(ctx)
+	if err != nil {
+		return policies, err
+	}
+	for i := range rows {
+		switch rows[i].Policy {
+		case PolicyCompression:
+			policies.Compression = &rows[i]
+		case PolicyRetention:
+			policies.Retention = &rows[i]
+		}
+	}
+	return policies, nil
+}
+
+// SetCompressionPolicy compresses chunks once they are older than the given
+// number of days, replacing any previous compression policy
+func (service *Service) SetCompressionPolicy(ctx context.Context, compressAfterDays int32) (Policies, error) {
+	if err := checkCompressAfter(compressAfterDays); err != nil {
+		return Policies{}, err
+	}
+	if _, err := service.store.SetCompressionPolicyTx(ctx, days(compressAfterDays)); err != nil {
+		return Policies{}, err
+	}
+	return service.Policies(ctx)
+}
+
+// RemoveCompressionPolicy stops compressing new chunks; chunks that are
+// already compressed stay compressed
+func (service *Service) RemoveCompressionPolicy(ctx context.Context) error {
+	return service.store.RemoveCompressionPolicy(ctx)
+}
+
+// SetRetentionPolicy drops chunks once they are older than the given number
+// of days, replacing any previous retention policy
+func (service *Service) SetRetentionPolicy(ctx context.Context, dropAfterDays int32) (Policies, error) {
+	if err := service.checkDropAfter(dropAfterDays); err != nil {
+		return Policies{}, err
+	}
+	if _, err := service.store.SetRetentionPolicyTx(ctx, days(dropAfterDays)); err != nil {
+		return Policies{}, err
+	}
+	return service.Policies(ctx)
+}
+
+// RemoveRetentionPolicy keeps readings forever
+func (service *Service) RemoveRetentionPolicy(ctx context.Context) error {
+	return service.store.RemoveRetentionPolicy(ctx)
+}
+
+// Chunks lists the chunks of traffic_data oldest first
+func (service *Service) Chunks(ctx context.Context) ([]Chunk, error) {
+	rows, err := service.store.ListTrafficChunks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make([]Chunk, len(rows))
+	for i, row := range rows {
+		chunks[i] = NewChunk(row)
+	}
+	return chunks, nil
+}
+
+// Compress compresses every chunk holding only readings older than the
+// given number of days and returns their names
+func (service *Service) Compress(ctx context.Context, olderThanDays int32) ([]string, error) {
+	if err := checkCompressAfter(olderThanDays); err != nil {
+		return nil, err
+	}
+	return service.store.CompressTrafficChunks(ctx, days(olderThanDays))
+}
+
+// Drop deletes every chunk holding only readings older than the given
+// number of days and returns their names. With dryRun the chunks are only
+// listed.
+func (service *Service) Drop(ctx context.Context, olderThanDays int32, dryRun bool) ([]string, error) {
+	if err := service.checkDropAfter(olderThanDays); err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return service.store.ListTrafficChunksOlderThan(ctx, days(olderThanDays))
+	}
+	return service.store.DropTrafficChunks(ctx, days(olderThanDays))
+}
@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"smart_city/traffic_flow/catalog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDropAfter(t *testing.T) {
+	// Forecasts and baselines read four weeks of readings
+	service := NewService(nil, 28*24*time.Hour)
+	require.ErrorIs(t, service.checkDropAfter(11), catalog.ErrInvalidRequest)
+	require.ErrorIs(t, service.checkDropAfter(28), catalog.ErrInvalidRequest)
+	require.NoError(t, service.checkDropAfter(29))
+
+	// Partial days round up
+	service = NewService(nil, 20*24*time.Hour+time.Hour)
+	require.ErrorIs(t, service.checkDropAfter(21), catalog.ErrInvalidRequest)
+	require.NoError(t, service.checkDropAfter(22))
+
+	// The rollup refresh window is kept however short the history is
+	service = NewService(nil, time.Hour)
+	require.ErrorIs(t, service.checkDropAfter(RollupRefreshDays), catalog.ErrInvalidRequest)
+	require.NoError(t, service.checkDropAfter(RollupRefreshDays+1))
+}
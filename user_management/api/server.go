@@ -26,6 +26,8 @@ type ServerConfig struct {
 	Timeout        time.Duration
 	TrustedProxies []string
 	MaxBodySize    int
+	// IdempotencyTTL is how long responses are kept for Idempotency-Key retries
+	IdempotencyTTL time.Duration
 }
 
 func NewServer(store *db.Store) (*Server, error) {
@@ -52,6 +54,12 @@ func NewServer(store *db.Store) (*Server, error) {
 		return nil, fmt.Errorf("cannot parse refresh token duration: %w", err)
 	}
 
+	idempotencyTTL, err := loadIdempotencyTTL()
+	if err != nil {
+		return nil, err
+	}
+	config.IdempotencyTTL = idempotencyTTL
+
 	server := &Server{
 		store:                store,
 		tokenMaker:           tokenMaker,
@@ -76,12 +84,21 @@ func (server *Server) setupRouter(config ServerConfig) {
 		ctx.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
 
-	router.POST("/users", server.createUser)
+	// Login and token refresh issue credentials and are never answered from
+	// a stored response: the tokens would sit in idempotency_keys in plain
+	// text, and a replay could hand out an access token that has expired.
 	router.POST("/users/login", server.loginUser)
+	router.POST("/users/refresh", authMiddleware(server.tokenMaker), server.renewAccessToken)
 
-	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker))
+	// Retried POST and PUT requests with an Idempotency-Key are answered
+	// from the stored response. On authenticated routes it runs after
+	// authMiddleware so keys are scoped to the user.
+	publicRoutes := router.Group("/").Use(server.idempotencyMiddleware())
+
+	publicRoutes.POST("/users", server.createUser)
+
+	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker), server.idempotencyMiddleware())
 
-	authRoutes.POST("/users/refresh", server.renewAccessToken)
 	authRoutes.POST("/sensors", server.createSensorContribution)
 	authRoutes.GET("/sensors", server.getUserContributions)
 	authRoutes.DELETE("/sensors/:contribution_id", server.deleteSensor)
@@ -90,6 +107,8 @@ func (server *Server) setupRouter(config ServerConfig) {
 }
 
 func (server *Server) Start(address string) error {
+	go server.purgeIdempotencyKeys()
+
 	return server.router.Run(address)
 }
 
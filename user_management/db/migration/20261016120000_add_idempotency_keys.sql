@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "idempotency_keys" (
+  "idempotency_key" VARCHAR(255) NOT NULL,
+  "scope" VARCHAR(512) NOT NULL,
+  "request_hash" bytea NOT NULL,
+  "status_code" int,
+  "content_type" VARCHAR(255),
+  "response_body" bytea,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "expires_at" timestamptz NOT NULL,
+  PRIMARY KEY ("idempotency_key", "scope")
+);
+
+CREATE INDEX "idempotency_keys_expires_at_idx" ON "idempotency_keys" ("expires_at");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "idempotency_keys";
+-- +goose StatementEnd
@@ -57,6 +57,17 @@ func (ns NullServices) Value() (driver.Value, error) {
 	return string(ns.Services), nil
 }
 
+type IdempotencyKey struct {
+	IdempotencyKey string             `json:"idempotency_key"`
+	Scope          string             `json:"scope"`
+	RequestHash    []byte             `json:"request_hash"`
+	StatusCode     pgtype.Int4        `json:"status_code"`
+	ContentType    pgtype.Text        `json:"content_type"`
+	ResponseBody   []byte             `json:"response_body"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	ExpiresAt      pgtype.Timestamptz `json:"expires_at"`
+}
+
 type User struct {
 	UserID       int32            `json:"user_id"`
 	Username     string           `json:"username"`